@@ -0,0 +1,119 @@
+package istiowatcher
+
+import (
+	"context"
+	"fmt"
+	"github.com/amit7itz/goset"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net"
+	"strconv"
+	"strings"
+)
+
+/*
+In Istio ambient mode, pods have no sidecar. L4 traffic is handled by ztunnel, a per-node DaemonSet which reports
+'istio_tcp_connections_opened_total', and L7 traffic is handled by waypoint proxies, which are Envoy deployments that
+report 'istio_requests_total' just like sidecars do.
+*/
+const (
+	AmbientDataplaneModeLabel           = "istio.io/dataplane-mode"
+	AmbientDataplaneModeAmbient         = "ambient"
+	WaypointPodsLabelSelector           = "gateway.istio.io/managed=istio.io-mesh-controller"
+	ZtunnelPodsLabelSelector            = "app=ztunnel"
+	ZtunnelMetricsPort                  = 15020
+	IstioTCPConnectionsOpenedMetricName = "istio_tcp_connections_opened_total"
+	ztunnelMetricsPath                  = "/metrics"
+)
+
+// l4GroupNames are the labels of ztunnel metrics used to build connections, which have no HTTP method or path.
+var l4GroupNames = []string{
+	"source_workload",
+	"source_workload_namespace",
+	"destination_workload",
+	"destination_service_name",
+	"destination_workload_namespace",
+}
+
+func isL4Metric(metric Metric) bool {
+	return strings.HasPrefix(metric.Name, IstioTCPConnectionsOpenedMetricName)
+}
+
+// inNamespaces keeps samples of connections that start or end in one of the given namespaces.
+func inNamespaces(namespaces *goset.Set[string]) func(labels map[string]string) bool {
+	return func(labels map[string]string) bool {
+		return namespaces.Contains(labels["source_workload_namespace"]) || namespaces.Contains(labels["destination_workload_namespace"])
+	}
+}
+
+// getAmbientNamespaces returns the namespaces enrolled in Istio ambient mode, restricted to the given namespace if set.
+func (m *IstioWatcher) getAmbientNamespaces(ctx context.Context, namespace string) (*goset.Set[string], error) {
+	namespaceList, err := m.clientset.CoreV1().Namespaces().List(ctx, v1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", AmbientDataplaneModeLabel, AmbientDataplaneModeAmbient),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+
+	namespaces := goset.NewSet[string]()
+	for _, ns := range namespaceList.Items {
+		if namespace == "" || ns.Name == namespace {
+			namespaces.Add(ns.Name)
+		}
+	}
+	return namespaces, nil
+}
+
+func (m *IstioWatcher) getWaypointPods(ctx context.Context, namespaces *goset.Set[string]) ([]corev1.Pod, error) {
+	waypoints := make([]corev1.Pod, 0)
+	for _, namespace := range namespaces.Items() {
+		podList, err := m.clientset.CoreV1().Pods(namespace).List(ctx, v1.ListOptions{LabelSelector: WaypointPodsLabelSelector})
+		if err != nil {
+			return nil, errors.Wrap(err)
+		}
+		waypoints = append(waypoints, podList.Items...)
+	}
+	return waypoints, nil
+}
+
+func (m *IstioWatcher) getZtunnelPods(ctx context.Context) ([]corev1.Pod, error) {
+	podList, err := m.clientset.CoreV1().Pods("").List(ctx, v1.ListOptions{LabelSelector: ZtunnelPodsLabelSelector})
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	return lo.Filter(podList.Items, func(pod corev1.Pod, _ int) bool {
+		return pod.Status.Phase == corev1.PodRunning && pod.Status.PodIP != ""
+	}), nil
+}
+
+func (m *IstioWatcher) getL4MetricsFromZtunnel(ctx context.Context, pod corev1.Pod, ambientNamespaces *goset.Set[string], metricsChan chan<- *EnvoyMetrics) error {
+	target := fmt.Sprintf("http://%s%s", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(ZtunnelMetricsPort)), ztunnelMetricsPath)
+	metrics, err := m.scrapeMetrics(ctx, target, IstioTCPConnectionsOpenedMetricName, inNamespaces(ambientNamespaces))
+	if err != nil {
+		return errors.Wrap(err)
+	}
+
+	if len(metrics.Stats) == 0 {
+		return nil
+	}
+
+	metricsChan <- metrics
+	return nil
+}
+
+func (m *IstioWatcher) getL4MetricsFromPrometheus(ctx context.Context, ambientNamespaces *goset.Set[string], metricsChan chan<- *EnvoyMetrics) error {
+	query := fmt.Sprintf(`sum by (%s) (%s)`, strings.Join(l4GroupNames, ","), IstioTCPConnectionsOpenedMetricName)
+	metrics, err := m.queryPrometheus(ctx, query, IstioTCPConnectionsOpenedMetricName, inNamespaces(ambientNamespaces))
+	if err != nil {
+		return errors.Wrap(err)
+	}
+
+	if len(metrics.Stats) == 0 {
+		return nil
+	}
+
+	metricsChan <- metrics
+	return nil
+}
@@ -0,0 +1,96 @@
+package istiowatcher
+
+import (
+	"context"
+	"fmt"
+	"github.com/amit7itz/goset"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const ztunnelMetrics = `# TYPE istio_tcp_connections_opened counter
+istio_tcp_connections_opened_total{reporter="destination",source_workload="client",source_workload_namespace="ambient-ns",destination_workload="server",destination_workload_namespace="ambient-ns",destination_service_name="server-service",request_protocol="tcp"} 4
+istio_tcp_connections_opened_total{reporter="destination",source_workload="client",source_workload_namespace="sidecar-ns",destination_workload="server",destination_workload_namespace="sidecar-ns",destination_service_name="server-service",request_protocol="tcp"} 9
+istio_tcp_connections_opened_total{reporter="source",source_workload="client",source_workload_namespace="ambient-ns",destination_workload="unknown",destination_workload_namespace="unknown",destination_service_name="unknown",request_protocol="tcp"} 1
+# EOF
+`
+
+type AmbientTestSuite struct {
+	suite.Suite
+	watcher *IstioWatcher
+}
+
+func (s *AmbientTestSuite) SetupTest() {
+	s.watcher = &IstioWatcher{
+		clientset: fake.NewSimpleClientset(
+			&corev1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "ambient-ns", Labels: map[string]string{AmbientDataplaneModeLabel: AmbientDataplaneModeAmbient}}},
+			&corev1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "other-ambient-ns", Labels: map[string]string{AmbientDataplaneModeLabel: AmbientDataplaneModeAmbient}}},
+			&corev1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "sidecar-ns", Labels: map[string]string{"istio-injection": "enabled"}}},
+			&corev1.Pod{ObjectMeta: v1.ObjectMeta{Name: "waypoint", Namespace: "ambient-ns", Labels: map[string]string{"gateway.istio.io/managed": "istio.io-mesh-controller"}}},
+			&corev1.Pod{ObjectMeta: v1.ObjectMeta{Name: "client", Namespace: "ambient-ns"}},
+		),
+		connections:  map[ConnectionWithPath]time.Time{},
+		metricsCount: map[string]int{},
+		httpClient:   &http.Client{},
+	}
+}
+
+func (s *AmbientTestSuite) TestGetAmbientNamespaces() {
+	namespaces, err := s.watcher.getAmbientNamespaces(context.Background(), "")
+	s.Require().NoError(err)
+	s.Require().ElementsMatch([]string{"ambient-ns", "other-ambient-ns"}, namespaces.Items())
+
+	namespaces, err = s.watcher.getAmbientNamespaces(context.Background(), "sidecar-ns")
+	s.Require().NoError(err)
+	s.Require().True(namespaces.IsEmpty())
+}
+
+func (s *AmbientTestSuite) TestGetWaypointPods() {
+	waypoints, err := s.watcher.getWaypointPods(context.Background(), goset.NewSet("ambient-ns"))
+	s.Require().NoError(err)
+	s.Require().Len(waypoints, 1)
+	s.Require().Equal("waypoint", waypoints[0].Name)
+}
+
+func (s *AmbientTestSuite) TestZtunnelL4Connections() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Require().Equal(ztunnelMetricsPath, r.URL.Path)
+		fmt.Fprint(w, ztunnelMetrics)
+	}))
+	defer server.Close()
+
+	metrics, err := s.watcher.scrapeMetrics(context.Background(), server.URL+ztunnelMetricsPath, IstioTCPConnectionsOpenedMetricName, inNamespaces(goset.NewSet("ambient-ns")))
+	s.Require().NoError(err)
+	s.Require().Len(metrics.Stats, 2)
+
+	metricsChan := make(chan *EnvoyMetrics, 1)
+	metricsChan <- metrics
+	close(metricsChan)
+	s.Require().NoError(s.watcher.convertMetricsToConnections(metricsChan))
+
+	connections := s.watcher.Flush()
+	expected := ConnectionWithPath{
+		SourceWorkload:         "client",
+		SourceNamespace:        "ambient-ns",
+		DestinationWorkload:    "server",
+		DestinationServiceName: "server-service",
+		DestinationNamespace:   "ambient-ns",
+	}
+	s.Require().Len(connections, 1)
+	s.Require().Contains(connections, expected)
+
+	results := ToGraphQLIstioConnections(connections)
+	s.Require().Len(results, 1)
+	s.Require().Equal("", results[0].Path)
+	s.Require().Empty(results[0].Methods)
+}
+
+func TestAmbientTestSuite(t *testing.T) {
+	suite.Run(t, new(AmbientTestSuite))
+}
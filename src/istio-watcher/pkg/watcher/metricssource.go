@@ -0,0 +1,140 @@
+package istiowatcher
+
+import (
+	"context"
+	"fmt"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"github.com/otterize/network-mapper/src/mapper/pkg/config"
+	"github.com/otterize/network-mapper/src/shared/prometheusmetrics"
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Metric sources the Istio watcher can read 'istio_requests_total' from. The exec source requires `pods/exec`
+// permissions, while the others only require network access to the sidecars or to a Prometheus server.
+const (
+	MetricsSourceExec        = "exec"
+	MetricsSourceSidecarHTTP = "sidecar-http"
+	MetricsSourcePrometheus  = prometheusmetrics.SourcePrometheus
+)
+
+const (
+	IstioRequestsTotalMetricName = "istio_requests_total"
+	sidecarPrometheusStatsPath   = "/stats/prometheus"
+	reporterLabel                = "reporter"
+	requestPathLabel             = "request_path"
+)
+
+func validateMetricsSource(source string, prometheusURL string) error {
+	return prometheusmetrics.ValidateSource(source, prometheusURL, config.IstioPrometheusURLKey, MetricsSourceExec, MetricsSourceSidecarHTTP)
+}
+
+// metricNameFromLabels builds a metric name in the same dotted format Envoy uses for its JSON stats, so Prometheus
+// samples can be parsed by extractRegexGroups just like the metrics fetched from the sidecars via exec.
+func metricNameFromLabels(metricName string, labels map[string]string) string {
+	var name strings.Builder
+	name.WriteString(metricName)
+	for _, label := range append([]string{reporterLabel}, GroupNames...) {
+		value, ok := labels[label]
+		if !ok {
+			continue
+		}
+		name.WriteString("." + label + "." + value)
+	}
+	return name.String()
+}
+
+func (m *IstioWatcher) getEnvoyMetricsFromSidecarHTTP(ctx context.Context, pod corev1.Pod, metricsChan chan<- *EnvoyMetrics) error {
+	if !podHasIstioSidecar(pod) || pod.Status.PodIP == "" {
+		return nil
+	}
+
+	target := fmt.Sprintf("http://%s%s?filter=%s",
+		net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(m.sidecarMetricsPort)), sidecarPrometheusStatsPath, IstioRequestsTotalMetricName)
+	metrics, err := m.scrapeMetrics(ctx, target, IstioRequestsTotalMetricName, hasRequestPath)
+	if err != nil {
+		return errors.Wrap(err)
+	}
+
+	if len(metrics.Stats) == 0 {
+		return nil
+	}
+
+	metricsChan <- metrics
+	return nil
+}
+
+func hasRequestPath(labels map[string]string) bool {
+	_, ok := labels[requestPathLabel]
+	return ok
+}
+
+// scrapeMetrics reads a single metric from a Prometheus endpoint, such as the one served by Envoy on port 15090 and
+// merged with the application's metrics by the Istio agent on port 15020, keeping only the samples accepted by keep.
+func (m *IstioWatcher) scrapeMetrics(ctx context.Context, target string, metricName string, keep func(labels map[string]string) bool) (*EnvoyMetrics, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, viper.GetDuration(config.MetricFetchTimeoutKey))
+	defer cancel()
+
+	families, err := prometheusmetrics.Scrape(timeoutCtx, m.httpClient, target)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+
+	metrics := &EnvoyMetrics{}
+	family, ok := families[metricName]
+	if !ok {
+		return metrics, nil
+	}
+
+	for _, metric := range family.GetMetric() {
+		labels := prometheusmetrics.Labels(metric)
+		if !keep(labels) {
+			continue
+		}
+		metrics.Stats = append(metrics.Stats, Metric{Name: metricNameFromLabels(metricName, labels), Value: prometheusmetrics.SampleValue(metric)})
+	}
+
+	return metrics, nil
+}
+
+// getEnvoyMetricsFromPrometheus queries a Prometheus server that already scrapes the sidecars. Samples are summed by
+// the labels the watcher uses, so the counters of different sidecars reporting the same connection are merged.
+func (m *IstioWatcher) getEnvoyMetricsFromPrometheus(ctx context.Context, namespace string, metricsChan chan<- *EnvoyMetrics) error {
+	query := fmt.Sprintf(`sum by (%s) (%s{%s!=""})`, strings.Join(GroupNames, ","), IstioRequestsTotalMetricName, requestPathLabel)
+	metrics, err := m.queryPrometheus(ctx, query, IstioRequestsTotalMetricName, func(labels map[string]string) bool {
+		return namespace == "" || labels["source_workload_namespace"] == namespace || labels["destination_workload_namespace"] == namespace
+	})
+	if err != nil {
+		return errors.Wrap(err)
+	}
+
+	if len(metrics.Stats) == 0 {
+		return nil
+	}
+
+	metricsChan <- metrics
+	return nil
+}
+
+func (m *IstioWatcher) queryPrometheus(ctx context.Context, query string, metricName string, keep func(labels map[string]string) bool) (*EnvoyMetrics, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, viper.GetDuration(config.MetricFetchTimeoutKey))
+	defer cancel()
+
+	samples, err := prometheusmetrics.Query(timeoutCtx, m.httpClient, m.prometheusURL, query)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+
+	metrics := &EnvoyMetrics{}
+	for _, sample := range samples {
+		if !keep(sample.Labels) {
+			continue
+		}
+		metrics.Stats = append(metrics.Stats, Metric{Name: metricNameFromLabels(metricName, sample.Labels), Value: sample.Value})
+	}
+
+	return metrics, nil
+}
@@ -0,0 +1,141 @@
+package istiowatcher
+
+import (
+	"context"
+	"fmt"
+	"github.com/otterize/network-mapper/src/shared/prometheusmetrics"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const sidecarPrometheusStats = `# TYPE istio_requests_total counter
+istio_requests_total{reporter="source",source_workload="client",source_workload_namespace="test-ns",destination_workload="server",destination_workload_namespace="test-ns",destination_service_name="server-service",request_method="GET",request_path="/a-path",response_code="200"} 5
+istio_requests_total{reporter="source",source_workload="client",source_workload_namespace="test-ns",destination_workload="unknown",destination_workload_namespace="unknown",destination_service_name="PassthroughCluster",request_method="GET",request_path="/external",response_code="200"} 2
+istio_requests_total{reporter="source",source_workload="client",source_workload_namespace="test-ns",destination_workload="server",destination_workload_namespace="test-ns",destination_service_name="server-service",response_code="200"} 7
+# TYPE istio_request_bytes_sum counter
+istio_request_bytes_sum{reporter="source",source_workload="client",request_path="/a-path"} 100
+`
+
+const prometheusQueryResult = `{
+  "status": "success",
+  "data": {
+    "resultType": "vector",
+    "result": [
+      {"metric": {"source_workload": "client", "source_workload_namespace": "test-ns", "destination_workload": "server", "destination_workload_namespace": "test-ns", "destination_service_name": "server-service", "request_method": "POST", "request_path": "/b-path"}, "value": [1718000000.123, "12"]},
+      {"metric": {"source_workload": "other", "source_workload_namespace": "other-ns", "destination_workload": "server", "destination_workload_namespace": "other-ns", "destination_service_name": "server-service", "request_method": "GET", "request_path": "/c-path"}, "value": [1718000000.123, "3"]}
+    ]
+  }
+}`
+
+type MetricsSourceTestSuite struct {
+	suite.Suite
+	watcher *IstioWatcher
+}
+
+func (s *MetricsSourceTestSuite) SetupTest() {
+	s.watcher = &IstioWatcher{
+		connections:  map[ConnectionWithPath]time.Time{},
+		metricsCount: map[string]int{},
+		httpClient:   &http.Client{},
+	}
+}
+
+func (s *MetricsSourceTestSuite) convert(metrics ...*EnvoyMetrics) map[ConnectionWithPath]time.Time {
+	metricsChan := make(chan *EnvoyMetrics, len(metrics))
+	for _, m := range metrics {
+		metricsChan <- m
+	}
+	close(metricsChan)
+	s.Require().NoError(s.watcher.convertMetricsToConnections(metricsChan))
+	return s.watcher.Flush()
+}
+
+func (s *MetricsSourceTestSuite) TestScrapeMetrics() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Require().Equal(sidecarPrometheusStatsPath, r.URL.Path)
+		fmt.Fprint(w, sidecarPrometheusStats)
+	}))
+	defer server.Close()
+
+	metrics, err := s.watcher.scrapeMetrics(context.Background(), server.URL+sidecarPrometheusStatsPath, IstioRequestsTotalMetricName, hasRequestPath)
+	s.Require().NoError(err)
+	s.Require().Len(metrics.Stats, 2)
+
+	connections := s.convert(metrics)
+	s.Require().Len(connections, 1)
+	s.Require().Contains(connections, ConnectionWithPath{
+		SourceWorkload:         "client",
+		SourceNamespace:        "test-ns",
+		DestinationWorkload:    "server",
+		DestinationServiceName: "server-service",
+		DestinationNamespace:   "test-ns",
+		RequestPath:            "/a-path",
+		RequestMethod:          "GET",
+	})
+
+	// Counters that did not change since the previous scrape are not reported again
+	s.Require().Empty(s.convert(metrics))
+}
+
+func (s *MetricsSourceTestSuite) TestScrapeMetricsErrorStatus() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := s.watcher.scrapeMetrics(context.Background(), server.URL+sidecarPrometheusStatsPath, IstioRequestsTotalMetricName, hasRequestPath)
+	s.Require().Error(err)
+}
+
+func (s *MetricsSourceTestSuite) TestPrometheusQuery() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Require().Equal(prometheusmetrics.QueryPath, r.URL.Path)
+		s.Require().Contains(r.URL.Query().Get("query"), IstioRequestsTotalMetricName)
+		fmt.Fprint(w, prometheusQueryResult)
+	}))
+	defer server.Close()
+	s.watcher.prometheusURL = server.URL + "/"
+
+	metricsChan := make(chan *EnvoyMetrics, 1)
+	s.Require().NoError(s.watcher.getEnvoyMetricsFromPrometheus(context.Background(), "test-ns", metricsChan))
+	close(metricsChan)
+	s.Require().NoError(s.watcher.convertMetricsToConnections(metricsChan))
+
+	connections := s.watcher.Flush()
+	s.Require().Len(connections, 1)
+	s.Require().Contains(connections, ConnectionWithPath{
+		SourceWorkload:         "client",
+		SourceNamespace:        "test-ns",
+		DestinationWorkload:    "server",
+		DestinationServiceName: "server-service",
+		DestinationNamespace:   "test-ns",
+		RequestPath:            "/b-path",
+		RequestMethod:          "POST",
+	})
+}
+
+func (s *MetricsSourceTestSuite) TestPrometheusQueryError() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": "error", "errorType": "bad_data", "error": "parse error"}`)
+	}))
+	defer server.Close()
+	s.watcher.prometheusURL = server.URL
+
+	err := s.watcher.getEnvoyMetricsFromPrometheus(context.Background(), "", make(chan *EnvoyMetrics, 1))
+	s.Require().ErrorContains(err, "parse error")
+}
+
+func (s *MetricsSourceTestSuite) TestValidateMetricsSource() {
+	s.Require().NoError(validateMetricsSource(MetricsSourceExec, ""))
+	s.Require().NoError(validateMetricsSource(MetricsSourceSidecarHTTP, ""))
+	s.Require().NoError(validateMetricsSource(MetricsSourcePrometheus, "http://prometheus:9090"))
+	s.Require().Error(validateMetricsSource(MetricsSourcePrometheus, ""))
+	s.Require().Error(validateMetricsSource("grafana", ""))
+}
+
+func TestMetricsSourceTestSuite(t *testing.T) {
+	suite.Run(t, new(MetricsSourceTestSuite))
+}
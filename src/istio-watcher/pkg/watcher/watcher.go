@@ -6,6 +6,7 @@ import (
 	"crypto/md5"
 	"encoding/json"
 	"fmt"
+	"github.com/amit7itz/goset"
 	"github.com/otterize/intents-operator/src/shared/errors"
 	"github.com/otterize/network-mapper/src/mapper/pkg/config"
 	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
@@ -15,12 +16,14 @@ import (
 	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/remotecommand"
 	"k8s.io/client-go/util/homedir"
+	"net/http"
 	"path/filepath"
 	"strings"
 	"time"
@@ -71,21 +74,30 @@ type ConnectionWithPath struct {
 }
 
 type IstioWatcher struct {
-	clientset    *kubernetes.Clientset
-	config       *rest.Config
-	reporter     IstioReporter
-	connections  map[ConnectionWithPath]time.Time
-	metricsCount map[string]int
+	clientset          kubernetes.Interface
+	config             *rest.Config
+	reporter           IstioReporter
+	connections        map[ConnectionWithPath]time.Time
+	metricsCount       map[string]int
+	metricsSource      string
+	sidecarMetricsPort int
+	prometheusURL      string
+	httpClient         *http.Client
 }
 
 func (p *ConnectionWithPath) hasMissingInfo() bool {
+	return p.hasMissingWorkloadInfo() || p.RequestPath == "" || p.RequestPath == "unknown"
+}
+
+// hasMissingWorkloadInfo checks only the source and destination, for L4 connections which have no request path.
+func (p *ConnectionWithPath) hasMissingWorkloadInfo() bool {
 	for _, field := range []string{p.SourceWorkload, p.SourceNamespace, p.DestinationWorkload, p.DestinationNamespace} {
 		if field == "" || strings.Contains(field, "unknown") {
 			return true
 		}
 	}
 
-	return p.RequestPath == "" || p.RequestPath == "unknown"
+	return false
 }
 
 type EnvoyMetrics struct {
@@ -103,6 +115,12 @@ type IstioReporter interface {
 
 // NewWatcher The Istio watcher uses this interface because it used to be a standalone component that communicates with the network mapper, and was then integrated into the network mapper.
 func NewWatcher(resolver IstioReporter) (*IstioWatcher, error) {
+	metricsSource := viper.GetString(config.IstioMetricsSourceKey)
+	prometheusURL := viper.GetString(config.IstioPrometheusURLKey)
+	if err := validateMetricsSource(metricsSource, prometheusURL); err != nil {
+		return nil, errors.Wrap(err)
+	}
+
 	conf, err := rest.InClusterConfig()
 
 	if err != nil && !errors.Is(err, rest.ErrNotInCluster) {
@@ -123,11 +141,15 @@ func NewWatcher(resolver IstioReporter) (*IstioWatcher, error) {
 	}
 
 	m := &IstioWatcher{
-		clientset:    clientset,
-		config:       conf,
-		reporter:     resolver,
-		connections:  map[ConnectionWithPath]time.Time{},
-		metricsCount: map[string]int{},
+		clientset:          clientset,
+		config:             conf,
+		reporter:           resolver,
+		connections:        map[ConnectionWithPath]time.Time{},
+		metricsCount:       map[string]int{},
+		metricsSource:      metricsSource,
+		sidecarMetricsPort: viper.GetInt(config.IstioSidecarMetricsPortKey),
+		prometheusURL:      prometheusURL,
+		httpClient:         &http.Client{},
 	}
 
 	return m, nil
@@ -146,26 +168,9 @@ func (m *IstioWatcher) CollectIstioConnectionMetrics(ctx context.Context, namesp
 	receiverErrGroup, _ := errgroup.WithContext(ctx)
 	metricsChan := make(chan *EnvoyMetrics, MetricsBufferedChannelSize)
 
-	podList, err := m.clientset.CoreV1().Pods(namespace).List(ctx, v1.ListOptions{LabelSelector: IstioPodsLabelSelector})
-	if err != nil {
+	if err := m.startMetricsSenders(ctx, sendersErrGroup, sendersCtx, namespace, metricsChan); err != nil {
 		return errors.Wrap(err)
 	}
-
-	for _, pod := range podList.Items {
-		if pod.Status.Phase != corev1.PodRunning {
-			logrus.Debugf("Skipping pod %s as it is not running", pod.Name)
-			continue
-		}
-		// Known for loop gotcha with goroutines
-		curr := pod
-		sendersErrGroup.Go(func() error {
-			if err := m.getEnvoyMetricsFromSidecar(sendersCtx, curr, metricsChan); err != nil {
-				logrus.WithError(err).Errorf("Failed fetching request metrics from pod %s", curr.Name)
-				return nil // Intentionally logging error and returning nil to not cancel err group context
-			}
-			return nil
-		})
-	}
 	receiverErrGroup.Go(func() error {
 		// Function call below updates a map which isn't concurrent-safe.
 		// Needs to be taken into consideration if the code should ever change to use multiple goroutines
@@ -187,6 +192,84 @@ func (m *IstioWatcher) CollectIstioConnectionMetrics(ctx context.Context, namesp
 	return nil
 }
 
+func (m *IstioWatcher) startMetricsSenders(ctx context.Context, sendersErrGroup *errgroup.Group, sendersCtx context.Context, namespace string, metricsChan chan<- *EnvoyMetrics) error {
+	ambientNamespaces, err := m.getAmbientNamespaces(ctx, namespace)
+	if err != nil {
+		// Sidecar collection does not depend on ambient mode discovery, so it carries on without it
+		logrus.WithError(err).Warning("Failed discovering namespaces enrolled in Istio ambient mode")
+		ambientNamespaces = goset.NewSet[string]()
+	}
+
+	if m.metricsSource == MetricsSourcePrometheus {
+		sendersErrGroup.Go(func() error {
+			if err := m.getEnvoyMetricsFromPrometheus(sendersCtx, namespace, metricsChan); err != nil {
+				logrus.WithError(err).Errorf("Failed fetching request metrics from Prometheus")
+			}
+			return nil // Intentionally logging error and returning nil to not cancel err group context
+		})
+		if !ambientNamespaces.IsEmpty() {
+			sendersErrGroup.Go(func() error {
+				if err := m.getL4MetricsFromPrometheus(sendersCtx, ambientNamespaces, metricsChan); err != nil {
+					logrus.WithError(err).Errorf("Failed fetching ztunnel connection metrics from Prometheus")
+				}
+				return nil
+			})
+		}
+		return nil
+	}
+
+	podList, err := m.clientset.CoreV1().Pods(namespace).List(ctx, v1.ListOptions{LabelSelector: IstioPodsLabelSelector})
+	if err != nil {
+		return errors.Wrap(err)
+	}
+	pods := podList.Items
+
+	if !ambientNamespaces.IsEmpty() {
+		waypoints, err := m.getWaypointPods(ctx, ambientNamespaces)
+		if err != nil {
+			return errors.Wrap(err)
+		}
+		pods = lo.UniqBy(append(pods, waypoints...), func(pod corev1.Pod) types.UID { return pod.UID })
+
+		ztunnels, err := m.getZtunnelPods(ctx)
+		if err != nil {
+			return errors.Wrap(err)
+		}
+		for _, ztunnel := range ztunnels {
+			curr := ztunnel
+			sendersErrGroup.Go(func() error {
+				if err := m.getL4MetricsFromZtunnel(sendersCtx, curr, ambientNamespaces, metricsChan); err != nil {
+					logrus.WithError(err).Errorf("Failed fetching connection metrics from ztunnel pod %s", curr.Name)
+				}
+				return nil
+			})
+		}
+	}
+
+	getPodMetrics := m.getEnvoyMetricsFromSidecar
+	if m.metricsSource == MetricsSourceSidecarHTTP {
+		getPodMetrics = m.getEnvoyMetricsFromSidecarHTTP
+	}
+
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodRunning {
+			logrus.Debugf("Skipping pod %s as it is not running", pod.Name)
+			continue
+		}
+		// Known for loop gotcha with goroutines
+		curr := pod
+		sendersErrGroup.Go(func() error {
+			if err := getPodMetrics(sendersCtx, curr, metricsChan); err != nil {
+				logrus.WithError(err).Errorf("Failed fetching request metrics from pod %s", curr.Name)
+				return nil // Intentionally logging error and returning nil to not cancel err group context
+			}
+			return nil
+		})
+	}
+
+	return nil
+}
+
 func podHasIstioSidecar(pod corev1.Pod) bool {
 	return lo.ContainsBy(pod.Spec.Containers, func(item corev1.Container) bool {
 		return item.Name == IstioSidecarContainerName
@@ -329,6 +412,13 @@ func (m *IstioWatcher) buildConnectionFromMetric(metric Metric) (ConnectionWithP
 		return ConnectionWithPath{}, errors.Wrap(err)
 	}
 
+	if isL4Metric(metric) {
+		if conn.hasMissingWorkloadInfo() {
+			return ConnectionWithPath{}, ConnectionInfoInsufficient
+		}
+		return *conn, nil
+	}
+
 	if conn.hasMissingInfo() {
 		return ConnectionWithPath{}, ConnectionInfoInsufficient
 	}
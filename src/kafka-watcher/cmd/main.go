@@ -48,10 +48,19 @@ func main() {
 
 	mapperClient := mapperclient.New(viper.GetString(sharedconfig.MapperApiUrlKey))
 
+	principalMapper, err := logwatcher2.NewPrincipalMapper(viper.GetStringSlice(config.KafkaPrincipalMappingsKey))
+	if err != nil {
+		logrus.WithError(err).Panic("could not parse Kafka principal mappings")
+	}
+
+	offsets, err := logwatcher2.NewOffsetStore(viper.GetString(config.KafkaOffsetsFilePathKey))
+	if err != nil {
+		logrus.WithError(err).Panic("could not load Kafka log offsets")
+	}
+
 	mode := viper.GetString(config.KafkaLogReadModeKey)
 
 	var watcher logwatcher2.Watcher
-	var err error
 
 	switch mode {
 	case config.FileReadMode:
@@ -69,11 +78,20 @@ func main() {
 			Name:      viper.GetString(sharedconfig.EnvPodKey),
 		}
 
-		watcher, err = logwatcher2.NewLogFileWatcher(mapperClient, logPath, serverName)
+		watcher, err = logwatcher2.NewLogFileWatcher(mapperClient, principalMapper, offsets, logPath, serverName)
 		if err != nil {
 			logrus.WithError(err).Panic("could not initialize log file watcher")
 		}
 	case config.KubernetesLogReadMode:
+		if discovery, ok := brokerDiscoveryFromViper(); ok {
+			logrus.Infof("Reading from k8s logs - discovering servers")
+			watcher, err = logwatcher2.NewKubernetesDiscoveryLogWatcher(mapperClient, principalMapper, offsets, discovery)
+			if err != nil {
+				logrus.WithError(err).Panic("could not initialize Kubernetes log watcher")
+			}
+			break
+		}
+
 		kafkaServers, err := parseKafkaServers(viper.GetStringSlice(config.KafkaServersKey))
 		logrus.Infof("Reading from k8s logs - %d servers", len(kafkaServers))
 
@@ -81,7 +99,7 @@ func main() {
 			logrus.WithError(err).Panic("could not parse Kafka servers list")
 		}
 
-		watcher, err = logwatcher2.NewKubernetesLogWatcher(mapperClient, kafkaServers)
+		watcher, err = logwatcher2.NewKubernetesLogWatcher(mapperClient, principalMapper, offsets, kafkaServers)
 		if err != nil {
 			logrus.WithError(err).Panic("could not initialize Kubernetes log watcher")
 		}
@@ -152,3 +170,19 @@ func parseKafkaServers(serverNames []string) ([]types.NamespacedName, error) {
 	}
 	return servers, nil
 }
+
+func brokerDiscoveryFromViper() (logwatcher2.BrokerDiscovery, bool) {
+	if statefulSet := viper.GetString(config.KafkaServersStatefulSetKey); statefulSet != "" {
+		servers, err := parseKafkaServers([]string{statefulSet})
+		if err != nil {
+			logrus.WithError(err).Panic("could not parse Kafka StatefulSet")
+		}
+		return logwatcher2.BrokerDiscovery{Namespace: servers[0].Namespace, StatefulSet: servers[0].Name}, true
+	}
+
+	if labelSelector := viper.GetString(config.KafkaServersLabelSelectorKey); labelSelector != "" {
+		return logwatcher2.BrokerDiscovery{Namespace: viper.GetString(config.KafkaServersNamespaceKey), LabelSelector: labelSelector}, true
+	}
+
+	return logwatcher2.BrokerDiscovery{}, false
+}
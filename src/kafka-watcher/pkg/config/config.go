@@ -14,18 +14,25 @@ const (
 	KafkaLogReadModeKey          = "kafka-log-read-mode"
 	KafkaLogReadModeDefault      = KubernetesLogReadMode
 	KafkaServersKey              = "kafka-servers"
+	KafkaServersLabelSelectorKey = "kafka-servers-label-selector" // Discovers servers by label instead of using kafka-servers
+	KafkaServersNamespaceKey     = "kafka-servers-namespace"      // Namespace for kafka-servers-label-selector, empty for all namespaces
+	KafkaServersStatefulSetKey   = "kafka-servers-statefulset"    // Discovers the pods of a StatefulSet, formatted as 'name.namespace'
+	KafkaPrincipalMappingsKey    = "kafka-principal-mappings"     // Maps principals to ServiceAccounts, formatted as 'PATTERN=>TEMPLATE'
 	KafkaReportIntervalKey       = "kafka-report-interval"
 	KafkaReportIntervalDefault   = 10 * time.Second
 	KafkaCooldownIntervalKey     = "kafka-cooldown-interval"
 	KafkaCooldownIntervalDefault = 10 * time.Second
 	KafkaAuthZLogPathKey         = "kafka-authz-log-path"
 	KafkaAuthZLogPathDefault     = "/opt/otterize/kafka-watcher/authz.log"
+	KafkaOffsetsFilePathKey      = "kafka-offsets-file-path" // Persists log read offsets across restarts, disabled when empty
 )
 
 func init() {
 	viper.SetDefault(KafkaReportIntervalKey, KafkaReportIntervalDefault)
 	viper.SetDefault(KafkaServersKey, []string{})
+	viper.SetDefault(KafkaPrincipalMappingsKey, []string{})
 	viper.SetDefault(KafkaCooldownIntervalKey, KafkaCooldownIntervalDefault)
 	viper.SetDefault(KafkaAuthZLogPathKey, KafkaAuthZLogPathDefault)
 	viper.SetDefault(KafkaLogReadModeKey, KafkaLogReadModeDefault)
+	viper.SetDefault(KafkaOffsetsFilePathKey, "")
 }
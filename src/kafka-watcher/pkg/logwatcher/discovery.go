@@ -0,0 +1,94 @@
+package logwatcher
+
+import (
+	"context"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+)
+
+func (w *KubernetesLogWatcher) discoverySelector(ctx context.Context) (labels.Selector, error) {
+	if w.discovery.StatefulSet == "" {
+		selector, err := labels.Parse(w.discovery.LabelSelector)
+		if err != nil {
+			return nil, errors.Wrap(err)
+		}
+		return selector, nil
+	}
+
+	statefulSet, err := w.clientset.AppsV1().StatefulSets(w.discovery.Namespace).Get(ctx, w.discovery.StatefulSet, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(statefulSet.Spec.Selector)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	return selector, nil
+}
+
+// runDiscovery watches the pods selected by the watcher's BrokerDiscovery, and keeps a log stream running for every
+// one of them that is running.
+func (w *KubernetesLogWatcher) runDiscovery(ctx context.Context) error {
+	selector, err := w.discoverySelector(ctx)
+	if err != nil {
+		return errors.Wrap(err)
+	}
+	logrus.Infof("Discovering Kafka servers in namespace '%s' with selector '%s'", w.discovery.Namespace, selector)
+
+	factory := informers.NewSharedInformerFactoryWithOptions(w.clientset, 0,
+		informers.WithNamespace(w.discovery.Namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = selector.String()
+		}),
+	)
+	podInformer := factory.Core().V1().Pods().Informer()
+	_, err = podInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) {
+			w.handlePod(ctx, obj)
+		},
+		UpdateFunc: func(_, obj any) {
+			w.handlePod(ctx, obj)
+		},
+		DeleteFunc: func(obj any) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if pod, ok := obj.(*corev1.Pod); ok {
+				w.stopWatching(types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name})
+			}
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err)
+	}
+
+	factory.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), podInformer.HasSynced) {
+		return errors.New("failed waiting for Kafka server pods to sync")
+	}
+	return nil
+}
+
+func (w *KubernetesLogWatcher) handlePod(ctx context.Context, obj any) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return
+	}
+
+	kafkaServer := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+	// Pods that stopped running are no longer watched, and are watched again if they are restarted under the same name.
+	if pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		w.stopWatching(kafkaServer)
+		return
+	}
+	if pod.Status.Phase != corev1.PodRunning {
+		return
+	}
+	w.startWatching(ctx, kafkaServer)
+}
@@ -0,0 +1,97 @@
+package logwatcher
+
+import (
+	"context"
+	"github.com/stretchr/testify/suite"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"testing"
+	"time"
+)
+
+const testNamespace = "kafka"
+
+type DiscoveryTestSuite struct {
+	suite.Suite
+	clientset *fake.Clientset
+	ctx       context.Context
+	cancel    context.CancelFunc
+}
+
+func (s *DiscoveryTestSuite) SetupTest() {
+	s.clientset = fake.NewSimpleClientset()
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+}
+
+func (s *DiscoveryTestSuite) TearDownTest() {
+	s.cancel()
+}
+
+func (s *DiscoveryTestSuite) newWatcher(discovery BrokerDiscovery) *KubernetesLogWatcher {
+	w := newKubernetesLogWatcher(nil, nil, nil, s.clientset)
+	w.discovery = &discovery
+	return w
+}
+
+func (s *DiscoveryTestSuite) createPod(name string, labels map[string]string, phase corev1.PodPhase) *corev1.Pod {
+	pod, err := s.clientset.CoreV1().Pods(testNamespace).Create(s.ctx, &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace, Labels: labels},
+		Status:     corev1.PodStatus{Phase: phase},
+	}, metav1.CreateOptions{})
+	s.Require().NoError(err)
+	return pod
+}
+
+func (s *DiscoveryTestSuite) requireWatched(w *KubernetesLogWatcher, servers ...types.NamespacedName) {
+	s.Require().Eventually(func() bool {
+		return s.ElementsMatch(servers, w.watchedServers())
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func (s *DiscoveryTestSuite) TestLabelSelectorDiscovery() {
+	brokerLabels := map[string]string{"strimzi.io/name": "my-cluster-kafka"}
+	s.createPod("my-cluster-kafka-0", brokerLabels, corev1.PodRunning)
+	s.createPod("my-cluster-zookeeper-0", map[string]string{"strimzi.io/name": "my-cluster-zookeeper"}, corev1.PodRunning)
+
+	w := s.newWatcher(BrokerDiscovery{Namespace: testNamespace, LabelSelector: "strimzi.io/name=my-cluster-kafka"})
+	s.Require().NoError(w.runDiscovery(s.ctx))
+	s.requireWatched(w, types.NamespacedName{Namespace: testNamespace, Name: "my-cluster-kafka-0"})
+
+	// A pending broker is only watched once it is running.
+	pending := s.createPod("my-cluster-kafka-1", brokerLabels, corev1.PodPending)
+	s.requireWatched(w, types.NamespacedName{Namespace: testNamespace, Name: "my-cluster-kafka-0"})
+
+	pending.Status.Phase = corev1.PodRunning
+	_, err := s.clientset.CoreV1().Pods(testNamespace).UpdateStatus(s.ctx, pending, metav1.UpdateOptions{})
+	s.Require().NoError(err)
+	s.requireWatched(w,
+		types.NamespacedName{Namespace: testNamespace, Name: "my-cluster-kafka-0"},
+		types.NamespacedName{Namespace: testNamespace, Name: "my-cluster-kafka-1"},
+	)
+
+	s.Require().NoError(s.clientset.CoreV1().Pods(testNamespace).Delete(s.ctx, "my-cluster-kafka-0", metav1.DeleteOptions{}))
+	s.requireWatched(w, types.NamespacedName{Namespace: testNamespace, Name: "my-cluster-kafka-1"})
+}
+
+func (s *DiscoveryTestSuite) TestStatefulSetDiscovery() {
+	_, err := s.clientset.AppsV1().StatefulSets(testNamespace).Create(s.ctx, &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: testNamespace},
+		Spec: appsv1.StatefulSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "kafka"}},
+		},
+	}, metav1.CreateOptions{})
+	s.Require().NoError(err)
+	s.createPod("kafka-0", map[string]string{"app": "kafka"}, corev1.PodRunning)
+	s.createPod("other-0", map[string]string{"app": "other"}, corev1.PodRunning)
+
+	w := s.newWatcher(BrokerDiscovery{Namespace: testNamespace, StatefulSet: "kafka"})
+	s.Require().NoError(w.runDiscovery(s.ctx))
+	s.requireWatched(w, types.NamespacedName{Namespace: testNamespace, Name: "kafka-0"})
+}
+
+func TestDiscoveryTestSuite(t *testing.T) {
+	suite.Run(t, new(DiscoveryTestSuite))
+}
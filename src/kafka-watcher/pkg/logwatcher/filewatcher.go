@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/viper"
 	"io"
 	"k8s.io/apimachinery/pkg/types"
+	"os"
 	"sync"
 	"time"
 )
@@ -20,40 +21,71 @@ type LogFileWatcher struct {
 	server        types.NamespacedName
 }
 
-func NewLogFileWatcher(mapperClient *mapperclient.Client, authzFilePath string, server types.NamespacedName) (*LogFileWatcher, error) {
+func NewLogFileWatcher(mapperClient *mapperclient.Client, principalMapper *PrincipalMapper, offsets *OffsetStore, authzFilePath string, server types.NamespacedName) (*LogFileWatcher, error) {
 	w := &LogFileWatcher{
 		baseWatcher: baseWatcher{
-			mu:           sync.Mutex{},
-			seen:         SeenRecordsStore{},
-			mapperClient: mapperClient,
+			mu:              sync.Mutex{},
+			seen:            SeenRecordsStore{},
+			mapperClient:    mapperClient,
+			parsers:         DefaultParsers(),
+			principalMapper: principalMapper,
+			offsets:         offsets,
+			pendingOffsets:  make(map[string]Offset),
 		},
 		authzFilePath: authzFilePath,
 		server:        server,
 	}
+	w.initReportQueue()
 
 	return w, nil
 }
 
 func (w *LogFileWatcher) RunForever(ctx context.Context) error {
 	go w.watchForever(ctx)
+	go w.reports.RunForever(ctx)
 
 	for {
 		time.Sleep(viper.GetDuration(config.KafkaReportIntervalKey))
-
-		if err := w.reportResults(ctx); err != nil {
-			logrus.WithError(err).Errorf("Failed reporting watcher results to mapper")
-		}
+		w.reportResults()
 	}
 }
 
 func (w *LogFileWatcher) watchForever(ctx context.Context) {
-	t, err := tail.TailFile(w.authzFilePath, tail.Config{Follow: true, ReOpen: true, MustExist: false, Location: &tail.SeekInfo{Offset: 0, Whence: io.SeekEnd}})
+	t, err := tail.TailFile(w.authzFilePath, tail.Config{Follow: true, ReOpen: true, MustExist: false, Location: w.startLocation()})
 
 	if err != nil {
 		logrus.WithError(err).Panic()
 	}
 
+	go func() {
+		<-ctx.Done()
+		_ = t.Stop()
+	}()
+
 	for line := range t.Lines {
+		if line.Err != nil {
+			logrus.WithError(line.Err).Warning("Error tailing log file")
+			continue
+		}
 		w.processLogRecord(w.server, line.Text)
+		w.advanceOffset(w.authzFilePath, Offset{Position: line.SeekInfo.Offset})
+	}
+}
+
+// startLocation resumes from the stored offset, or starts from the end of the file when there is none.
+func (w *LogFileWatcher) startLocation() *tail.SeekInfo {
+	offset, ok := w.offsets.Get(w.authzFilePath)
+	if !ok {
+		return &tail.SeekInfo{Offset: 0, Whence: io.SeekEnd}
 	}
+
+	info, err := os.Stat(w.authzFilePath)
+	if err != nil || info.Size() < offset.Position {
+		// The file was rotated or truncated since the offset was stored.
+		logrus.Infof("Log file %s is shorter than its stored offset, reading from its start", w.authzFilePath)
+		return &tail.SeekInfo{Offset: 0, Whence: io.SeekStart}
+	}
+
+	logrus.Infof("Resuming log file %s from offset %d", w.authzFilePath, offset.Position)
+	return &tail.SeekInfo{Offset: offset.Position, Whence: io.SeekStart}
 }
@@ -5,10 +5,13 @@ import (
 	"context"
 	"github.com/otterize/intents-operator/src/shared/errors"
 	"github.com/otterize/network-mapper/src/kafka-watcher/pkg/config"
+	"github.com/otterize/network-mapper/src/kafka-watcher/pkg/prometheus"
 	"github.com/otterize/network-mapper/src/mapperclient"
+	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	"golang.org/x/exp/slices"
+	"io"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -18,17 +21,33 @@ import (
 	"k8s.io/client-go/util/homedir"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"sync"
 	"time"
 )
 
+// BrokerDiscovery selects the Kafka broker pods to watch, instead of a static list of servers. Pods are selected
+// either by LabelSelector, or by the selector of StatefulSet, in Namespace. An empty Namespace with a LabelSelector
+// selects pods in all namespaces.
+type BrokerDiscovery struct {
+	Namespace     string
+	LabelSelector string
+	StatefulSet   string
+}
+
+// maxLogLineSize is the longest log line read from a log stream; longer lines are dropped.
+const maxLogLineSize = 64 * 1024
+
 type KubernetesLogWatcher struct {
 	baseWatcher
-	clientset    *kubernetes.Clientset
+	clientset    kubernetes.Interface
 	kafkaServers []types.NamespacedName
+	discovery    *BrokerDiscovery
+	streamsLock  sync.Mutex
+	streams      map[types.NamespacedName]context.CancelFunc
 }
 
-func NewKubernetesLogWatcher(mapperClient *mapperclient.Client, kafkaServers []types.NamespacedName) (*KubernetesLogWatcher, error) {
+func newClientset() (kubernetes.Interface, error) {
 	conf, err := rest.InClusterConfig()
 
 	if err != nil && !errors.Is(err, rest.ErrNotInCluster) {
@@ -47,90 +66,225 @@ func NewKubernetesLogWatcher(mapperClient *mapperclient.Client, kafkaServers []t
 	if err != nil {
 		return nil, errors.Wrap(err)
 	}
+	return cs, nil
+}
 
+func newKubernetesLogWatcher(mapperClient *mapperclient.Client, principalMapper *PrincipalMapper, offsets *OffsetStore, clientset kubernetes.Interface) *KubernetesLogWatcher {
 	w := &KubernetesLogWatcher{
 		baseWatcher: baseWatcher{
-			mu:           sync.Mutex{},
-			seen:         SeenRecordsStore{},
-			mapperClient: mapperClient,
+			mu:              sync.Mutex{},
+			seen:            SeenRecordsStore{},
+			mapperClient:    mapperClient,
+			parsers:         DefaultParsers(),
+			principalMapper: principalMapper,
+			offsets:         offsets,
+			pendingOffsets:  make(map[string]Offset),
 		},
-		clientset:    cs,
-		kafkaServers: kafkaServers,
+		clientset: clientset,
+		streams:   make(map[types.NamespacedName]context.CancelFunc),
+	}
+	w.initReportQueue()
+	return w
+}
+
+func NewKubernetesLogWatcher(mapperClient *mapperclient.Client, principalMapper *PrincipalMapper, offsets *OffsetStore, kafkaServers []types.NamespacedName) (*KubernetesLogWatcher, error) {
+	cs, err := newClientset()
+	if err != nil {
+		return nil, errors.Wrap(err)
 	}
 
+	w := newKubernetesLogWatcher(mapperClient, principalMapper, offsets, cs)
+	w.kafkaServers = kafkaServers
 	return w, nil
 }
 
-func (w *KubernetesLogWatcher) RunForever(ctx context.Context) error {
-	err := w.validateKafkaServers(ctx)
-
+// NewKubernetesDiscoveryLogWatcher creates a watcher that follows the logs of the brokers selected by discovery,
+// starting and stopping log streams as brokers are added and removed.
+func NewKubernetesDiscoveryLogWatcher(mapperClient *mapperclient.Client, principalMapper *PrincipalMapper, offsets *OffsetStore, discovery BrokerDiscovery) (*KubernetesLogWatcher, error) {
+	cs, err := newClientset()
 	if err != nil {
-		return errors.Wrap(err)
+		return nil, errors.Wrap(err)
 	}
 
-	for _, kafkaServer := range w.kafkaServers {
-		go w.watchForever(ctx, kafkaServer)
+	w := newKubernetesLogWatcher(mapperClient, principalMapper, offsets, cs)
+	w.discovery = &discovery
+	return w, nil
+}
+
+func (w *KubernetesLogWatcher) RunForever(ctx context.Context) error {
+	if w.discovery != nil {
+		if err := w.runDiscovery(ctx); err != nil {
+			return errors.Wrap(err)
+		}
+	} else {
+		err := w.validateKafkaServers(ctx)
+
+		if err != nil {
+			return errors.Wrap(err)
+		}
+
+		for _, kafkaServer := range w.kafkaServers {
+			w.startWatching(ctx, kafkaServer)
+		}
 	}
 
+	go w.reports.RunForever(ctx)
 	for {
 		time.Sleep(viper.GetDuration(config.KafkaReportIntervalKey))
-		if err := w.reportResults(ctx); err != nil {
-			logrus.WithError(err).Errorf("Failed reporting watcher results to mapper")
-		}
+		w.reportResults()
 	}
 }
 
-func (w *KubernetesLogWatcher) watchOnce(ctx context.Context, kafkaServer types.NamespacedName, startTime time.Time) error {
+func (w *KubernetesLogWatcher) startWatching(ctx context.Context, kafkaServer types.NamespacedName) {
+	w.streamsLock.Lock()
+	defer w.streamsLock.Unlock()
+	if _, ok := w.streams[kafkaServer]; ok {
+		return
+	}
+
+	logrus.WithField("pod", kafkaServer).Info("Kafka server added, watching logs")
+	streamCtx, cancel := context.WithCancel(ctx)
+	w.streams[kafkaServer] = cancel
+	prometheus.SetWatchedKafkaServers(len(w.streams))
+	go w.watchForever(streamCtx, kafkaServer)
+}
+
+func (w *KubernetesLogWatcher) stopWatching(kafkaServer types.NamespacedName) {
+	w.streamsLock.Lock()
+	defer w.streamsLock.Unlock()
+	cancel, ok := w.streams[kafkaServer]
+	if !ok {
+		return
+	}
+
+	logrus.WithField("pod", kafkaServer).Info("Kafka server removed, no longer watching logs")
+	cancel()
+	delete(w.streams, kafkaServer)
+	prometheus.SetWatchedKafkaServers(len(w.streams))
+}
+
+func (w *KubernetesLogWatcher) watchedServers() []types.NamespacedName {
+	w.streamsLock.Lock()
+	defer w.streamsLock.Unlock()
+	return lo.Keys(w.streams)
+}
+
+// watchOnce follows the logs of kafkaServer from offset until the log stream ends, and returns the offset to resume
+// following from.
+func (w *KubernetesLogWatcher) watchOnce(ctx context.Context, kafkaServer types.NamespacedName, offset Offset) (Offset, error) {
 	pod, err := w.clientset.CoreV1().Pods(kafkaServer.Namespace).Get(ctx, kafkaServer.Name, metav1.GetOptions{})
 	if err != nil {
-		return errors.Wrap(err)
+		return offset, errors.Wrap(err)
 	}
 	if pod.Status.Phase != corev1.PodRunning {
 		logrus.Debugf("Kafka server %s is not running, skipping logs for this iteration", kafkaServer.String())
-		return nil
+		return offset, nil
 	}
 	podLogOpts := corev1.PodLogOptions{
-		SinceTime: &metav1.Time{Time: startTime},
+		Follow:     true,
+		Timestamps: true,
+		SinceTime:  &metav1.Time{Time: offset.Timestamp},
 	}
-	ctxTimeout, cancel := context.WithTimeout(ctx, 1*time.Minute)
-	defer cancel()
 	req := w.clientset.CoreV1().Pods(kafkaServer.Namespace).GetLogs(kafkaServer.Name, &podLogOpts)
-	reader, err := req.Stream(ctxTimeout)
+	reader, err := req.Stream(ctx)
 	if err != nil {
-		return errors.Wrap(err)
+		return offset, errors.Wrap(err)
 	}
 
 	defer reader.Close()
 
-	s := bufio.NewScanner(reader)
-	s.Split(bufio.ScanLines)
-	for s.Scan() {
-		w.processLogRecord(kafkaServer, s.Text())
+	return w.readLogStream(kafkaServer, reader, offset)
+}
+
+// readLogStream processes log lines prefixed by their timestamp, skipping the lines at the start of the stream that
+// are not newer than offset.
+func (w *KubernetesLogWatcher) readLogStream(kafkaServer types.NamespacedName, reader io.Reader, offset Offset) (Offset, error) {
+	r := bufio.NewReaderSize(reader, maxLogLineSize)
+	caughtUp := false
+	for {
+		rawLine, isPrefix, err := r.ReadLine()
+		if errors.Is(err, io.EOF) {
+			return offset, nil
+		}
+		if err != nil {
+			return offset, errors.Wrap(err)
+		}
+		if isPrefix {
+			// Skip the rest of lines that are too long to be authorizer records, rather than failing the stream and
+			// reading them again when it is reopened.
+			for isPrefix && err == nil {
+				_, isPrefix, err = r.ReadLine()
+			}
+			prometheus.IncrementKafkaLogLinesDropped(prometheus.DropReasonMalformed)
+			continue
+		}
+
+		timestamp, line, ok := splitLogTimestamp(string(rawLine))
+		if !ok {
+			prometheus.IncrementKafkaLogLinesDropped(prometheus.DropReasonMalformed)
+			continue
+		}
+		// SinceTime only has a resolution of seconds, so lines read before the stream was reopened are streamed again.
+		// Once a newer line is read the stream is past them, and later lines sharing a timestamp are all new.
+		if !caughtUp {
+			if !timestamp.After(offset.Timestamp) {
+				prometheus.IncrementKafkaLogLinesDropped(prometheus.DropReasonDuplicate)
+				continue
+			}
+			caughtUp = true
+		}
+		offset.Timestamp = timestamp
+		w.processLogRecord(kafkaServer, line)
+		w.advanceOffset(kafkaServer.String(), offset)
 	}
+}
 
-	return nil
+func splitLogTimestamp(line string) (time.Time, string, bool) {
+	rawTimestamp, text, found := strings.Cut(line, " ")
+	if !found {
+		return time.Time{}, "", false
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, rawTimestamp)
+	if err != nil {
+		return time.Time{}, "", false
+	}
+	return timestamp, text, true
 }
 
 func (w *KubernetesLogWatcher) watchForever(ctx context.Context, kafkaServer types.NamespacedName) {
 	log := logrus.WithField("pod", kafkaServer)
 	cooldownPeriod := viper.GetDuration(config.KafkaCooldownIntervalKey)
-	readFromTime := time.Now().Add(-(viper.GetDuration(config.KafkaCooldownIntervalKey)))
+	offset, ok := w.offsets.Get(kafkaServer.String())
+	if ok {
+		log.Infof("Resuming logs from %s", offset.Timestamp)
+	} else {
+		offset = Offset{Timestamp: time.Now().Add(-cooldownPeriod)}
+	}
+
+	for reconnect := false; ; reconnect = true {
+		if reconnect {
+			prometheus.IncrementKafkaLogStreamReconnects()
+		}
 
-	for {
 		log.Info("Watching logs")
-		err := w.watchOnce(ctx, kafkaServer, readFromTime)
+		var err error
+		offset, err = w.watchOnce(ctx, kafkaServer, offset)
+		if ctx.Err() != nil {
+			// The server was removed, or the watcher is shutting down.
+			return
+		}
 
 		if err != nil {
-			if errors.Is(err, context.DeadlineExceeded) {
-				continue
-			}
 			log.WithError(err).Error("Error watching logs")
 		}
 
-		readFromTime = time.Now()
 		log.Infof("Waiting %s before watching logs again...", cooldownPeriod)
 
-		time.Sleep(cooldownPeriod)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(cooldownPeriod):
+		}
 	}
 }
 
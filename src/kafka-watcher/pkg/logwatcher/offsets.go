@@ -0,0 +1,90 @@
+package logwatcher
+
+import (
+	"encoding/json"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Offset is the position the watcher resumes reading a log from - the timestamp of the last line read from a Kubernetes
+// log stream, or the byte position following the last line read from a log file.
+type Offset struct {
+	Timestamp time.Time `json:"timestamp,omitempty"`
+	Position  int64     `json:"position,omitempty"`
+}
+
+// OffsetStore holds the offsets of the logs read by the watcher, keyed by log. Offsets are persisted to path so that
+// reading resumes where it left off across restarts, or kept in memory only when path is empty.
+type OffsetStore struct {
+	path    string
+	lock    sync.Mutex
+	offsets map[string]Offset
+}
+
+func NewOffsetStore(path string) (*OffsetStore, error) {
+	s := &OffsetStore{path: path, offsets: make(map[string]Offset)}
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	if err := json.Unmarshal(data, &s.offsets); err != nil {
+		return nil, errors.Errorf("failed parsing offsets file %s: %w", path, err)
+	}
+	return s, nil
+}
+
+func (s *OffsetStore) Get(key string) (Offset, bool) {
+	if s == nil {
+		return Offset{}, false
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	offset, ok := s.offsets[key]
+	return offset, ok
+}
+
+// Commit stores offsets and persists the store.
+func (s *OffsetStore) Commit(offsets map[string]Offset) error {
+	if s == nil {
+		return nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for key, offset := range offsets {
+		s.offsets[key] = offset
+	}
+	if s.path == "" || len(offsets) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(s.offsets)
+	if err != nil {
+		return errors.Wrap(err)
+	}
+	// Write to a temporary file and rename it over the offsets file, so a crash never leaves a partially written file.
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return errors.Wrap(err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return errors.Wrap(err)
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err)
+	}
+	return errors.Wrap(os.Rename(tmp.Name(), s.path))
+}
@@ -0,0 +1,192 @@
+package logwatcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/otterize/network-mapper/src/mapperclient"
+	"github.com/otterize/network-mapper/src/shared/reportqueue"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testAuthorizerLine = "[2023-03-12 13:51:55,904] INFO Principal = User:CN=myclient is Allowed Operation = Read from host = 10.244.0.27 on resource = Topic:LITERAL:%s for request = Fetch with resourceRefCount = 1 (kafka.authorizer.logger)"
+
+type OffsetsTestSuite struct {
+	suite.Suite
+	dir string
+}
+
+func (s *OffsetsTestSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+}
+
+func authorizerLine(topic string) string {
+	return fmt.Sprintf(testAuthorizerLine, topic)
+}
+
+func topicsOf(records SeenRecordsStore) []string {
+	topics := make([]string, 0, len(records))
+	for record := range records {
+		topics = append(topics, record.ResourceName)
+	}
+	return topics
+}
+
+func (s *OffsetsTestSuite) TestCommitPersistsOffsets() {
+	path := filepath.Join(s.dir, "offsets.json")
+	store, err := NewOffsetStore(path)
+	s.Require().NoError(err)
+	_, ok := store.Get("kafka/kafka-0")
+	s.Require().False(ok)
+
+	timestamp := time.Date(2024, 5, 2, 9, 12, 31, 118000000, time.UTC)
+	s.Require().NoError(store.Commit(map[string]Offset{"kafka/kafka-0": {Timestamp: timestamp}, "/var/log/authz.log": {Position: 42}}))
+
+	reloaded, err := NewOffsetStore(path)
+	s.Require().NoError(err)
+	offset, ok := reloaded.Get("kafka/kafka-0")
+	s.Require().True(ok)
+	s.Require().True(timestamp.Equal(offset.Timestamp))
+	offset, ok = reloaded.Get("/var/log/authz.log")
+	s.Require().True(ok)
+	s.Require().Equal(int64(42), offset.Position)
+}
+
+func (s *OffsetsTestSuite) TestInvalidOffsetsFile() {
+	path := filepath.Join(s.dir, "offsets.json")
+	s.Require().NoError(os.WriteFile(path, []byte("{"), 0600))
+	_, err := NewOffsetStore(path)
+	s.Require().Error(err)
+}
+
+func (s *OffsetsTestSuite) TestNilStore() {
+	var store *OffsetStore
+	_, ok := store.Get("kafka/kafka-0")
+	s.Require().False(ok)
+	s.Require().NoError(store.Commit(map[string]Offset{"kafka/kafka-0": {Position: 1}}))
+}
+
+func (s *OffsetsTestSuite) TestReadLogStreamSkipsReadLines() {
+	w := newKubernetesLogWatcher(nil, nil, nil, nil)
+	server := types.NamespacedName{Namespace: "kafka", Name: "kafka-0"}
+	lastRead := time.Date(2024, 5, 2, 9, 12, 31, 0, time.UTC)
+
+	stream := strings.Join([]string{
+		"2024-05-02T09:12:30.900000000Z " + authorizerLine("before"),
+		"2024-05-02T09:12:31.000000000Z " + authorizerLine("last-read"),
+		authorizerLine("no-timestamp"),
+		"2024-05-02T09:12:31.500000000Z " + strings.Repeat("x", 2*maxLogLineSize),
+		"2024-05-02T09:12:32.000000000Z " + authorizerLine("after"),
+	}, "\n")
+
+	offset, err := w.readLogStream(server, strings.NewReader(stream), Offset{Timestamp: lastRead})
+	s.Require().NoError(err)
+	s.Require().True(time.Date(2024, 5, 2, 9, 12, 32, 0, time.UTC).Equal(offset.Timestamp))
+
+	records, offsets := w.flush()
+	s.Require().Equal([]string{"after"}, topicsOf(records))
+	s.Require().Equal(map[string]Offset{server.String(): offset}, offsets)
+}
+
+func (s *OffsetsTestSuite) TestReadLogStreamKeepsLinesSharingATimestamp() {
+	w := newKubernetesLogWatcher(nil, nil, nil, nil)
+	server := types.NamespacedName{Namespace: "kafka", Name: "kafka-0"}
+	lastRead := time.Date(2024, 5, 2, 9, 12, 31, 0, time.UTC)
+
+	stream := strings.Join([]string{
+		"2024-05-02T09:12:31.000000000Z " + authorizerLine("last-read"),
+		"2024-05-02T09:12:32.000000000Z " + authorizerLine("first"),
+		"2024-05-02T09:12:32.000000000Z " + authorizerLine("second"),
+		"2024-05-02T09:12:32.000000000Z " + authorizerLine("third"),
+	}, "\n")
+
+	offset, err := w.readLogStream(server, strings.NewReader(stream), Offset{Timestamp: lastRead})
+	s.Require().NoError(err)
+	s.Require().True(time.Date(2024, 5, 2, 9, 12, 32, 0, time.UTC).Equal(offset.Timestamp))
+
+	records, _ := w.flush()
+	s.Require().ElementsMatch([]string{"first", "second", "third"}, topicsOf(records))
+}
+
+func (s *OffsetsTestSuite) TestLogFileWatcherResumesFromOffset() {
+	path := filepath.Join(s.dir, "authz.log")
+	firstLine := authorizerLine("first") + "\n"
+	s.Require().NoError(os.WriteFile(path, []byte(firstLine+authorizerLine("second")+"\n"), 0600))
+
+	store, err := NewOffsetStore("")
+	s.Require().NoError(err)
+	s.Require().NoError(store.Commit(map[string]Offset{path: {Position: int64(len(firstLine))}}))
+
+	w, err := NewLogFileWatcher(nil, nil, store, path, types.NamespacedName{Namespace: "kafka", Name: "kafka-0"})
+	s.Require().NoError(err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.watchForever(ctx)
+
+	var records SeenRecordsStore
+	var offsets map[string]Offset
+	s.Require().Eventually(func() bool {
+		records, offsets = w.flush()
+		return len(records) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	s.Require().Equal([]string{"second"}, topicsOf(records))
+	s.Require().Equal(Offset{Position: int64(len(firstLine) + len(authorizerLine("second")) + 1)}, offsets[path])
+}
+
+func (s *OffsetsTestSuite) TestLogFileWatcherRestartsTruncatedFile() {
+	path := filepath.Join(s.dir, "authz.log")
+	s.Require().NoError(os.WriteFile(path, []byte(authorizerLine("first")+"\n"), 0600))
+
+	store, err := NewOffsetStore("")
+	s.Require().NoError(err)
+	s.Require().NoError(store.Commit(map[string]Offset{path: {Position: 1 << 20}}))
+
+	w, err := NewLogFileWatcher(nil, nil, store, path, types.NamespacedName{Namespace: "kafka", Name: "kafka-0"})
+	s.Require().NoError(err)
+	s.Require().Equal(int64(0), w.startLocation().Offset)
+}
+
+func (s *OffsetsTestSuite) TestOffsetsNotCommittedPastUnreportedRecords() {
+	store, err := NewOffsetStore("")
+	s.Require().NoError(err)
+	server := types.NamespacedName{Namespace: "kafka", Name: "kafka-0"}
+	w := &baseWatcher{seen: SeenRecordsStore{}, parsers: DefaultParsers(), offsets: store, pendingOffsets: make(map[string]Offset)}
+
+	sendErr := errors.New("mapper unavailable")
+	w.reports = reportqueue.New("test", func(_ context.Context, _ []mapperclient.KafkaMapperResult) error {
+		return sendErr
+	}, mapperclient.KafkaMapperResultKey, mapperclient.MergeKafkaMapperResults, reportqueue.Options{})
+
+	w.processLogRecord(server, authorizerLine("first"))
+	w.pendingOffsets[server.String()] = Offset{Position: 1}
+	w.reportResults()
+	s.Require().Error(w.reports.Flush(context.Background()))
+
+	// Records read while the first report fails stay pending, along with their offsets
+	w.processLogRecord(server, authorizerLine("second"))
+	w.pendingOffsets[server.String()] = Offset{Position: 2}
+	w.reportResults()
+	_, ok := store.Get(server.String())
+	s.Require().False(ok)
+	s.Require().Equal(1, w.reports.Len())
+
+	sendErr = nil
+	s.Require().NoError(w.reports.Flush(context.Background()))
+	offset, _ := store.Get(server.String())
+	s.Require().Equal(int64(1), offset.Position)
+
+	w.reportResults()
+	s.Require().NoError(w.reports.Flush(context.Background()))
+	offset, _ = store.Get(server.String())
+	s.Require().Equal(int64(2), offset.Position)
+}
+
+func TestOffsetsTestSuite(t *testing.T) {
+	suite.Run(t, new(OffsetsTestSuite))
+}
@@ -0,0 +1,101 @@
+package logwatcher
+
+import (
+	"errors"
+	"github.com/oriser/regroup"
+	"github.com/otterize/network-mapper/src/mapperclient"
+	"github.com/sirupsen/logrus"
+	"strings"
+)
+
+// RecordParser parses a single authorizer log line into an AuthorizerRecord. It returns false for lines it does not
+// recognize, so that the next parser can be tried.
+type RecordParser interface {
+	Parse(line string) (AuthorizerRecord, bool)
+}
+
+// AclAuthorizerRegex matches & decodes AclAuthorizer log records, logged by ZooKeeper-based clusters.
+// Sample log record for reference:
+// [2023-03-12 13:51:55,904] INFO Principal = User:2.5.4.45=#13206331373734376636373865323137613636346130653335393130326638303662,CN=myclient.otterize-tutorial-kafka-mtls,O=SPIRE,C=US is Denied Operation = Describe from host = 10.244.0.27 on resource = Topic:LITERAL:mytopic for request = Metadata with resourceRefCount = 1 (kafka.authorizer.logger)
+var AclAuthorizerRegex = regroup.MustCompile(
+	`^\[\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2},\d+\] [A-Z]+ Principal = (?P<principal>\S+) is (?P<access>\S+) Operation = (?P<operation>\S+) from host = (?P<host>\S+) on resource = (?P<resourceType>[A-Za-z_]+):(?P<patternType>[A-Z]+):(?P<resourceName>.+) for request = \S+ with resourceRefCount = \d+ \(kafka\.authorizer\.logger\)$`,
+)
+
+// StandardAuthorizerRegex matches & decodes StandardAuthorizer log records, logged by KRaft-based clusters. Unlike
+// AclAuthorizer, StandardAuthorizer logs the operation keyword in lowercase, which is matched case-insensitively.
+// Sample log record for reference:
+// [2024-05-02 09:12:31,118] DEBUG Principal = User:CN=myclient is Allowed operation = READ from host = 10.244.0.27 on resource = Group:LITERAL:mygroup for request = OffsetFetch with resourceRefCount = 1 based on rule MatchingAcl(acl=StandardAcl(resourceType=GROUP, resourceName=my, patternType=PREFIXED, principal=User:CN=myclient, host=*, operation=READ, permissionType=ALLOW)) (kafka.authorizer.logger)
+var StandardAuthorizerRegex = regroup.MustCompile(
+	`^\[\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2},\d+\] [A-Z]+ Principal = (?P<principal>\S+) is (?P<access>\S+) (?i:operation) = (?P<operation>\S+) from host = (?P<host>\S+) on resource = (?P<resourceType>[A-Za-z_]+):(?P<patternType>[A-Z]+):(?P<resourceName>.+) for request = \S+ with resourceRefCount = \d+ based on rule .+ \(kafka\.authorizer\.logger\)$`,
+)
+
+var resourceTypes = map[string]mapperclient.KafkaResourceType{
+	"topic":           mapperclient.KafkaResourceTypeTopic,
+	"group":           mapperclient.KafkaResourceTypeGroup,
+	"transactionalid": mapperclient.KafkaResourceTypeTransactionalId,
+	"cluster":         mapperclient.KafkaResourceTypeCluster,
+}
+
+var patternTypes = map[string]mapperclient.KafkaPatternType{
+	"LITERAL":  mapperclient.KafkaPatternTypeLiteral,
+	"PREFIXED": mapperclient.KafkaPatternTypePrefixed,
+}
+
+type regexRecordParser struct {
+	regex *regroup.ReGroup
+}
+
+type regexMatch struct {
+	Principal    string `regroup:"principal"`
+	Access       string `regroup:"access"`
+	Operation    string `regroup:"operation"`
+	Host         string `regroup:"host"`
+	ResourceType string `regroup:"resourceType"`
+	PatternType  string `regroup:"patternType"`
+	ResourceName string `regroup:"resourceName"`
+}
+
+func (p *regexRecordParser) Parse(line string) (AuthorizerRecord, bool) {
+	match := regexMatch{}
+	if err := p.regex.MatchToTarget(line, &match); errors.Is(err, &regroup.NoMatchFoundError{}) {
+		return AuthorizerRecord{}, false
+	} else if err != nil {
+		logrus.Errorf("Error matching authorizer regex: %s", err)
+		return AuthorizerRecord{}, false
+	}
+
+	// Both "TransactionalId" and "TRANSACTIONAL_ID" spellings are logged, depending on the Kafka version.
+	resourceType, ok := resourceTypes[strings.ReplaceAll(strings.ToLower(match.ResourceType), "_", "")]
+	if !ok {
+		logrus.Debugf("Ignoring authorizer record for unsupported resource type %s", match.ResourceType)
+		return AuthorizerRecord{}, false
+	}
+	patternType, ok := patternTypes[match.PatternType]
+	if !ok {
+		logrus.Debugf("Ignoring authorizer record for unsupported pattern type %s", match.PatternType)
+		return AuthorizerRecord{}, false
+	}
+
+	return AuthorizerRecord{
+		Principal:    match.Principal,
+		Access:       match.Access,
+		Operation:    match.Operation,
+		Host:         match.Host,
+		ResourceType: resourceType,
+		PatternType:  patternType,
+		ResourceName: match.ResourceName,
+	}, true
+}
+
+func NewAclAuthorizerParser() RecordParser {
+	return &regexRecordParser{regex: AclAuthorizerRegex}
+}
+
+func NewStandardAuthorizerParser() RecordParser {
+	return &regexRecordParser{regex: StandardAuthorizerRegex}
+}
+
+// DefaultParsers are the parsers used by watchers, covering the authorizers of both ZooKeeper and KRaft clusters.
+func DefaultParsers() []RecordParser {
+	return []RecordParser{NewAclAuthorizerParser(), NewStandardAuthorizerParser()}
+}
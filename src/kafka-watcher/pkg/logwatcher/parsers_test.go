@@ -0,0 +1,98 @@
+package logwatcher
+
+import (
+	"github.com/otterize/network-mapper/src/mapperclient"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/types"
+	"testing"
+)
+
+type ParsersTestSuite struct {
+	suite.Suite
+}
+
+func (s *ParsersTestSuite) parse(line string) (AuthorizerRecord, bool) {
+	for _, parser := range DefaultParsers() {
+		if record, ok := parser.Parse(line); ok {
+			return record, true
+		}
+	}
+	return AuthorizerRecord{}, false
+}
+
+func (s *ParsersTestSuite) TestAclAuthorizerTopic() {
+	record, ok := s.parse("[2023-03-12 13:51:55,904] INFO Principal = User:2.5.4.45=#13206331373734376636373865323137613636346130653335393130326638303662,CN=myclient.otterize-tutorial-kafka-mtls,O=SPIRE,C=US is Denied Operation = Describe from host = 10.244.0.27 on resource = Topic:LITERAL:mytopic for request = Metadata with resourceRefCount = 1 (kafka.authorizer.logger)")
+	s.Require().True(ok)
+	s.Require().Equal(AuthorizerRecord{
+		Principal:    "User:2.5.4.45=#13206331373734376636373865323137613636346130653335393130326638303662,CN=myclient.otterize-tutorial-kafka-mtls,O=SPIRE,C=US",
+		Access:       "Denied",
+		Operation:    "Describe",
+		Host:         "10.244.0.27",
+		ResourceType: mapperclient.KafkaResourceTypeTopic,
+		PatternType:  mapperclient.KafkaPatternTypeLiteral,
+		ResourceName: "mytopic",
+	}, record)
+}
+
+func (s *ParsersTestSuite) TestAclAuthorizerPrefixedTransactionalId() {
+	record, ok := s.parse("[2023-03-12 13:51:55,904] INFO Principal = User:CN=producer is Allowed Operation = Write from host = 10.244.0.28 on resource = TransactionalId:PREFIXED:tx- for request = InitProducerId with resourceRefCount = 1 (kafka.authorizer.logger)")
+	s.Require().True(ok)
+	s.Require().Equal(mapperclient.KafkaResourceTypeTransactionalId, record.ResourceType)
+	s.Require().Equal(mapperclient.KafkaPatternTypePrefixed, record.PatternType)
+	s.Require().Equal("tx-", record.ResourceName)
+}
+
+func (s *ParsersTestSuite) TestStandardAuthorizerUppercaseTransactionalId() {
+	record, ok := s.parse("[2024-05-02 09:12:31,118] DEBUG Principal = User:CN=producer is Allowed operation = WRITE from host = 10.244.0.28 on resource = TRANSACTIONAL_ID:LITERAL:tx-1 for request = InitProducerId with resourceRefCount = 1 based on rule MatchingAcl(acl=StandardAcl(resourceType=TRANSACTIONAL_ID, resourceName=tx-, patternType=PREFIXED, principal=User:CN=producer, host=*, operation=WRITE, permissionType=ALLOW)) (kafka.authorizer.logger)")
+	s.Require().True(ok)
+	s.Require().Equal(mapperclient.KafkaResourceTypeTransactionalId, record.ResourceType)
+	s.Require().Equal(mapperclient.KafkaPatternTypeLiteral, record.PatternType)
+	s.Require().Equal("tx-1", record.ResourceName)
+}
+
+func (s *ParsersTestSuite) TestStandardAuthorizerGroup() {
+	record, ok := s.parse("[2024-05-02 09:12:31,118] DEBUG Principal = User:CN=myclient is Allowed operation = READ from host = 10.244.0.27 on resource = Group:LITERAL:mygroup for request = OffsetFetch with resourceRefCount = 1 based on rule MatchingAcl(acl=StandardAcl(resourceType=GROUP, resourceName=my, patternType=PREFIXED, principal=User:CN=myclient, host=*, operation=READ, permissionType=ALLOW)) (kafka.authorizer.logger)")
+	s.Require().True(ok)
+	s.Require().Equal(AuthorizerRecord{
+		Principal:    "User:CN=myclient",
+		Access:       "Allowed",
+		Operation:    "READ",
+		Host:         "10.244.0.27",
+		ResourceType: mapperclient.KafkaResourceTypeGroup,
+		PatternType:  mapperclient.KafkaPatternTypeLiteral,
+		ResourceName: "mygroup",
+	}, record)
+}
+
+func (s *ParsersTestSuite) TestStandardAuthorizerCluster() {
+	record, ok := s.parse("[2024-05-02 09:12:31,118] INFO Principal = User:CN=admin is Denied operation = IDEMPOTENT_WRITE from host = 10.244.0.29 on resource = Cluster:LITERAL:kafka-cluster for request = InitProducerId with resourceRefCount = 1 based on rule DefaultRule(result=DENIED) (kafka.authorizer.logger)")
+	s.Require().True(ok)
+	s.Require().Equal(mapperclient.KafkaResourceTypeCluster, record.ResourceType)
+	s.Require().Equal("IDEMPOTENT_WRITE", record.Operation)
+	s.Require().Equal("kafka-cluster", record.ResourceName)
+}
+
+func (s *ParsersTestSuite) TestUnsupportedLines() {
+	_, ok := s.parse("[2024-05-02 09:12:31,118] DEBUG Principal = User:CN=admin is Allowed operation = DESCRIBE from host = 10.244.0.29 on resource = DelegationToken:LITERAL:token for request = DescribeTokens with resourceRefCount = 1 based on rule SuperUser (kafka.authorizer.logger)")
+	s.Require().False(ok)
+
+	_, ok = s.parse("[2024-05-02 09:12:31,118] INFO [KafkaServer id=0] started (kafka.server.KafkaServer)")
+	s.Require().False(ok)
+}
+
+func (s *ParsersTestSuite) TestProcessLogRecordSetsServer() {
+	w := &baseWatcher{seen: SeenRecordsStore{}, parsers: DefaultParsers()}
+	server := types.NamespacedName{Namespace: "kafka", Name: "kafka-0"}
+	w.processLogRecord(server, "[2023-03-12 13:51:55,904] INFO Principal = User:CN=myclient is Allowed Operation = Read from host = 10.244.0.27 on resource = Topic:LITERAL:mytopic for request = Fetch with resourceRefCount = 1 (kafka.authorizer.logger)")
+
+	records, _ := w.flush()
+	s.Require().Len(records, 1)
+	for record := range records {
+		s.Require().Equal(server, record.Server)
+		s.Require().Equal("mytopic", record.ResourceName)
+	}
+}
+
+func TestParsersTestSuite(t *testing.T) {
+	suite.Run(t, new(ParsersTestSuite))
+}
@@ -0,0 +1,64 @@
+package logwatcher
+
+import (
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"regexp"
+	"strings"
+)
+
+const principalMappingSeparator = "=>"
+
+// PrincipalMapping maps principals matching Pattern to a ServiceAccount. Template is expanded with the pattern's
+// capture groups and must result in 'namespace/name'.
+type PrincipalMapping struct {
+	Pattern  *regexp.Regexp
+	Template string
+}
+
+// PrincipalMapper maps Kafka principals to the ServiceAccounts of the workloads they belong to, so that clients can be
+// identified when their source IP does not belong to them. The first matching mapping wins.
+type PrincipalMapper struct {
+	mappings []PrincipalMapping
+}
+
+// NewPrincipalMapper parses mappings formatted as 'PATTERN=>TEMPLATE', for example:
+//
+//	SPIFFE IDs:      ^User:spiffe://[^/]+/ns/([^/]+)/sa/([^/]+)$=>$1/$2
+//	CN name.ns:      ^User:CN=([^.,]+)\.([^,]+)(,.*)?$=>$2/$1
+//	SASL usernames:  ^User:(.+)$=>kafka-clients/$1
+func NewPrincipalMapper(mappings []string) (*PrincipalMapper, error) {
+	mapper := &PrincipalMapper{}
+	for _, mapping := range mappings {
+		pattern, template, found := strings.Cut(mapping, principalMappingSeparator)
+		if !found {
+			return nil, errors.Errorf("error parsing principal mapping %s - should be formatted as 'PATTERN%sTEMPLATE'", mapping, principalMappingSeparator)
+		}
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.Wrap(err)
+		}
+		mapper.mappings = append(mapper.mappings, PrincipalMapping{Pattern: regex, Template: template})
+	}
+	return mapper, nil
+}
+
+func (m *PrincipalMapper) Map(principal string) (types.NamespacedName, bool) {
+	if m == nil {
+		return types.NamespacedName{}, false
+	}
+
+	for _, mapping := range m.mappings {
+		match := mapping.Pattern.FindStringSubmatchIndex(principal)
+		if match == nil {
+			continue
+		}
+		expanded := string(mapping.Pattern.ExpandString(nil, mapping.Template, principal, match))
+		namespace, name, found := strings.Cut(expanded, "/")
+		if !found || namespace == "" || name == "" {
+			continue
+		}
+		return types.NamespacedName{Namespace: namespace, Name: name}, true
+	}
+	return types.NamespacedName{}, false
+}
@@ -0,0 +1,70 @@
+package logwatcher
+
+import (
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/types"
+	"testing"
+)
+
+type PrincipalMapperTestSuite struct {
+	suite.Suite
+}
+
+func (s *PrincipalMapperTestSuite) TestSpiffeID() {
+	mapper, err := NewPrincipalMapper([]string{`^User:spiffe://[^/]+/ns/([^/]+)/sa/([^/]+)$=>$1/$2`})
+	s.Require().NoError(err)
+
+	serviceAccount, ok := mapper.Map("User:spiffe://cluster.local/ns/orders/sa/checkout")
+	s.Require().True(ok)
+	s.Require().Equal(types.NamespacedName{Namespace: "orders", Name: "checkout"}, serviceAccount)
+}
+
+func (s *PrincipalMapperTestSuite) TestFirstMatchWins() {
+	mapper, err := NewPrincipalMapper([]string{
+		`^User:CN=([^.,]+)\.([^,]+)(,.*)?$=>$2/$1`,
+		`^User:(.+)$=>kafka-clients/$1`,
+	})
+	s.Require().NoError(err)
+
+	serviceAccount, ok := mapper.Map("User:CN=myclient.otterize-tutorial-kafka-mtls,O=SPIRE,C=US")
+	s.Require().True(ok)
+	s.Require().Equal(types.NamespacedName{Namespace: "otterize-tutorial-kafka-mtls", Name: "myclient"}, serviceAccount)
+
+	serviceAccount, ok = mapper.Map("User:alice")
+	s.Require().True(ok)
+	s.Require().Equal(types.NamespacedName{Namespace: "kafka-clients", Name: "alice"}, serviceAccount)
+}
+
+func (s *PrincipalMapperTestSuite) TestNoMatch() {
+	mapper, err := NewPrincipalMapper([]string{`^User:spiffe://[^/]+/ns/([^/]+)/sa/([^/]+)$=>$1/$2`})
+	s.Require().NoError(err)
+
+	_, ok := mapper.Map("User:ANONYMOUS")
+	s.Require().False(ok)
+}
+
+func (s *PrincipalMapperTestSuite) TestIncompleteExpansionIsSkipped() {
+	mapper, err := NewPrincipalMapper([]string{`^User:(.*)$=>$1`})
+	s.Require().NoError(err)
+
+	_, ok := mapper.Map("User:alice")
+	s.Require().False(ok)
+}
+
+func (s *PrincipalMapperTestSuite) TestNilMapper() {
+	var mapper *PrincipalMapper
+	_, ok := mapper.Map("User:alice")
+	s.Require().False(ok)
+}
+
+func (s *PrincipalMapperTestSuite) TestInvalidMappings() {
+	_, err := NewPrincipalMapper([]string{"^User:(.*)$"})
+	s.Require().Error(err)
+
+	_, err = NewPrincipalMapper([]string{"^User:(.*$=>ns/$1"})
+	s.Require().Error(err)
+}
+
+func TestPrincipalMapperTestSuite(t *testing.T) {
+	suite.Run(t, new(PrincipalMapperTestSuite))
+}
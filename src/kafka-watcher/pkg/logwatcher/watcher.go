@@ -2,10 +2,11 @@ package logwatcher
 
 import (
 	"context"
-	"errors"
-	"github.com/oriser/regroup"
+	"github.com/otterize/intents-operator/src/shared/errors"
 	"github.com/otterize/network-mapper/src/kafka-watcher/pkg/prometheus"
 	"github.com/otterize/network-mapper/src/mapperclient"
+	"github.com/otterize/network-mapper/src/shared/reportqueue"
+	"github.com/otterize/nilable"
 	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/types"
@@ -13,19 +14,15 @@ import (
 	"time"
 )
 
-// AclAuthorizerRegex matches & decodes AclAuthorizer log records.
-// Sample log record for reference:
-// [2023-03-12 13:51:55,904] INFO Principal = User:2.5.4.45=#13206331373734376636373865323137613636346130653335393130326638303662,CN=myclient.otterize-tutorial-kafka-mtls,O=SPIRE,C=US is Denied Operation = Describe from host = 10.244.0.27 on resource = Topic:LITERAL:mytopic for request = Metadata with resourceRefCount = 1 (kafka.authorizer.logger)
-var AclAuthorizerRegex = regroup.MustCompile(
-	`^\[\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2},\d+\] [A-Z]+ Principal = \S+ is (?P<access>\S+) Operation = (?P<operation>\S+) from host = (?P<host>\S+) on resource = Topic:LITERAL:(?P<topic>.+) for request = \S+ with resourceRefCount = \d+ \(kafka\.authorizer\.logger\)$`,
-)
-
 type AuthorizerRecord struct {
-	Server    types.NamespacedName
-	Access    string `regroup:"access"`
-	Operation string `regroup:"operation"`
-	Host      string `regroup:"host"`
-	Topic     string `regroup:"topic"`
+	Server       types.NamespacedName
+	Principal    string
+	Access       string
+	Operation    string
+	Host         string
+	ResourceType mapperclient.KafkaResourceType
+	PatternType  mapperclient.KafkaPatternType
+	ResourceName string
 }
 
 type SeenRecordsStore map[AuthorizerRecord]time.Time
@@ -35,58 +32,107 @@ type Watcher interface {
 }
 
 type baseWatcher struct {
-	mu           sync.Mutex
-	seen         SeenRecordsStore
-	mapperClient *mapperclient.Client
+	mu              sync.Mutex
+	seen            SeenRecordsStore
+	mapperClient    *mapperclient.Client
+	parsers         []RecordParser
+	principalMapper *PrincipalMapper
+	offsets         *OffsetStore
+	pendingOffsets  map[string]Offset
+	reports         *reportqueue.Queue[mapperclient.KafkaMapperResult, mapperclient.KafkaMapperResult]
 }
 
-func (b *baseWatcher) flush() SeenRecordsStore {
+// initReportQueue creates the queue results are held in until they are reported to the mapper, which retries reports
+// that failed, such as while the mapper is restarting.
+func (b *baseWatcher) initReportQueue() {
+	b.reports = reportqueue.New("kafka-watcher", b.send,
+		mapperclient.KafkaMapperResultKey, mapperclient.MergeKafkaMapperResults, reportqueue.OptionsFromConfig("kafka-watcher"))
+}
+
+func (b *baseWatcher) flush() (SeenRecordsStore, map[string]Offset) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	r := b.seen
+	o := b.pendingOffsets
 	b.seen = SeenRecordsStore{}
-	return r
+	b.pendingOffsets = make(map[string]Offset)
+	return r, o
 }
 
-func (b *baseWatcher) reportResults(ctx context.Context) error {
-	records := b.flush()
-
-	cRecords := len(records)
+func (b *baseWatcher) reportResults() {
+	// While earlier results were not reported yet, such as while the mapper is unavailable, records and offsets are kept
+	// pending rather than queued, so that the queue never drops records whose offsets are committed by later reports.
+	if queued := b.reports.Len(); queued > 0 {
+		logrus.Infof("%d earlier records were not reported yet, keeping new records pending", queued)
+		return
+	}
 
-	if cRecords == 0 {
+	records, offsets := b.flush()
+	if len(records) == 0 {
 		logrus.Infof("Zero records, not reporting")
-		return nil
+	} else {
+		logrus.Infof("Queueing %d records for reporting", len(records))
 	}
 
-	logrus.Infof("Reporting %d records", cRecords)
-	prometheus.IncrementKafkaTopicReports(cRecords)
+	// Offsets are only committed once the records read up to them were reported, so that reading resumes from the
+	// first unreported record after a restart.
+	b.reports.Enqueue(b.results(records), func() {
+		if err := b.offsets.Commit(offsets); err != nil {
+			logrus.WithError(err).Error("Failed committing Kafka log offsets")
+		}
+	})
+}
 
-	results := lo.MapToSlice(records, func(r AuthorizerRecord, t time.Time) mapperclient.KafkaMapperResult {
-		return mapperclient.KafkaMapperResult{
+func (b *baseWatcher) results(records SeenRecordsStore) []mapperclient.KafkaMapperResult {
+	return lo.MapToSlice(records, func(r AuthorizerRecord, t time.Time) mapperclient.KafkaMapperResult {
+		result := mapperclient.KafkaMapperResult{
 			SrcIp:           r.Host,
-			ServerPodName:   r.Server.Name,
-			ServerNamespace: r.Server.Namespace,
-			Topic:           r.Topic,
+			ServerPodName:   nilable.From(r.Server.Name),
+			ServerNamespace: nilable.From(r.Server.Namespace),
+			Topic:           r.ResourceName,
 			Operation:       r.Operation,
 			LastSeen:        t,
+			Principal:       nilable.From(r.Principal),
+			ResourceType:    nilable.From(r.ResourceType),
+			PatternType:     nilable.From(r.PatternType),
+		}
+		if serviceAccount, ok := b.principalMapper.Map(r.Principal); ok {
+			result.SrcServiceAccount = nilable.From(mapperclient.NamespacedName{Name: serviceAccount.Name, Namespace: serviceAccount.Namespace})
 		}
+		return result
 	})
+}
 
-	return b.mapperClient.ReportKafkaMapperResults(ctx, mapperclient.KafkaMapperResults{Results: results})
+func (b *baseWatcher) send(ctx context.Context, results []mapperclient.KafkaMapperResult) error {
+	logrus.Infof("Reporting %d records", len(results))
+	if err := b.mapperClient.ReportKafkaMapperResults(ctx, mapperclient.KafkaMapperResults{Results: results}); err != nil {
+		return errors.Wrap(err)
+	}
+	prometheus.IncrementKafkaTopicReports(len(results))
+	return nil
 }
 
 func (b *baseWatcher) processLogRecord(kafkaServer types.NamespacedName, record string) {
-	authorizerRecord := AuthorizerRecord{
-		Server: kafkaServer,
-	}
-	if err := AclAuthorizerRegex.MatchToTarget(record, &authorizerRecord); errors.Is(err, &regroup.NoMatchFoundError{}) {
-		return
-	} else if err != nil {
-		logrus.Errorf("Error matching authorizer regex: %s", err)
+	for _, parser := range b.parsers {
+		authorizerRecord, ok := parser.Parse(record)
+		if !ok {
+			continue
+		}
+		authorizerRecord.Server = kafkaServer
+
+		prometheus.IncrementKafkaLogLinesParsed()
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.seen[authorizerRecord] = time.Now()
 		return
 	}
+	prometheus.IncrementKafkaLogLinesDropped(prometheus.DropReasonUnparsed)
+}
 
+// advanceOffset records the offset following the last log line processed from the log identified by key, to be
+// committed with the next report.
+func (b *baseWatcher) advanceOffset(key string, offset Offset) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
-	b.seen[authorizerRecord] = time.Now()
+	b.pendingOffsets[key] = offset
 }
@@ -10,8 +10,46 @@ var (
 		Name: "kafka_reported_topics",
 		Help: "The total number of Kafka topics reported.",
 	})
+	watchedServers = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "kafka_watched_servers",
+		Help: "The number of Kafka servers whose logs are being watched.",
+	})
+	logLinesParsed = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "kafka_log_lines_parsed",
+		Help: "The total number of log lines parsed into authorizer records.",
+	})
+	logLinesDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kafka_log_lines_dropped",
+		Help: "The total number of log lines dropped, by reason.",
+	}, []string{"reason"})
+	logStreamReconnects = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "kafka_log_stream_reconnects",
+		Help: "The total number of times a Kafka server log stream was reopened.",
+	})
+)
+
+const (
+	DropReasonUnparsed  = "unparsed"  // Not an authorizer log line
+	DropReasonMalformed = "malformed" // Too long, or missing the timestamp added by the Kubernetes API
+	DropReasonDuplicate = "duplicate" // Already read before the log stream was reopened
 )
 
 func IncrementKafkaTopicReports(count int) {
 	topicReports.Add(float64(count))
 }
+
+func SetWatchedKafkaServers(count int) {
+	watchedServers.Set(float64(count))
+}
+
+func IncrementKafkaLogLinesParsed() {
+	logLinesParsed.Inc()
+}
+
+func IncrementKafkaLogLinesDropped(reason string) {
+	logLinesDropped.WithLabelValues(reason).Inc()
+}
+
+func IncrementKafkaLogStreamReconnects() {
+	logStreamReconnects.Inc()
+}
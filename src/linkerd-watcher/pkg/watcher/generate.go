@@ -0,0 +1,3 @@
+package linkerdwatcher
+
+//go:generate go run go.uber.org/mock/mockgen@v0.2.0 -source=watcher.go -destination=mocks/mocks.go
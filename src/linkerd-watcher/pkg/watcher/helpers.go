@@ -0,0 +1,68 @@
+package linkerdwatcher
+
+import (
+	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
+	"github.com/samber/lo"
+	"golang.org/x/exp/slices"
+	"net/http"
+	"time"
+)
+
+var HTTPMethodsToGQLMethods = map[string]model.HTTPMethod{
+	http.MethodGet:     model.HTTPMethodGet,
+	http.MethodPost:    model.HTTPMethodPost,
+	http.MethodPut:     model.HTTPMethodPut,
+	http.MethodDelete:  model.HTTPMethodDelete,
+	http.MethodOptions: model.HTTPMethodOptions,
+	http.MethodTrace:   model.HTTPMethodTrace,
+	http.MethodPatch:   model.HTTPMethodPatch,
+	http.MethodConnect: model.HTTPMethodConnect,
+}
+
+type connectionWithoutMethod struct {
+	SrcPodName   string
+	SrcNamespace string
+	Authority    string
+	Path         string
+}
+
+// ToGraphQLLinkerdConnections merges connections that only differ by their method.
+func ToGraphQLLinkerdConnections(connections map[Connection]time.Time) []model.LinkerdConnection {
+	results := map[connectionWithoutMethod]model.LinkerdConnection{}
+
+	for conn, timestamp := range connections {
+		key := connectionWithoutMethod{
+			SrcPodName:   conn.SrcPodName,
+			SrcNamespace: conn.SrcNamespace,
+			Authority:    conn.Authority,
+			Path:         conn.Path,
+		}
+
+		result, ok := results[key]
+		if !ok {
+			result = model.LinkerdConnection{
+				SrcPodName:   conn.SrcPodName,
+				SrcNamespace: conn.SrcNamespace,
+				Authority:    conn.Authority,
+				Methods:      []model.HTTPMethod{},
+				LastSeen:     timestamp,
+			}
+			if conn.Path != "" {
+				result.Path = lo.ToPtr(conn.Path)
+			}
+		}
+
+		if timestamp.After(result.LastSeen) {
+			result.LastSeen = timestamp
+		}
+
+		method, ok := HTTPMethodsToGQLMethods[conn.Method]
+		if ok && !slices.Contains(result.Methods, method) {
+			result.Methods = append(result.Methods, method)
+		}
+
+		results[key] = result
+	}
+
+	return lo.Values(results)
+}
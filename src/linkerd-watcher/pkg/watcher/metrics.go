@@ -0,0 +1,135 @@
+package linkerdwatcher
+
+import (
+	"context"
+	"fmt"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"github.com/otterize/network-mapper/src/mapper/pkg/config"
+	"github.com/otterize/network-mapper/src/shared/prometheusmetrics"
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	"net"
+	"strconv"
+	"strings"
+)
+
+const (
+	RequestTotalMetricName      = "request_total"
+	RouteRequestTotalMetricName = "route_request_total"
+	proxyMetricsPath            = "/metrics"
+	outboundDirection           = "outbound"
+)
+
+// parseRouteName extracts the method and path from a ServiceProfile route name, when the route is named after them,
+// as done by `linkerd profile` when generating ServiceProfiles from OpenAPI and protobuf definitions.
+func parseRouteName(route string) (method string, path string, ok bool) {
+	method, path, found := strings.Cut(route, " ")
+	if !found || !strings.HasPrefix(path, "/") || strings.Contains(path, " ") {
+		return "", "", false
+	}
+	if _, ok := HTTPMethodsToGQLMethods[method]; !ok {
+		return "", "", false
+	}
+	return method, path, true
+}
+
+// connectionFromLabels builds a connection from the labels of a 'request_total' or 'route_request_total' sample.
+func connectionFromLabels(metricName string, labels map[string]string, srcPodName string, srcNamespace string) (Connection, bool) {
+	conn := Connection{SrcPodName: srcPodName, SrcNamespace: srcNamespace}
+	switch metricName {
+	case RequestTotalMetricName:
+		conn.Authority = labels["authority"]
+	case RouteRequestTotalMetricName:
+		conn.Authority = labels["dst"]
+		if method, path, ok := parseRouteName(labels["rt_route"]); ok {
+			conn.Method, conn.Path = method, path
+		}
+	default:
+		return Connection{}, false
+	}
+
+	if conn.SrcPodName == "" || conn.SrcNamespace == "" || conn.Authority == "" {
+		return Connection{}, false
+	}
+	return conn, true
+}
+
+func (m *LinkerdWatcher) getRequestCountsFromProxy(ctx context.Context, pod corev1.Pod) (RequestCounts, error) {
+	target := fmt.Sprintf("http://%s%s", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(m.proxyAdminPort)), proxyMetricsPath)
+	return m.scrapeProxyMetrics(ctx, target, pod.Name, pod.Namespace)
+}
+
+// scrapeProxyMetrics reads the outbound request metrics served on a linkerd-proxy's admin port. These carry no labels
+// identifying the client, which is the pod the proxy runs in.
+func (m *LinkerdWatcher) scrapeProxyMetrics(ctx context.Context, target string, podName string, namespace string) (RequestCounts, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, viper.GetDuration(config.LinkerdMetricFetchTimeoutKey))
+	defer cancel()
+
+	families, err := prometheusmetrics.Scrape(timeoutCtx, m.httpClient, target)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+
+	counts := RequestCounts{}
+	for _, metricName := range []string{RequestTotalMetricName, RouteRequestTotalMetricName} {
+		family, ok := families[metricName]
+		if !ok {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := prometheusmetrics.Labels(metric)
+			if labels["direction"] != outboundDirection {
+				continue
+			}
+			conn, ok := connectionFromLabels(metricName, labels, podName, namespace)
+			if !ok {
+				continue
+			}
+			counts[conn] += prometheusmetrics.SampleValue(metric)
+		}
+	}
+
+	return counts, nil
+}
+
+// getRequestCountsFromPrometheus queries the Prometheus server of Linkerd viz, which labels the proxies' metrics with
+// the namespace and name of the pod they were scraped from.
+func (m *LinkerdWatcher) getRequestCountsFromPrometheus(ctx context.Context, namespace string) (RequestCounts, error) {
+	selector := fmt.Sprintf(`direction="%s"`, outboundDirection)
+	if namespace != "" {
+		selector += fmt.Sprintf(`,namespace="%s"`, namespace)
+	}
+
+	queries := map[string]string{
+		RequestTotalMetricName:      fmt.Sprintf(`sum by (namespace, pod, authority) (%s{%s})`, RequestTotalMetricName, selector),
+		RouteRequestTotalMetricName: fmt.Sprintf(`sum by (namespace, pod, dst, rt_route) (%s{%s})`, RouteRequestTotalMetricName, selector),
+	}
+
+	counts := RequestCounts{}
+	for metricName, query := range queries {
+		if err := m.queryPrometheus(ctx, metricName, query, counts); err != nil {
+			return nil, errors.Wrap(err)
+		}
+	}
+	return counts, nil
+}
+
+func (m *LinkerdWatcher) queryPrometheus(ctx context.Context, metricName string, query string, counts RequestCounts) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, viper.GetDuration(config.LinkerdMetricFetchTimeoutKey))
+	defer cancel()
+
+	samples, err := prometheusmetrics.Query(timeoutCtx, m.httpClient, m.prometheusURL, query)
+	if err != nil {
+		return errors.Wrap(err)
+	}
+
+	for _, sample := range samples {
+		conn, ok := connectionFromLabels(metricName, sample.Labels, sample.Labels["pod"], sample.Labels["namespace"])
+		if !ok {
+			continue
+		}
+		counts[conn] += sample.Value
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: watcher.go
+
+// Package mock_linkerdwatcher is a generated GoMock package.
+package mock_linkerdwatcher
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLinkerdReporter is a mock of LinkerdReporter interface.
+type MockLinkerdReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockLinkerdReporterMockRecorder
+}
+
+// MockLinkerdReporterMockRecorder is the mock recorder for MockLinkerdReporter.
+type MockLinkerdReporterMockRecorder struct {
+	mock *MockLinkerdReporter
+}
+
+// NewMockLinkerdReporter creates a new mock instance.
+func NewMockLinkerdReporter(ctrl *gomock.Controller) *MockLinkerdReporter {
+	mock := &MockLinkerdReporter{ctrl: ctrl}
+	mock.recorder = &MockLinkerdReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLinkerdReporter) EXPECT() *MockLinkerdReporterMockRecorder {
+	return m.recorder
+}
+
+// ReportLinkerdConnectionResults mocks base method.
+func (m *MockLinkerdReporter) ReportLinkerdConnectionResults(ctx context.Context, results model.LinkerdConnectionResults) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportLinkerdConnectionResults", ctx, results)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReportLinkerdConnectionResults indicates an expected call of ReportLinkerdConnectionResults.
+func (mr *MockLinkerdReporterMockRecorder) ReportLinkerdConnectionResults(ctx, results interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportLinkerdConnectionResults", reflect.TypeOf((*MockLinkerdReporter)(nil).ReportLinkerdConnectionResults), ctx, results)
+}
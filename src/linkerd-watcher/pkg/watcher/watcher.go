@@ -0,0 +1,258 @@
+package linkerdwatcher
+
+import (
+	"context"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"github.com/otterize/network-mapper/src/mapper/pkg/config"
+	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
+	"github.com/otterize/network-mapper/src/shared/prometheusmetrics"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/homedir"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+/*
+Linkerd proxy metric samples, as served on the proxy's admin port
+request_total{direction="outbound",authority="server.default.svc.cluster.local:8080",target_addr="10.244.0.12:8080",tls="true",
+	server_id="default.default.serviceaccount.identity.linkerd.cluster.local",dst_deployment="server",dst_namespace="default"} 12
+route_request_total{direction="outbound",dst="server.default.svc.cluster.local:8080",rt_route="GET /api/orders"} 7
+*/
+const (
+	LinkerdProxyContainerName  = "linkerd-proxy"
+	LinkerdPodsLabelSelector   = "linkerd.io/control-plane-ns"
+	MetricsSourceProxy         = "proxy"
+	MetricsSourcePrometheus    = prometheusmetrics.SourcePrometheus
+	MetricsBufferedChannelSize = 100
+)
+
+// Connection is HTTP traffic from a meshed pod to an authority. The method and path are only known for requests that
+// matched a ServiceProfile route named after them, such as "GET /api/orders".
+type Connection struct {
+	SrcPodName   string
+	SrcNamespace string
+	Authority    string
+	Path         string
+	Method       string
+}
+
+// RequestCounts are the number of requests a proxy has seen for each connection since it was started.
+type RequestCounts map[Connection]int
+
+type LinkerdReporter interface {
+	ReportLinkerdConnectionResults(ctx context.Context, results model.LinkerdConnectionResults) (bool, error)
+}
+
+type LinkerdWatcher struct {
+	clientset      kubernetes.Interface
+	reporter       LinkerdReporter
+	connections    map[Connection]time.Time
+	requestCounts  RequestCounts
+	lock           sync.Mutex
+	metricsSource  string
+	proxyAdminPort int
+	prometheusURL  string
+	httpClient     *http.Client
+}
+
+// NewWatcher Like the Istio watcher, the Linkerd watcher runs as part of the network mapper and reports to it through
+// the same interface a standalone component would use.
+func NewWatcher(reporter LinkerdReporter) (*LinkerdWatcher, error) {
+	metricsSource := viper.GetString(config.LinkerdMetricsSourceKey)
+	prometheusURL := viper.GetString(config.LinkerdPrometheusURLKey)
+	if err := validateMetricsSource(metricsSource, prometheusURL); err != nil {
+		return nil, errors.Wrap(err)
+	}
+
+	conf, err := rest.InClusterConfig()
+	if err != nil && !errors.Is(err, rest.ErrNotInCluster) {
+		return nil, errors.Wrap(err)
+	}
+
+	// We try building the REST Config from ./kube/config to support running the watcher locally
+	if conf == nil {
+		conf, err = clientcmd.BuildConfigFromFlags("", filepath.Join(homedir.HomeDir(), ".kube", "config"))
+		if err != nil {
+			return nil, errors.Wrap(err)
+		}
+	}
+
+	clientset, err := kubernetes.NewForConfig(conf)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+
+	return &LinkerdWatcher{
+		clientset:      clientset,
+		reporter:       reporter,
+		connections:    map[Connection]time.Time{},
+		requestCounts:  RequestCounts{},
+		metricsSource:  metricsSource,
+		proxyAdminPort: viper.GetInt(config.LinkerdProxyAdminPortKey),
+		prometheusURL:  prometheusURL,
+		httpClient:     &http.Client{},
+	}, nil
+}
+
+func validateMetricsSource(source string, prometheusURL string) error {
+	return prometheusmetrics.ValidateSource(source, prometheusURL, config.LinkerdPrometheusURLKey, MetricsSourceProxy)
+}
+
+func (m *LinkerdWatcher) Flush() map[Connection]time.Time {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	r := m.connections
+	m.connections = map[Connection]time.Time{}
+	return r
+}
+
+func (m *LinkerdWatcher) CollectLinkerdConnectionMetrics(ctx context.Context, namespace string) error {
+	if m.metricsSource == MetricsSourcePrometheus {
+		counts, err := m.getRequestCountsFromPrometheus(ctx, namespace)
+		if err != nil {
+			return errors.Wrap(err)
+		}
+		m.updateConnections(counts)
+		m.pruneRequestCounts(counts, nil)
+		return nil
+	}
+
+	podList, err := m.clientset.CoreV1().Pods(namespace).List(ctx, v1.ListOptions{LabelSelector: LinkerdPodsLabelSelector})
+	if err != nil {
+		return errors.Wrap(err)
+	}
+
+	sendersErrGroup, sendersCtx := errgroup.WithContext(ctx)
+	sendersErrGroup.SetLimit(10)
+	countsChan := make(chan RequestCounts, MetricsBufferedChannelSize)
+	done := make(chan struct{})
+	scraped := RequestCounts{}
+	go func() {
+		defer close(done)
+		for counts := range countsChan {
+			m.updateConnections(counts)
+			for conn, count := range counts {
+				scraped[conn] = count
+			}
+		}
+	}()
+
+	var unscrapedPodsLock sync.Mutex
+	unscrapedPods := map[types.NamespacedName]struct{}{}
+
+	for _, pod := range podList.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" || !podHasLinkerdProxy(pod) {
+			continue
+		}
+		curr := pod
+		sendersErrGroup.Go(func() error {
+			counts, err := m.getRequestCountsFromProxy(sendersCtx, curr)
+			if err != nil {
+				logrus.WithError(err).Errorf("Failed fetching request metrics from pod %s", curr.Name)
+				unscrapedPodsLock.Lock()
+				unscrapedPods[types.NamespacedName{Namespace: curr.Namespace, Name: curr.Name}] = struct{}{}
+				unscrapedPodsLock.Unlock()
+				return nil // Intentionally logging error and returning nil to not cancel err group context
+			}
+			countsChan <- counts
+			return nil
+		})
+	}
+
+	err = sendersErrGroup.Wait()
+	close(countsChan)
+	<-done
+	if err != nil {
+		return errors.Wrap(err)
+	}
+	m.pruneRequestCounts(scraped, unscrapedPods)
+	return nil
+}
+
+func podHasLinkerdProxy(pod corev1.Pod) bool {
+	// Linkerd can run its proxy as a native sidecar, which is an init container
+	return lo.ContainsBy(append(pod.Spec.Containers, pod.Spec.InitContainers...), func(item corev1.Container) bool {
+		return item.Name == LinkerdProxyContainerName
+	})
+}
+
+// updateConnections marks connections as seen now if their request count changed since it was last collected, as
+// proxies report all the requests they have seen since they were started.
+func (m *LinkerdWatcher) updateConnections(counts RequestCounts) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for conn, count := range counts {
+		previousCount, found := m.requestCounts[conn]
+		if found && previousCount == count {
+			continue
+		}
+		m.requestCounts[conn] = count
+		m.connections[conn] = time.Now()
+	}
+}
+
+// pruneRequestCounts forgets the request counts of connections that were not seen in the latest scrape, such as those
+// of deleted pods, except for the pods that could not be scraped.
+func (m *LinkerdWatcher) pruneRequestCounts(scraped RequestCounts, unscrapedPods map[types.NamespacedName]struct{}) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for conn := range m.requestCounts {
+		if _, found := scraped[conn]; found {
+			continue
+		}
+		if _, unscraped := unscrapedPods[types.NamespacedName{Namespace: conn.SrcNamespace, Name: conn.SrcPodName}]; unscraped {
+			continue
+		}
+		delete(m.requestCounts, conn)
+	}
+}
+
+func (m *LinkerdWatcher) ReportResults(ctx context.Context) {
+	for {
+		time.Sleep(viper.GetDuration(config.LinkerdReportIntervalKey))
+		err := m.reportResults(ctx)
+		if err != nil {
+			logrus.WithError(err).Errorf("Failed reporting Linkerd connection results to mapper")
+		}
+	}
+}
+
+func (m *LinkerdWatcher) reportResults(ctx context.Context) error {
+	connections := m.Flush()
+	if len(connections) == 0 {
+		logrus.Debugln("No connections found in metrics - skipping report")
+		return nil
+	}
+
+	logrus.Debugf("Reporting %d connections", len(connections))
+	results := ToGraphQLLinkerdConnections(connections)
+	_, err := m.reporter.ReportLinkerdConnectionResults(ctx, model.LinkerdConnectionResults{Results: results})
+	if err != nil {
+		return errors.Wrap(err)
+	}
+	return nil
+}
+
+func (m *LinkerdWatcher) RunForever(ctx context.Context) error {
+	go m.ReportResults(ctx)
+	cooldownPeriod := viper.GetDuration(config.LinkerdCooldownIntervalKey)
+	for {
+		logrus.Debug("Retrieving 'request_total' metrics from Linkerd proxies")
+		if err := m.CollectLinkerdConnectionMetrics(ctx, viper.GetString(config.LinkerdRestrictCollectionToNamespace)); err != nil {
+			logrus.WithError(err).Debugf("Failed getting connection metrics from Linkerd proxies")
+		}
+		logrus.Debugf("Linkerd mapping stopped, will retry after cool down period (%s)...", cooldownPeriod)
+		time.Sleep(cooldownPeriod)
+	}
+}
@@ -0,0 +1,162 @@
+package linkerdwatcher
+
+import (
+	"context"
+	"fmt"
+	mock_linkerdwatcher "github.com/otterize/network-mapper/src/linkerd-watcher/pkg/watcher/mocks"
+	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
+	"github.com/otterize/network-mapper/src/shared/prometheusmetrics"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"k8s.io/apimachinery/pkg/types"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const proxyMetrics = `# HELP request_total Total count of HTTP requests.
+# TYPE request_total counter
+request_total{direction="outbound",authority="server.test-ns.svc.cluster.local:8080",target_addr="10.244.0.12:8080",tls="true",dst_deployment="server",dst_namespace="test-ns"} 10
+request_total{direction="outbound",authority="server.test-ns.svc.cluster.local:8080",target_addr="10.244.0.13:8080",tls="true",dst_deployment="server",dst_namespace="test-ns"} 5
+request_total{direction="inbound",authority="client.test-ns.svc.cluster.local:80",target_addr="0.0.0.0:80",tls="true"} 3
+# HELP route_request_total Total count of HTTP requests.
+# TYPE route_request_total counter
+route_request_total{direction="outbound",dst="server.test-ns.svc.cluster.local:8080",rt_route="GET /api/orders"} 8
+route_request_total{direction="outbound",dst="server.test-ns.svc.cluster.local:8080",rt_route="POST /api/orders"} 2
+route_request_total{direction="outbound",dst="server.test-ns.svc.cluster.local:8080",rt_route="[DEFAULT]"} 5
+`
+
+const prometheusRequestTotalResult = `{"status": "success", "data": {"resultType": "vector", "result": [
+  {"metric": {"namespace": "test-ns", "pod": "client-6d4cf56db6-x7k2p", "authority": "server.test-ns.svc.cluster.local:8080"}, "value": [1718000000.1, "15"]}
+]}}`
+
+const prometheusRouteRequestTotalResult = `{"status": "success", "data": {"resultType": "vector", "result": [
+  {"metric": {"namespace": "test-ns", "pod": "client-6d4cf56db6-x7k2p", "dst": "server.test-ns.svc.cluster.local:8080", "rt_route": "DELETE /api/orders/{id}"}, "value": [1718000000.1, "1"]}
+]}}`
+
+type WatcherTestSuite struct {
+	suite.Suite
+	mockLinkerdReporter *mock_linkerdwatcher.MockLinkerdReporter
+	watcher             *LinkerdWatcher
+}
+
+func (s *WatcherTestSuite) SetupTest() {
+	controller := gomock.NewController(s.T())
+	s.mockLinkerdReporter = mock_linkerdwatcher.NewMockLinkerdReporter(controller)
+	s.watcher = &LinkerdWatcher{
+		reporter:      s.mockLinkerdReporter,
+		connections:   map[Connection]time.Time{},
+		requestCounts: RequestCounts{},
+		httpClient:    &http.Client{},
+	}
+}
+
+func (s *WatcherTestSuite) TestParseRouteName() {
+	method, path, ok := parseRouteName("GET /api/orders/{id}")
+	s.Require().True(ok)
+	s.Require().Equal("GET", method)
+	s.Require().Equal("/api/orders/{id}", path)
+
+	for _, route := range []string{"[DEFAULT]", "orders", "FETCH /api/orders", "GET api/orders", ""} {
+		_, _, ok = parseRouteName(route)
+		s.Require().False(ok, route)
+	}
+}
+
+func (s *WatcherTestSuite) TestScrapeProxyMetrics() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Require().Equal(proxyMetricsPath, r.URL.Path)
+		fmt.Fprint(w, proxyMetrics)
+	}))
+	defer server.Close()
+
+	counts, err := s.watcher.scrapeProxyMetrics(context.Background(), server.URL+proxyMetricsPath, "client", "test-ns")
+	s.Require().NoError(err)
+
+	authority := "server.test-ns.svc.cluster.local:8080"
+	s.Require().Equal(RequestCounts{
+		{SrcPodName: "client", SrcNamespace: "test-ns", Authority: authority}:                                      20,
+		{SrcPodName: "client", SrcNamespace: "test-ns", Authority: authority, Method: "GET", Path: "/api/orders"}:  8,
+		{SrcPodName: "client", SrcNamespace: "test-ns", Authority: authority, Method: "POST", Path: "/api/orders"}: 2,
+	}, counts)
+}
+
+func (s *WatcherTestSuite) TestPrometheusQuery() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Require().Equal(prometheusmetrics.QueryPath, r.URL.Path)
+		query := r.URL.Query().Get("query")
+		s.Require().Contains(query, `namespace="test-ns"`)
+		if strings.Contains(query, RouteRequestTotalMetricName) {
+			fmt.Fprint(w, prometheusRouteRequestTotalResult)
+			return
+		}
+		fmt.Fprint(w, prometheusRequestTotalResult)
+	}))
+	defer server.Close()
+	s.watcher.prometheusURL = server.URL
+
+	counts, err := s.watcher.getRequestCountsFromPrometheus(context.Background(), "test-ns")
+	s.Require().NoError(err)
+
+	authority := "server.test-ns.svc.cluster.local:8080"
+	s.Require().Equal(RequestCounts{
+		{SrcPodName: "client-6d4cf56db6-x7k2p", SrcNamespace: "test-ns", Authority: authority}:                                             15,
+		{SrcPodName: "client-6d4cf56db6-x7k2p", SrcNamespace: "test-ns", Authority: authority, Method: "DELETE", Path: "/api/orders/{id}"}: 1,
+	}, counts)
+}
+
+func (s *WatcherTestSuite) TestIgnoreUnchangedCounts() {
+	connA := Connection{SrcPodName: "clientA", SrcNamespace: "test-ns", Authority: "server:8080"}
+	connB := Connection{SrcPodName: "clientB", SrcNamespace: "test-ns", Authority: "server:8080"}
+
+	s.watcher.updateConnections(RequestCounts{connA: 5, connB: 1})
+	s.Require().Len(s.watcher.Flush(), 2)
+
+	s.watcher.updateConnections(RequestCounts{connA: 6, connB: 1})
+	connections := s.watcher.Flush()
+	s.Require().Len(connections, 1)
+	s.Require().Contains(connections, connA)
+}
+
+func (s *WatcherTestSuite) TestPruneRequestCounts() {
+	connA := Connection{SrcPodName: "clientA", SrcNamespace: "test-ns", Authority: "server:8080"}
+	connB := Connection{SrcPodName: "clientB", SrcNamespace: "test-ns", Authority: "server:8080"}
+	connC := Connection{SrcPodName: "clientC", SrcNamespace: "test-ns", Authority: "server:8080"}
+
+	s.watcher.updateConnections(RequestCounts{connA: 5, connB: 1, connC: 3})
+	s.watcher.pruneRequestCounts(RequestCounts{connA: 5}, map[types.NamespacedName]struct{}{{Namespace: "test-ns", Name: "clientC"}: {}})
+	s.Require().Equal(RequestCounts{connA: 5, connC: 3}, s.watcher.requestCounts)
+}
+
+func (s *WatcherTestSuite) TestReportResults() {
+	s.watcher.updateConnections(RequestCounts{
+		{SrcPodName: "client", SrcNamespace: "test-ns", Authority: "server:8080", Method: "GET", Path: "/api/orders"}:  8,
+		{SrcPodName: "client", SrcNamespace: "test-ns", Authority: "server:8080", Method: "POST", Path: "/api/orders"}: 2,
+		{SrcPodName: "client", SrcNamespace: "test-ns", Authority: "server:8080"}:                                      10,
+	})
+
+	s.mockLinkerdReporter.EXPECT().ReportLinkerdConnectionResults(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, results model.LinkerdConnectionResults) (bool, error) {
+			s.Require().Len(results.Results, 2)
+			withPath, found := lo.Find(results.Results, func(result model.LinkerdConnection) bool { return result.Path != nil })
+			s.Require().True(found)
+			s.Require().Equal("/api/orders", *withPath.Path)
+			s.Require().ElementsMatch([]model.HTTPMethod{model.HTTPMethodGet, model.HTTPMethodPost}, withPath.Methods)
+			return true, nil
+		})
+	s.Require().NoError(s.watcher.reportResults(context.Background()))
+}
+
+func (s *WatcherTestSuite) TestValidateMetricsSource() {
+	s.Require().NoError(validateMetricsSource(MetricsSourceProxy, ""))
+	s.Require().NoError(validateMetricsSource(MetricsSourcePrometheus, "http://prometheus.linkerd-viz:9090"))
+	s.Require().Error(validateMetricsSource(MetricsSourcePrometheus, ""))
+	s.Require().Error(validateMetricsSource("tap", ""))
+}
+
+func TestWatcherTestSuite(t *testing.T) {
+	suite.Run(t, new(WatcherTestSuite))
+}
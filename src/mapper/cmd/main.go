@@ -14,6 +14,8 @@ import (
 	"github.com/otterize/intents-operator/src/shared/telemetries/componentinfo"
 	"github.com/otterize/intents-operator/src/shared/telemetries/errorreporter"
 	istiowatcher "github.com/otterize/network-mapper/src/istio-watcher/pkg/watcher"
+	linkerdwatcher "github.com/otterize/network-mapper/src/linkerd-watcher/pkg/watcher"
+	"github.com/otterize/network-mapper/src/mapper/pkg/accesslogs"
 	"github.com/otterize/network-mapper/src/mapper/pkg/awsintentsholder"
 	"github.com/otterize/network-mapper/src/mapper/pkg/azureintentsholder"
 	"github.com/otterize/network-mapper/src/mapper/pkg/collectors/traffic"
@@ -25,6 +27,7 @@ import (
 	"github.com/otterize/network-mapper/src/mapper/pkg/metadatareporter"
 	"github.com/otterize/network-mapper/src/mapper/pkg/metrics_collection_traffic"
 	"github.com/otterize/network-mapper/src/mapper/pkg/networkpolicyreport"
+	"github.com/otterize/network-mapper/src/mapper/pkg/persistentstore"
 	"github.com/otterize/network-mapper/src/mapper/pkg/resourcevisibility"
 	"github.com/otterize/network-mapper/src/mapper/pkg/webhook_traffic"
 	"github.com/otterize/network-mapper/src/shared/echologrus"
@@ -49,13 +52,18 @@ import (
 	"github.com/otterize/network-mapper/src/mapper/pkg/cloudclient"
 	"github.com/otterize/network-mapper/src/mapper/pkg/clouduploader"
 	"github.com/otterize/network-mapper/src/mapper/pkg/config"
+	"github.com/otterize/network-mapper/src/mapper/pkg/intentsdiff"
 	"github.com/otterize/network-mapper/src/mapper/pkg/intentsstore"
+	"github.com/otterize/network-mapper/src/mapper/pkg/kafkaexporter"
 	"github.com/otterize/network-mapper/src/mapper/pkg/kubefinder"
 	"github.com/otterize/network-mapper/src/mapper/pkg/metricexporter"
 	"github.com/otterize/network-mapper/src/mapper/pkg/resolvers"
+	"github.com/otterize/network-mapper/src/mapper/pkg/servicegraphmetrics"
+	"github.com/otterize/network-mapper/src/mapper/pkg/webhookexporter"
 	sharedconfig "github.com/otterize/network-mapper/src/shared/config"
 	"github.com/otterize/network-mapper/src/shared/kubeutils"
 	"github.com/otterize/network-mapper/src/shared/version"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -176,7 +184,25 @@ func main() {
 	gcpIntentsHolder := gcpintentsholder.New()
 	azureIntentsHolder := azureintentsholder.New()
 	trafficCollector := traffic.NewCollector()
+
+	var persistentStore *persistentstore.Store
+	if viper.GetBool(config.PersistenceEnabledKey) {
+		backend, err := persistentstore.NewFileBackend(viper.GetString(config.PersistencePathKey))
+		if err != nil {
+			logrus.WithError(err).Panic("Failed to initialize persistent store")
+		}
+		persistentStore = persistentstore.NewStore(backend, viper.GetDuration(config.PersistenceRetentionKey))
+		persistentStore.Register("intents", intentsHolder)
+		persistentStore.Register("external-traffic", externalTrafficIntentsHolder)
+		persistentStore.Register("incoming-traffic", incomingTrafficIntentsHolder)
+		persistentStore.Register("aws", awsIntentsHolder)
+		persistentStore.Register("gcp", gcpIntentsHolder)
+		persistentStore.Register("azure", azureIntentsHolder)
+		persistentStore.Restore(errGroupCtx)
+	}
+
 	serviceIdResolver := serviceidresolver.NewResolver(mgr.GetClient())
+	intentsDiffer := intentsdiff.NewDiffer(mgr.GetClient(), intentsHolder)
 
 	resolver := resolvers.NewResolver(
 		kubeFinder,
@@ -189,6 +215,7 @@ func main() {
 		dnsCache,
 		incomingTrafficIntentsHolder,
 		trafficCollector,
+		intentsDiffer,
 	)
 	resolver.Register(mapperServer)
 
@@ -208,6 +235,26 @@ func main() {
 		})
 	}
 
+	if viper.GetBool(config.EnableAccessLogReceiverKey) {
+		alsServer := accesslogs.NewALSServer(resolver)
+		errgrp.Go(func() error {
+			defer errorreporter.AutoNotify()
+			return alsServer.Serve(errGroupCtx, viper.GetInt(config.AccessLogGRPCPortKey))
+		})
+	}
+
+	if viper.GetBool(config.EnableLinkerdCollectionKey) {
+		linkerdWatcher, err := linkerdwatcher.NewWatcher(resolver.Mutation())
+		if err != nil {
+			logrus.WithError(err).Panic("failed to initialize linkerd watcher")
+		}
+
+		errgrp.Go(func() error {
+			defer errorreporter.AutoNotify()
+			return linkerdWatcher.RunForever(errGroupCtx)
+		})
+	}
+
 	cloudUploaderConfig := clouduploader.ConfigFromViper()
 	cloudClient, cloudEnabled, err := cloudclient.NewClient(errGroupCtx)
 	if err != nil {
@@ -281,12 +328,69 @@ func main() {
 		}
 	}
 
+	webhookExporterConfig, err := webhookexporter.ConfigFromViper()
+	if err != nil {
+		logrus.WithError(err).Panic("Invalid webhook exporter configuration")
+	}
+	if webhookExporterConfig.Enabled() {
+		webhookExporter := webhookexporter.NewExporter(webhookExporterConfig)
+		errgrp.Go(func() error {
+			defer errorreporter.AutoNotify()
+			webhookExporter.RunForever(errGroupCtx)
+			return nil
+		})
+
+		intentsHolder.RegisterNotifyIntents(webhookExporter.NotifyIntents)
+		if viper.GetBool(config.ExternalTrafficCaptureEnabledKey) {
+			externalTrafficIntentsHolder.RegisterNotifyIntents(webhookExporter.NotifyExternalTrafficIntents)
+			incomingTrafficIntentsHolder.RegisterNotifyIntents(webhookExporter.NotifyIncomingTrafficIntents)
+		}
+		awsIntentsHolder.RegisterNotifyIntents(webhookExporter.NotifyAWSIntents)
+		gcpIntentsHolder.RegisterNotifyIntents(webhookExporter.NotifyGCPIntents)
+		azureIntentsHolder.RegisterNotifyIntents(webhookExporter.NotifyAzureIntents)
+		trafficCollector.RegisterNotifyTraffic(webhookExporter.NotifyTrafficLevels)
+	}
+
+	if kafkaExporterConfig := kafkaexporter.ConfigFromViper(); kafkaExporterConfig.Enabled() {
+		kafkaExporter, err := kafkaexporter.NewExporter(kafkaExporterConfig)
+		if err != nil {
+			logrus.WithError(err).Panic("Failed to initialize Kafka exporter")
+		}
+		defer func() {
+			if err := kafkaExporter.Close(); err != nil {
+				logrus.WithError(err).Error("Failed to close Kafka exporter")
+			}
+		}()
+		errgrp.Go(func() error {
+			defer errorreporter.AutoNotify()
+			kafkaExporter.RunForever(errGroupCtx)
+			return nil
+		})
+
+		intentsHolder.RegisterNotifyIntents(kafkaExporter.NotifyIntents)
+		if viper.GetBool(config.ExternalTrafficCaptureEnabledKey) {
+			externalTrafficIntentsHolder.RegisterNotifyIntents(kafkaExporter.NotifyExternalTrafficIntents)
+		}
+		trafficCollector.RegisterNotifyTraffic(kafkaExporter.NotifyTrafficLevels)
+	}
+
+	if viper.GetBool(config.ServiceGraphMetricsEnabledKey) {
+		serviceGraphCollector := servicegraphmetrics.NewCollector(intentsHolder)
+		trafficCollector.RegisterNotifyTraffic(serviceGraphCollector.NotifyTrafficLevels)
+		prometheus.MustRegister(serviceGraphCollector)
+	}
+
 	if viper.GetBool(config.OTelEnabledKey) {
 		otelExporter, err := metricexporter.NewMetricExporter(errGroupCtx)
 		if err != nil {
 			logrus.WithError(err).Panic("Failed to initialize otel exporter")
 		}
 		intentsHolder.RegisterNotifyIntents(otelExporter.NotifyIntents)
+		if viper.GetBool(config.ExternalTrafficCaptureEnabledKey) {
+			externalTrafficIntentsHolder.RegisterNotifyIntents(otelExporter.NotifyExternalTrafficIntents)
+			incomingTrafficIntentsHolder.RegisterNotifyIntents(otelExporter.NotifyIncomingTrafficIntents)
+		}
+		trafficCollector.RegisterNotifyTraffic(otelExporter.NotifyTrafficLevels)
 	}
 
 	if dnsPublisherEnabled {
@@ -297,6 +401,27 @@ func main() {
 		})
 	}
 
+	if persistentStore != nil {
+		errgrp.Go(func() error {
+			defer errorreporter.AutoNotify()
+			persistentStore.PeriodicSnapshot(errGroupCtx, viper.GetDuration(config.PersistenceSnapshotIntervalKey))
+			return nil
+		})
+	}
+
+	errgrp.Go(func() error {
+		defer errorreporter.AutoNotify()
+		intentsDiffer.PeriodicMetricsUpdate(errGroupCtx, viper.GetDuration(config.IntentsDiffMetricsIntervalKey))
+		return nil
+	})
+
+	errgrp.Go(func() error {
+		defer errorreporter.AutoNotify()
+		intentsstore.PeriodicIntentsEviction(errGroupCtx, viper.GetDuration(config.IntentsTTLKey), viper.GetDuration(config.IntentsEvictionIntervalKey),
+			intentsHolder, externalTrafficIntentsHolder, incomingTrafficIntentsHolder, awsIntentsHolder, gcpIntentsHolder, azureIntentsHolder)
+		return nil
+	})
+
 	errgrp.Go(func() error {
 		defer errorreporter.AutoNotify()
 		intentsHolder.PeriodicIntentsUpload(errGroupCtx, cloudUploaderConfig.UploadInterval)
@@ -0,0 +1,112 @@
+package accesslogs
+
+import (
+	"context"
+	"fmt"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	datav3 "github.com/envoyproxy/go-control-plane/envoy/data/accesslog/v3"
+	alsv3 "github.com/envoyproxy/go-control-plane/envoy/service/accesslog/v3"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"io"
+	"net"
+	"strconv"
+)
+
+type Reporter interface {
+	ReportAccessLogEntries(ctx context.Context, entries Entries) bool
+}
+
+// ALSServer receives access logs streamed by Envoy proxies over the gRPC access log service (ALS), configured on
+// Envoy with an `envoy.access_loggers.http_grpc` access logger.
+type ALSServer struct {
+	alsv3.UnimplementedAccessLogServiceServer
+	reporter Reporter
+}
+
+func NewALSServer(reporter Reporter) *ALSServer {
+	return &ALSServer{reporter: reporter}
+}
+
+func (s *ALSServer) StreamAccessLogs(stream alsv3.AccessLogService_StreamAccessLogsServer) error {
+	for {
+		message, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return errors.Wrap(stream.SendAndClose(&alsv3.StreamAccessLogsResponse{}))
+		}
+		if err != nil {
+			return errors.Wrap(err)
+		}
+
+		// TCP access logs carry no HTTP request, and are ignored
+		entries := EntriesFromHTTPLogs(message.GetHttpLogs().GetLogEntry())
+		if len(entries) == 0 {
+			continue
+		}
+		if !s.reporter.ReportAccessLogEntries(stream.Context(), entries) {
+			logrus.Debugf("Dropped %d access log entries", len(entries))
+		}
+	}
+}
+
+func EntriesFromHTTPLogs(logEntries []*datav3.HTTPAccessLogEntry) Entries {
+	entries := make(Entries, 0, len(logEntries))
+	for _, logEntry := range logEntries {
+		common := logEntry.GetCommonProperties()
+		request := logEntry.GetRequest()
+
+		method := ""
+		if request.GetRequestMethod() != corev3.RequestMethod_METHOD_UNSPECIFIED {
+			method = request.GetRequestMethod().String()
+		}
+		path := request.GetOriginalPath()
+		if path == "" {
+			path = request.GetPath()
+		}
+
+		entry, ok := NewEntry(
+			socketAddress(common.GetDownstreamRemoteAddress()),
+			socketAddress(common.GetDownstreamLocalAddress()),
+			socketAddress(common.GetUpstreamRemoteAddress()),
+			request.GetAuthority(),
+			path,
+			method,
+			common.GetStartTime().AsTime(),
+		)
+		if ok {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func socketAddress(address *corev3.Address) string {
+	socket := address.GetSocketAddress()
+	if socket == nil {
+		return ""
+	}
+	return net.JoinHostPort(socket.GetAddress(), strconv.Itoa(int(socket.GetPortValue())))
+}
+
+// Serve runs the ALS gRPC server until the context is done.
+func (s *ALSServer) Serve(ctx context.Context, port int) error {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return errors.Wrap(err)
+	}
+
+	server := grpc.NewServer()
+	alsv3.RegisterAccessLogServiceServer(server, s)
+	go func() {
+		<-ctx.Done()
+		// Envoy streams access logs forever, so waiting for streams to end gracefully would never return
+		server.Stop()
+	}()
+
+	logrus.Infof("Starting Envoy access log service on port %d", port)
+	if err := server.Serve(listener); err != nil {
+		return errors.Wrap(err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package accesslogs
+
+import (
+	"context"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	datav3 "github.com/envoyproxy/go-control-plane/envoy/data/accesslog/v3"
+	alsv3 "github.com/envoyproxy/go-control-plane/envoy/service/accesslog/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingReporter struct {
+	lock    sync.Mutex
+	entries Entries
+}
+
+func (r *recordingReporter) ReportAccessLogEntries(_ context.Context, entries Entries) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.entries = append(r.entries, entries...)
+	return true
+}
+
+func (r *recordingReporter) Entries() Entries {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.entries
+}
+
+func socket(ip string, port uint32) *corev3.Address {
+	return &corev3.Address{Address: &corev3.Address_SocketAddress{SocketAddress: &corev3.SocketAddress{
+		Address:       ip,
+		PortSpecifier: &corev3.SocketAddress_PortValue{PortValue: port},
+	}}}
+}
+
+func httpLogEntry(method corev3.RequestMethod, path string, originalPath string) *datav3.HTTPAccessLogEntry {
+	return &datav3.HTTPAccessLogEntry{
+		CommonProperties: &datav3.AccessLogCommon{
+			DownstreamRemoteAddress: socket("10.244.0.5", 43122),
+			DownstreamLocalAddress:  socket("10.244.0.9", 8080),
+			UpstreamRemoteAddress:   socket("10.244.0.12", 8080),
+			StartTime:               timestamppb.New(time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)),
+		},
+		Request: &datav3.HTTPRequestProperties{
+			RequestMethod: method,
+			Authority:     "orders.shop.svc.cluster.local:8080",
+			Path:          path,
+			OriginalPath:  originalPath,
+		},
+	}
+}
+
+func TestEntriesFromHTTPLogs(t *testing.T) {
+	entries := EntriesFromHTTPLogs([]*datav3.HTTPAccessLogEntry{
+		httpLogEntry(corev3.RequestMethod_DELETE, "/internal/orders/1", "/api/orders/1?force=true"),
+		httpLogEntry(corev3.RequestMethod_METHOD_UNSPECIFIED, "/api/orders", ""),
+	})
+	require.Equal(t, Entries{{
+		SrcIP:     "10.244.0.5",
+		ProxyIP:   "10.244.0.9",
+		DstIP:     "10.244.0.12",
+		Authority: "orders.shop.svc.cluster.local:8080",
+		Path:      "/api/orders/1",
+		Method:    "DELETE",
+		LastSeen:  time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC),
+	}}, entries)
+}
+
+func TestStreamAccessLogs(t *testing.T) {
+	reporter := &recordingReporter{}
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	alsv3.RegisterAccessLogServiceServer(server, NewALSServer(reporter))
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	stream, err := alsv3.NewAccessLogServiceClient(conn).StreamAccessLogs(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&alsv3.StreamAccessLogsMessage{
+		LogEntries: &alsv3.StreamAccessLogsMessage_HttpLogs{HttpLogs: &alsv3.StreamAccessLogsMessage_HTTPAccessLogEntries{
+			LogEntry: []*datav3.HTTPAccessLogEntry{httpLogEntry(corev3.RequestMethod_GET, "/api/orders", "")},
+		}},
+	}))
+	require.NoError(t, stream.Send(&alsv3.StreamAccessLogsMessage{
+		LogEntries: &alsv3.StreamAccessLogsMessage_TcpLogs{TcpLogs: &alsv3.StreamAccessLogsMessage_TCPAccessLogEntries{
+			LogEntry: []*datav3.TCPAccessLogEntry{{}},
+		}},
+	}))
+	_, err = stream.CloseAndRecv()
+	require.NoError(t, err)
+
+	entries := reporter.Entries()
+	require.Len(t, entries, 1)
+	require.Equal(t, "/api/orders", entries[0].Path)
+	require.Equal(t, "GET", entries[0].Method)
+}
@@ -0,0 +1,180 @@
+package accesslogs
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Entry is an HTTP request logged by an Envoy proxy, such as a Contour, Emissary or standalone Envoy gateway.
+type Entry struct {
+	// SrcIP is the address of the client that sent the request to the proxy.
+	SrcIP string
+	// ProxyIP is the address the proxy received the request on, used as the client when SrcIP is outside the cluster.
+	ProxyIP string
+	// DstIP is the address of the upstream host the proxy forwarded the request to, if it was forwarded.
+	DstIP     string
+	Authority string
+	Path      string
+	Method    string
+	LastSeen  time.Time
+}
+
+type Entries []Entry
+
+func (e Entries) Length() int {
+	return len(e)
+}
+
+/*
+JSONEntry is an access log entry as produced by an Envoy JSON access log with the following format, and shipped to the
+mapper by an HTTP collector:
+
+	json_format:
+	  start_time: "%START_TIME%"
+	  method: "%REQ(:METHOD)%"
+	  path: "%REQ(X-ENVOY-ORIGINAL-PATH?:PATH)%"
+	  authority: "%REQ(:AUTHORITY)%"
+	  downstream_remote_address: "%DOWNSTREAM_REMOTE_ADDRESS%"
+	  downstream_local_address: "%DOWNSTREAM_LOCAL_ADDRESS%"
+	  upstream_host: "%UPSTREAM_HOST%"
+*/
+type JSONEntry struct {
+	StartTime               time.Time `json:"start_time"`
+	Method                  string    `json:"method"`
+	Path                    string    `json:"path"`
+	Authority               string    `json:"authority"`
+	DownstreamRemoteAddress string    `json:"downstream_remote_address"`
+	DownstreamLocalAddress  string    `json:"downstream_local_address"`
+	UpstreamHost            string    `json:"upstream_host"`
+}
+
+var ErrTooManyEntries = errors.NewSentinelError("too many access log entries")
+
+// ParseJSONEntries parses a JSON array of access log entries, or newline-delimited entries as shipped by most log
+// collectors. Entries are decoded one at a time, and parsing fails with ErrTooManyEntries once more than maxEntries were
+// decoded. Entries that are not HTTP requests between known addresses are skipped.
+func ParseJSONEntries(body io.Reader, maxEntries int) (Entries, error) {
+	reader := bufio.NewReader(body)
+	firstByte, err := peekNonSpace(reader)
+	if errors.Is(err, io.EOF) {
+		return Entries{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+
+	decoder := json.NewDecoder(reader)
+	isArray := firstByte == '['
+	if isArray {
+		// Consume the opening bracket, so the array's entries are decoded one at a time
+		if _, err := decoder.Token(); err != nil {
+			return nil, errors.Wrap(err)
+		}
+	}
+
+	entries := make(Entries, 0)
+	for decoded := 0; ; decoded++ {
+		if isArray && !decoder.More() {
+			if _, err := decoder.Token(); err != nil {
+				return nil, errors.Wrap(err)
+			}
+			break
+		}
+
+		var jsonEntry JSONEntry
+		err := decoder.Decode(&jsonEntry)
+		if !isArray && errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err)
+		}
+		if decoded == maxEntries {
+			return nil, errors.Wrap(ErrTooManyEntries)
+		}
+
+		entry, ok := NewEntry(jsonEntry.DownstreamRemoteAddress, jsonEntry.DownstreamLocalAddress, jsonEntry.UpstreamHost,
+			jsonEntry.Authority, jsonEntry.Path, jsonEntry.Method, jsonEntry.StartTime)
+		if ok {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func peekNonSpace(reader *bufio.Reader) (byte, error) {
+	for {
+		b, err := reader.Peek(1)
+		if err != nil {
+			return 0, errors.Wrap(err)
+		}
+		if !bytes.ContainsAny(b, " \t\r\n") {
+			return b[0], nil
+		}
+		if _, err := reader.Discard(1); err != nil {
+			return 0, errors.Wrap(err)
+		}
+	}
+}
+
+// NewEntry normalizes the fields of an access log entry, stripping ports from addresses and the query from the path.
+func NewEntry(srcAddress string, proxyAddress string, dstAddress string, authority string, path string, method string, lastSeen time.Time) (Entry, bool) {
+	entry := Entry{
+		SrcIP:     addressToIP(srcAddress),
+		ProxyIP:   addressToIP(proxyAddress),
+		DstIP:     addressToIP(dstAddress),
+		Authority: authority,
+		Path:      normalizePath(path),
+		Method:    strings.ToUpper(method),
+		LastSeen:  lastSeen,
+	}
+	if entry.LastSeen.IsZero() {
+		entry.LastSeen = time.Now()
+	}
+
+	if entry.SrcIP == "" && entry.ProxyIP == "" {
+		return Entry{}, false
+	}
+	if entry.DstIP == "" && entry.Authority == "" {
+		return Entry{}, false
+	}
+	if entry.Path == "" || entry.Method == "" {
+		return Entry{}, false
+	}
+	return entry, true
+}
+
+// addressToIP returns the IP of an "ip:port" address, or an empty string for Envoy's "-" placeholder and pipes.
+func addressToIP(address string) string {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+	if net.ParseIP(host) == nil {
+		return ""
+	}
+	return host
+}
+
+func normalizePath(path string) string {
+	if path == "" || path == "-" {
+		return ""
+	}
+	if u, err := url.Parse(path); err == nil && u.IsAbs() {
+		path = u.Path
+	}
+	if i := strings.IndexAny(path, "?#"); i != -1 {
+		path = path[:i]
+	}
+	if !strings.HasPrefix(path, "/") {
+		return ""
+	}
+	return path
+}
@@ -0,0 +1,66 @@
+package accesslogs
+
+import (
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseJSONEntriesArray(t *testing.T) {
+	body := `[
+		{"start_time": "2024-06-10T12:00:00.000Z", "method": "GET", "path": "/api/orders?limit=10", "authority": "orders.shop.svc.cluster.local:8080", "downstream_remote_address": "10.244.0.5:43122", "downstream_local_address": "10.244.0.9:8080", "upstream_host": "10.244.0.12:8080"},
+		{"start_time": "2024-06-10T12:00:01.000Z", "method": "CONNECT", "path": "-", "authority": "example.com:443", "downstream_remote_address": "10.244.0.5:43123", "downstream_local_address": "10.244.0.9:8080", "upstream_host": "-"}
+	]`
+	entries, err := ParseJSONEntries(strings.NewReader(body), 100)
+	require.NoError(t, err)
+	require.Equal(t, Entries{{
+		SrcIP:     "10.244.0.5",
+		ProxyIP:   "10.244.0.9",
+		DstIP:     "10.244.0.12",
+		Authority: "orders.shop.svc.cluster.local:8080",
+		Path:      "/api/orders",
+		Method:    "GET",
+		LastSeen:  time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC),
+	}}, entries)
+}
+
+func TestParseJSONEntriesNewlineDelimited(t *testing.T) {
+	body := `
+{"method": "post", "path": "http://orders.shop.svc.cluster.local/api/orders#top", "authority": "orders.shop.svc.cluster.local", "downstream_remote_address": "203.0.113.7:50000", "downstream_local_address": "10.244.0.9:8080", "upstream_host": ""}
+{"method": "GET", "path": "/healthz", "authority": "", "downstream_remote_address": "10.244.0.5:43122", "downstream_local_address": "10.244.0.9:8080", "upstream_host": ""}
+`
+	entries, err := ParseJSONEntries(strings.NewReader(body), 100)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "203.0.113.7", entries[0].SrcIP)
+	require.Equal(t, "", entries[0].DstIP)
+	require.Equal(t, "orders.shop.svc.cluster.local", entries[0].Authority)
+	require.Equal(t, "/api/orders", entries[0].Path)
+	require.Equal(t, "POST", entries[0].Method)
+	require.False(t, entries[0].LastSeen.IsZero())
+}
+
+func TestParseJSONEntriesEmptyAndInvalid(t *testing.T) {
+	entries, err := ParseJSONEntries(strings.NewReader("  \n"), 100)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	_, err = ParseJSONEntries(strings.NewReader(`{"method": "GET"`), 100)
+	require.Error(t, err)
+}
+
+func TestParseJSONEntriesMaxEntries(t *testing.T) {
+	entry := `{"method": "GET", "path": "/api/orders", "authority": "orders", "downstream_remote_address": "10.244.0.5:43122", "downstream_local_address": "10.244.0.9:8080", "upstream_host": "10.244.0.12:8080"}`
+
+	entries, err := ParseJSONEntries(strings.NewReader("["+entry+","+entry+"]"), 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	_, err = ParseJSONEntries(strings.NewReader("["+entry+","+entry+","+entry+"]"), 2)
+	require.True(t, errors.Is(err, ErrTooManyEntries))
+
+	_, err = ParseJSONEntries(strings.NewReader(entry+"\n"+entry+"\n"+entry+"\n"), 2)
+	require.True(t, errors.Is(err, ErrTooManyEntries))
+}
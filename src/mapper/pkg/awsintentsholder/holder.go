@@ -3,6 +3,7 @@ package awsintentsholder
 import (
 	"context"
 	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
+	"github.com/otterize/network-mapper/src/mapper/pkg/intentsstore"
 	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 	"sync"
@@ -28,16 +29,18 @@ type TimestampedAWSIntent struct {
 }
 
 type AWSIntentsHolder struct {
-	intents   map[AWSIntentKey]TimestampedAWSIntent
-	lock      sync.Mutex
-	callbacks []AWSIntentCallbackFunc
+	intents             map[AWSIntentKey]TimestampedAWSIntent
+	accumulatingIntents map[AWSIntentKey]TimestampedAWSIntent
+	lock                sync.Mutex
+	callbacks           []AWSIntentCallbackFunc
 }
 
 type AWSIntentCallbackFunc func(context.Context, []AWSIntent)
 
 func New() *AWSIntentsHolder {
 	notifier := &AWSIntentsHolder{
-		intents: make(map[AWSIntentKey]TimestampedAWSIntent),
+		intents:             make(map[AWSIntentKey]TimestampedAWSIntent),
+		accumulatingIntents: make(map[AWSIntentKey]TimestampedAWSIntent),
 	}
 
 	return notifier
@@ -59,20 +62,25 @@ func (h *AWSIntentsHolder) AddIntent(intent AWSIntent) {
 		ARN:             intent.ARN,
 	}
 
-	_, found := h.intents[key]
 	now := time.Now()
+	mergeIntent(h.intents, key, intent, now)
+	mergeIntent(h.accumulatingIntents, key, intent, now)
+}
+
+func mergeIntent(intents map[AWSIntentKey]TimestampedAWSIntent, key AWSIntentKey, intent AWSIntent, now time.Time) {
+	_, found := intents[key]
 
 	if !found {
-		h.intents[key] = TimestampedAWSIntent{
+		intents[key] = TimestampedAWSIntent{
 			Timestamp: now,
 			AWSIntent: intent,
 		}
 	}
 
-	mergedIntent := h.intents[key]
+	mergedIntent := intents[key]
 	mergedIntent.Timestamp = now
 	mergedIntent.Actions = lo.Union(mergedIntent.Actions, intent.Actions)
-	h.intents[key] = mergedIntent
+	intents[key] = mergedIntent
 }
 
 func (h *AWSIntentsHolder) PeriodicIntentsUpload(ctx context.Context, interval time.Duration) {
@@ -111,3 +119,27 @@ func (h *AWSIntentsHolder) GetNewIntentsSinceLastGet() []AWSIntent {
 
 	return intents
 }
+
+// GetIntents returns all the intents seen since startup or since the last Reset, regardless of whether they were
+// already uploaded.
+func (h *AWSIntentsHolder) GetIntents() []TimestampedAWSIntent {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return lo.Values(h.accumulatingIntents)
+}
+
+// EvictIntentsNotSeenSince removes intents last seen before cutoff, returning the number of intents removed.
+func (h *AWSIntentsHolder) EvictIntentsNotSeenSince(cutoff time.Time) int {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return intentsstore.DropStale(h.accumulatingIntents, cutoff, func(intent TimestampedAWSIntent) time.Time { return intent.Timestamp })
+}
+
+func (h *AWSIntentsHolder) Reset() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.accumulatingIntents = make(map[AWSIntentKey]TimestampedAWSIntent)
+}
@@ -3,6 +3,7 @@ package azureintentsholder
 import (
 	"context"
 	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
+	"github.com/otterize/network-mapper/src/mapper/pkg/intentsstore"
 	"github.com/samber/lo"
 	"k8s.io/apimachinery/pkg/types"
 	"sync"
@@ -14,17 +15,24 @@ type key struct {
 	scope  string
 }
 
+type TimestampedAzureOperation struct {
+	Timestamp time.Time
+	model.AzureOperation
+}
+
 type AzureIntentsHolder struct {
-	intents   map[key]model.AzureOperation
-	lock      sync.Mutex
-	callbacks []Callback
+	intents             map[key]TimestampedAzureOperation
+	accumulatingIntents map[key]TimestampedAzureOperation
+	lock                sync.Mutex
+	callbacks           []Callback
 }
 
 type Callback func(context.Context, []model.AzureOperation)
 
 func New() *AzureIntentsHolder {
 	return &AzureIntentsHolder{
-		intents: make(map[key]model.AzureOperation),
+		intents:             make(map[key]TimestampedAzureOperation),
+		accumulatingIntents: make(map[key]TimestampedAzureOperation),
 	}
 }
 
@@ -44,23 +52,35 @@ func (h *AzureIntentsHolder) AddOperation(serviceId model.OtterizeServiceIdentit
 		scope: op.Scope,
 	}
 
-	_, found := h.intents[k]
+	now := time.Now()
+	mergeOperation(h.intents, k, serviceId, op, now)
+	mergeOperation(h.accumulatingIntents, k, serviceId, op, now)
+}
+
+func mergeOperation(intents map[key]TimestampedAzureOperation, k key, serviceId model.OtterizeServiceIdentity, op model.AzureOperation, now time.Time) {
+	_, found := intents[k]
 
 	if !found {
-		h.intents[k] = model.AzureOperation{
-			Scope:           op.Scope,
-			Actions:         op.Actions,
-			DataActions:     op.DataActions,
-			ClientName:      serviceId.Name,
-			ClientNamespace: serviceId.Namespace,
+		intents[k] = TimestampedAzureOperation{
+			Timestamp: now,
+			AzureOperation: model.AzureOperation{
+				Scope:           op.Scope,
+				Actions:         op.Actions,
+				DataActions:     op.DataActions,
+				ClientName:      serviceId.Name,
+				ClientNamespace: serviceId.Namespace,
+			},
 		}
 	} else {
-		h.intents[k] = model.AzureOperation{
-			Scope:           op.Scope,
-			Actions:         lo.Union(h.intents[k].Actions, op.Actions),
-			DataActions:     lo.Union(h.intents[k].DataActions, op.DataActions),
-			ClientName:      h.intents[k].ClientName,
-			ClientNamespace: h.intents[k].ClientNamespace,
+		intents[k] = TimestampedAzureOperation{
+			Timestamp: now,
+			AzureOperation: model.AzureOperation{
+				Scope:           op.Scope,
+				Actions:         lo.Union(intents[k].Actions, op.Actions),
+				DataActions:     lo.Union(intents[k].DataActions, op.DataActions),
+				ClientName:      intents[k].ClientName,
+				ClientNamespace: intents[k].ClientNamespace,
+			},
 		}
 	}
 }
@@ -92,8 +112,34 @@ func (h *AzureIntentsHolder) getOperations() []model.AzureOperation {
 	h.lock.Lock()
 	defer h.lock.Unlock()
 
-	intents := lo.Values(h.intents)
-	h.intents = make(map[key]model.AzureOperation)
+	intents := lo.MapToSlice(h.intents, func(_ key, op TimestampedAzureOperation) model.AzureOperation {
+		return op.AzureOperation
+	})
+	h.intents = make(map[key]TimestampedAzureOperation)
 
 	return intents
 }
+
+// GetOperations returns all the operations seen since startup or since the last Reset, regardless of whether they
+// were already uploaded.
+func (h *AzureIntentsHolder) GetOperations() []TimestampedAzureOperation {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return lo.Values(h.accumulatingIntents)
+}
+
+// EvictIntentsNotSeenSince removes operations last seen before cutoff, returning the number of operations removed.
+func (h *AzureIntentsHolder) EvictIntentsNotSeenSince(cutoff time.Time) int {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return intentsstore.DropStale(h.accumulatingIntents, cutoff, func(op TimestampedAzureOperation) time.Time { return op.Timestamp })
+}
+
+func (h *AzureIntentsHolder) Reset() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.accumulatingIntents = make(map[key]TimestampedAzureOperation)
+}
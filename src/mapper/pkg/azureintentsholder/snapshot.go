@@ -0,0 +1,74 @@
+package azureintentsholder
+
+import (
+	"encoding/json"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"time"
+)
+
+type azureSnapshot struct {
+	Accumulating []TimestampedAzureOperation
+	SinceLastGet []TimestampedAzureOperation
+}
+
+func snapshotEntries(ops map[key]TimestampedAzureOperation, cutoff time.Time) []TimestampedAzureOperation {
+	entries := make([]TimestampedAzureOperation, 0, len(ops))
+	for _, op := range ops {
+		if op.Timestamp.Before(cutoff) {
+			continue
+		}
+		entries = append(entries, op)
+	}
+	return entries
+}
+
+func restoreEntries(ops map[key]TimestampedAzureOperation, entries []TimestampedAzureOperation, cutoff time.Time) {
+	for _, op := range entries {
+		if op.Timestamp.Before(cutoff) {
+			continue
+		}
+		k := key{
+			client: types.NamespacedName{
+				Namespace: op.ClientNamespace,
+				Name:      op.ClientName,
+			},
+			scope: op.Scope,
+		}
+		if _, found := ops[k]; found {
+			continue
+		}
+		ops[k] = op
+	}
+}
+
+// Snapshot returns the operations last seen since cutoff, serialized. Older operations are left for the TTL eviction.
+func (h *AzureIntentsHolder) Snapshot(cutoff time.Time) ([]byte, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	data, err := json.Marshal(azureSnapshot{
+		Accumulating: snapshotEntries(h.accumulatingIntents, cutoff),
+		SinceLastGet: snapshotEntries(h.intents, cutoff),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	return data, nil
+}
+
+// Restore adds operations from a snapshot created by Snapshot to the holder, skipping those last seen before cutoff.
+// Operations already in the holder take precedence over restored ones.
+func (h *AzureIntentsHolder) Restore(data []byte, cutoff time.Time) error {
+	var snapshot azureSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return errors.Wrap(err)
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	restoreEntries(h.accumulatingIntents, snapshot.Accumulating, cutoff)
+	restoreEntries(h.intents, snapshot.SinceLastGet, cutoff)
+	return nil
+}
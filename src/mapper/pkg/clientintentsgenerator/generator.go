@@ -0,0 +1,329 @@
+package clientintentsgenerator
+
+import (
+	"fmt"
+	"github.com/amit7itz/goset"
+	otterizev2beta1 "github.com/otterize/intents-operator/src/operator/api/v2beta1"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"github.com/otterize/network-mapper/src/mapper/pkg/awsintentsholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/azureintentsholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/externaltrafficholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/gcpintentsholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
+	"github.com/otterize/network-mapper/src/mapper/pkg/intentsstore"
+	"github.com/samber/lo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"reflect"
+	"sigs.k8s.io/yaml"
+	"slices"
+	"strings"
+)
+
+const clientIntentsKind = "ClientIntents"
+
+var kafkaOperations = map[model.KafkaOperation]otterizev2beta1.KafkaOperation{
+	model.KafkaOperationAll:             otterizev2beta1.KafkaOperationAll,
+	model.KafkaOperationConsume:         otterizev2beta1.KafkaOperationConsume,
+	model.KafkaOperationProduce:         otterizev2beta1.KafkaOperationProduce,
+	model.KafkaOperationCreate:          otterizev2beta1.KafkaOperationCreate,
+	model.KafkaOperationAlter:           otterizev2beta1.KafkaOperationAlter,
+	model.KafkaOperationDelete:          otterizev2beta1.KafkaOperationDelete,
+	model.KafkaOperationDescribe:        otterizev2beta1.KafkaOperationDescribe,
+	model.KafkaOperationClusterAction:   otterizev2beta1.KafkaOperationClusterAction,
+	model.KafkaOperationDescribeConfigs: otterizev2beta1.KafkaOperationDescribeConfigs,
+	model.KafkaOperationAlterConfigs:    otterizev2beta1.KafkaOperationAlterConfigs,
+	model.KafkaOperationIdempotentWrite: otterizev2beta1.KafkaOperationIdempotentWrite,
+}
+
+// ClientIntents has no equivalent of the mapper's ALL method, so it is expanded to every method.
+var allHTTPMethods = []otterizev2beta1.HTTPMethod{
+	otterizev2beta1.HTTPMethodGet,
+	otterizev2beta1.HTTPMethodPost,
+	otterizev2beta1.HTTPMethodPut,
+	otterizev2beta1.HTTPMethodDelete,
+	otterizev2beta1.HTTPMethodOptions,
+	otterizev2beta1.HTTPMethodTrace,
+	otterizev2beta1.HTTPMethodPatch,
+	otterizev2beta1.HTTPMethodConnect,
+}
+
+type Generator struct {
+	intentsHolder                *intentsstore.IntentsHolder
+	externalTrafficIntentsHolder *externaltrafficholder.ExternalTrafficIntentsHolder
+	awsIntentsHolder             *awsintentsholder.AWSIntentsHolder
+	gcpIntentsHolder             *gcpintentsholder.GCPIntentsHolder
+	azureIntentsHolder           *azureintentsholder.AzureIntentsHolder
+}
+
+func NewGenerator(
+	intentsHolder *intentsstore.IntentsHolder,
+	externalTrafficIntentsHolder *externaltrafficholder.ExternalTrafficIntentsHolder,
+	awsIntentsHolder *awsintentsholder.AWSIntentsHolder,
+	gcpIntentsHolder *gcpintentsholder.GCPIntentsHolder,
+	azureIntentsHolder *azureintentsholder.AzureIntentsHolder,
+) *Generator {
+	return &Generator{
+		intentsHolder:                intentsHolder,
+		externalTrafficIntentsHolder: externalTrafficIntentsHolder,
+		awsIntentsHolder:             awsIntentsHolder,
+		gcpIntentsHolder:             gcpIntentsHolder,
+		azureIntentsHolder:           azureIntentsHolder,
+	}
+}
+
+type clientIntentsBuilder struct {
+	workload otterizev2beta1.Workload
+	targets  []otterizev2beta1.Target
+	domains  *goset.Set[string]
+	ips      *goset.Set[string]
+}
+
+type builders map[types.NamespacedName]*clientIntentsBuilder
+
+func (b builders) get(client types.NamespacedName) *clientIntentsBuilder {
+	builder, ok := b[client]
+	if !ok {
+		builder = &clientIntentsBuilder{
+			workload: otterizev2beta1.Workload{Name: client.Name},
+			targets:  make([]otterizev2beta1.Target, 0),
+			domains:  goset.NewSet[string](),
+			ips:      goset.NewSet[string](),
+		}
+		b[client] = builder
+	}
+	return builder
+}
+
+// Generate renders the intents discovered so far as ClientIntents, one per client. If namespaces is not empty, only
+// clients in these namespaces are included.
+func (g *Generator) Generate(namespaces []string) ([]otterizev2beta1.ClientIntents, error) {
+	namespacesSet := goset.FromSlice(namespaces)
+	includeClient := func(client types.NamespacedName) bool {
+		return namespacesSet.IsEmpty() || namespacesSet.Contains(client.Namespace)
+	}
+	clients := make(builders)
+
+	intents, err := g.intentsHolder.GetIntents(namespaces, nil, nil, false, nil, nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	for _, intent := range intents {
+		builder := clients.get(intent.Intent.Client.AsNamespacedName())
+		if intent.Intent.Client.PodOwnerKind != nil {
+			builder.workload.Kind = intent.Intent.Client.PodOwnerKind.Kind
+		}
+		target := serviceTarget(intent.Intent)
+		// ClientIntents do not declare protocols, so TCP and UDP intents to the same server are declared by one target
+		isDuplicate := slices.ContainsFunc(builder.targets, func(existing otterizev2beta1.Target) bool {
+			return reflect.DeepEqual(existing, target)
+		})
+		if isDuplicate {
+			continue
+		}
+		builder.targets = append(builder.targets, target)
+	}
+
+	for _, intent := range g.externalTrafficIntentsHolder.GetIntents() {
+		client := intent.Intent.Client.AsNamespacedName()
+		if !includeClient(client) {
+			continue
+		}
+		builder := clients.get(client)
+		if intent.Intent.DNSName != "" {
+			builder.domains.Add(intent.Intent.DNSName)
+			continue
+		}
+		for ip := range intent.Intent.IPs {
+			builder.ips.Add(string(ip))
+		}
+	}
+
+	for _, intent := range g.awsIntentsHolder.GetIntents() {
+		client := intent.Client.AsNamespacedName()
+		if !includeClient(client) {
+			continue
+		}
+		clients.get(client).targets = append(clients.get(client).targets, otterizev2beta1.Target{
+			AWS: &otterizev2beta1.AWSTarget{ARN: intent.ARN, Actions: sorted(intent.Actions)},
+		})
+	}
+
+	for _, intent := range g.gcpIntentsHolder.GetIntents() {
+		client := intent.Client.AsNamespacedName()
+		if !includeClient(client) {
+			continue
+		}
+		clients.get(client).targets = append(clients.get(client).targets, otterizev2beta1.Target{
+			GCP: &otterizev2beta1.GCPTarget{Resource: intent.Resource, Permissions: sorted(intent.Permissions)},
+		})
+	}
+
+	for _, op := range g.azureIntentsHolder.GetOperations() {
+		client := types.NamespacedName{Namespace: op.ClientNamespace, Name: op.ClientName}
+		if !includeClient(client) {
+			continue
+		}
+		clients.get(client).targets = append(clients.get(client).targets, otterizev2beta1.Target{
+			Azure: &otterizev2beta1.AzureTarget{
+				Scope: op.Scope,
+				Actions: lo.Map(sorted(op.Actions), func(action string, _ int) otterizev2beta1.AzureAction {
+					return otterizev2beta1.AzureAction(action)
+				}),
+				DataActions: lo.Map(sorted(op.DataActions), func(action string, _ int) otterizev2beta1.AzureDataAction {
+					return otterizev2beta1.AzureDataAction(action)
+				}),
+			},
+		})
+	}
+
+	result := make([]otterizev2beta1.ClientIntents, 0, len(clients))
+	for client, builder := range clients {
+		if builder.domains.Len() > 0 || builder.ips.Len() > 0 {
+			builder.targets = append(builder.targets, otterizev2beta1.Target{
+				Internet: &otterizev2beta1.Internet{
+					Domains: sorted(builder.domains.Items()),
+					Ips:     sorted(builder.ips.Items()),
+				},
+			})
+		}
+		slices.SortFunc(builder.targets, func(a, b otterizev2beta1.Target) int {
+			return strings.Compare(targetSortKey(a), targetSortKey(b))
+		})
+
+		result = append(result, otterizev2beta1.ClientIntents{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: otterizev2beta1.GroupVersion.String(),
+				Kind:       clientIntentsKind,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      client.Name,
+				Namespace: client.Namespace,
+			},
+			Spec: &otterizev2beta1.IntentsSpec{
+				Workload: builder.workload,
+				Targets:  builder.targets,
+			},
+		})
+	}
+
+	// sorting by client so results are more consistent
+	slices.SortFunc(result, func(a, b otterizev2beta1.ClientIntents) int {
+		if a.Namespace != b.Namespace {
+			return strings.Compare(a.Namespace, b.Namespace)
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return result, nil
+}
+
+// GenerateYAML renders the result of Generate as a multi-document YAML, ready to be applied with kubectl.
+func (g *Generator) GenerateYAML(namespaces []string) (string, error) {
+	clientIntents, err := g.Generate(namespaces)
+	if err != nil {
+		return "", errors.Wrap(err)
+	}
+
+	documents := make([]string, 0, len(clientIntents))
+	for _, ci := range clientIntents {
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&ci)
+		if err != nil {
+			return "", errors.Wrap(err)
+		}
+		// Status and server-populated metadata are meaningless in a manifest that has not been applied yet.
+		delete(obj, "status")
+		if metadata, ok := obj["metadata"].(map[string]any); ok {
+			delete(metadata, "creationTimestamp")
+		}
+
+		document, err := yaml.Marshal(obj)
+		if err != nil {
+			return "", errors.Wrap(err)
+		}
+		documents = append(documents, string(document))
+	}
+
+	return strings.Join(documents, "---\n"), nil
+}
+
+func serviceTarget(intent model.Intent) otterizev2beta1.Target {
+	serverName := intent.Server.Name
+	if intent.Server.Namespace != intent.Client.Namespace {
+		serverName = fmt.Sprintf("%s.%s", intent.Server.Name, intent.Server.Namespace)
+	}
+
+	if lo.FromPtr(intent.Type) == model.IntentTypeKafka {
+		// ClientIntents only declare access to topics.
+		topics := lo.FilterMap(intent.KafkaTopics, func(topic model.KafkaConfig, _ int) (otterizev2beta1.KafkaTopic, bool) {
+			return otterizev2beta1.KafkaTopic{
+				Name: topic.Name,
+				Operations: lo.Uniq(lo.FilterMap(topic.Operations, func(op model.KafkaOperation, _ int) (otterizev2beta1.KafkaOperation, bool) {
+					operation, ok := kafkaOperations[op]
+					return operation, ok
+				})),
+			}, topic.IsTopic()
+		})
+		slices.SortFunc(topics, func(a, b otterizev2beta1.KafkaTopic) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+		return otterizev2beta1.Target{Kafka: &otterizev2beta1.KafkaTarget{Name: serverName, Topics: topics}}
+	}
+
+	httpTargets := lo.Map(intent.HTTPResources, func(resource model.HTTPResource, _ int) otterizev2beta1.HTTPTarget {
+		return otterizev2beta1.HTTPTarget{Path: resource.Path, Methods: httpMethods(resource.Methods)}
+	})
+	slices.SortFunc(httpTargets, func(a, b otterizev2beta1.HTTPTarget) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	if intent.Server.KubernetesService != nil {
+		serviceName := *intent.Server.KubernetesService
+		if intent.Server.Namespace != intent.Client.Namespace {
+			serviceName = fmt.Sprintf("%s.%s", serviceName, intent.Server.Namespace)
+		}
+		return otterizev2beta1.Target{Service: &otterizev2beta1.ServiceTarget{Name: serviceName, HTTP: httpTargets}}
+	}
+
+	kind := ""
+	if intent.Server.PodOwnerKind != nil {
+		kind = intent.Server.PodOwnerKind.Kind
+	}
+	return otterizev2beta1.Target{Kubernetes: &otterizev2beta1.KubernetesTarget{Name: serverName, Kind: kind, HTTP: httpTargets}}
+}
+
+func httpMethods(methods []model.HTTPMethod) []otterizev2beta1.HTTPMethod {
+	if slices.Contains(methods, model.HTTPMethodAll) {
+		return allHTTPMethods
+	}
+	return lo.Uniq(lo.Map(methods, func(method model.HTTPMethod, _ int) otterizev2beta1.HTTPMethod {
+		return otterizev2beta1.HTTPMethod(method)
+	}))
+}
+
+func targetSortKey(target otterizev2beta1.Target) string {
+	switch {
+	case target.Kubernetes != nil:
+		return "kubernetes/" + target.Kubernetes.Name
+	case target.Service != nil:
+		return "service/" + target.Service.Name
+	case target.Kafka != nil:
+		return "kafka/" + target.Kafka.Name
+	case target.AWS != nil:
+		return "aws/" + target.AWS.ARN
+	case target.GCP != nil:
+		return "gcp/" + target.GCP.Resource
+	case target.Azure != nil:
+		return "azure/" + target.Azure.Scope
+	case target.Internet != nil:
+		return "internet/"
+	}
+	return ""
+}
+
+func sorted(items []string) []string {
+	result := slices.Clone(items)
+	slices.Sort(result)
+	return result
+}
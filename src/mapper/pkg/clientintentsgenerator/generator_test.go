@@ -0,0 +1,153 @@
+package clientintentsgenerator
+
+import (
+	otterizev2beta1 "github.com/otterize/intents-operator/src/operator/api/v2beta1"
+	"github.com/otterize/network-mapper/src/mapper/pkg/awsintentsholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/azureintentsholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/externaltrafficholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/gcpintentsholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
+	"github.com/otterize/network-mapper/src/mapper/pkg/intentsstore"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type GeneratorSuite struct {
+	suite.Suite
+	intentsHolder         *intentsstore.IntentsHolder
+	externalTrafficHolder *externaltrafficholder.ExternalTrafficIntentsHolder
+	awsIntentsHolder      *awsintentsholder.AWSIntentsHolder
+	gcpIntentsHolder      *gcpintentsholder.GCPIntentsHolder
+	azureIntentsHolder    *azureintentsholder.AzureIntentsHolder
+	generator             *Generator
+}
+
+func (s *GeneratorSuite) SetupTest() {
+	s.intentsHolder = intentsstore.NewIntentsHolder()
+	s.externalTrafficHolder = externaltrafficholder.NewExternalTrafficIntentsHolder()
+	s.awsIntentsHolder = awsintentsholder.New()
+	s.gcpIntentsHolder = gcpintentsholder.New()
+	s.azureIntentsHolder = azureintentsholder.New()
+	s.generator = NewGenerator(s.intentsHolder, s.externalTrafficHolder, s.awsIntentsHolder, s.gcpIntentsHolder, s.azureIntentsHolder)
+}
+
+func (s *GeneratorSuite) TestGenerate() {
+	client := model.OtterizeServiceIdentity{
+		Name:         "client",
+		Namespace:    "ns1",
+		PodOwnerKind: &model.GroupVersionKind{Kind: "Deployment"},
+	}
+	now := time.Now()
+	s.intentsHolder.AddIntent(now, model.Intent{
+		Client: &client,
+		Server: &model.OtterizeServiceIdentity{Name: "server", Namespace: "ns1", PodOwnerKind: &model.GroupVersionKind{Kind: "StatefulSet"}},
+		Type:   lo.ToPtr(model.IntentTypeHTTP),
+		HTTPResources: []model.HTTPResource{
+			{Path: "/api", Methods: []model.HTTPMethod{model.HTTPMethodGet, model.HTTPMethodPost}},
+		},
+	}, nil)
+	s.intentsHolder.AddIntent(now, model.Intent{
+		Client:      &client,
+		Server:      &model.OtterizeServiceIdentity{Name: "kafka", Namespace: "kafka-ns"},
+		Type:        lo.ToPtr(model.IntentTypeKafka),
+		KafkaTopics: []model.KafkaConfig{{Name: "orders", Operations: []model.KafkaOperation{model.KafkaOperationConsume}}},
+	}, nil)
+	s.intentsHolder.AddIntent(now, model.Intent{
+		Client: &model.OtterizeServiceIdentity{Name: "other-client", Namespace: "ns2"},
+		Server: &model.OtterizeServiceIdentity{Name: "server", Namespace: "ns1", KubernetesService: lo.ToPtr("server-svc")},
+	}, nil)
+	// The same server over UDP is declared by the same target
+	s.intentsHolder.AddIntent(now, model.Intent{
+		Client:   &model.OtterizeServiceIdentity{Name: "other-client", Namespace: "ns2"},
+		Server:   &model.OtterizeServiceIdentity{Name: "server", Namespace: "ns1", KubernetesService: lo.ToPtr("server-svc")},
+		Protocol: lo.ToPtr(model.TransportProtocolUDP),
+	}, nil)
+	s.externalTrafficHolder.AddIntent(externaltrafficholder.ExternalTrafficIntent{
+		Client:   client,
+		LastSeen: now,
+		DNSName:  "api.example.com",
+		IPs:      map[externaltrafficholder.IP]struct{}{"1.1.1.1": {}},
+	})
+	s.awsIntentsHolder.AddIntent(awsintentsholder.AWSIntent{
+		Client:  client,
+		Actions: []string{"s3:PutObject", "s3:GetObject"},
+		ARN:     "arn:aws:s3:::bucket",
+	})
+	s.gcpIntentsHolder.AddIntent(gcpintentsholder.GCPIntent{
+		Client:      client,
+		Permissions: []string{"storage.objects.get"},
+		Resource:    "projects/_/buckets/bucket",
+	})
+	s.azureIntentsHolder.AddOperation(client, model.AzureOperation{
+		Scope:   "/subscriptions/sub/resourceGroups/rg",
+		Actions: []string{"Microsoft.Storage/storageAccounts/read"},
+	})
+
+	clientIntents, err := s.generator.Generate(nil)
+	s.Require().NoError(err)
+	s.Require().Len(clientIntents, 2)
+
+	ci := clientIntents[0]
+	s.Require().Equal("ClientIntents", ci.Kind)
+	s.Require().Equal("k8s.otterize.com/v2beta1", ci.APIVersion)
+	s.Require().Equal("client", ci.Name)
+	s.Require().Equal("ns1", ci.Namespace)
+	s.Require().Equal(otterizev2beta1.Workload{Name: "client", Kind: "Deployment"}, ci.Spec.Workload)
+	s.Require().Equal([]otterizev2beta1.Target{
+		{AWS: &otterizev2beta1.AWSTarget{ARN: "arn:aws:s3:::bucket", Actions: []string{"s3:GetObject", "s3:PutObject"}}},
+		{Azure: &otterizev2beta1.AzureTarget{
+			Scope:       "/subscriptions/sub/resourceGroups/rg",
+			Actions:     []otterizev2beta1.AzureAction{"Microsoft.Storage/storageAccounts/read"},
+			DataActions: []otterizev2beta1.AzureDataAction{},
+		}},
+		{GCP: &otterizev2beta1.GCPTarget{Resource: "projects/_/buckets/bucket", Permissions: []string{"storage.objects.get"}}},
+		{Internet: &otterizev2beta1.Internet{Domains: []string{"api.example.com"}, Ips: []string{}}},
+		{Kafka: &otterizev2beta1.KafkaTarget{
+			Name:   "kafka.kafka-ns",
+			Topics: []otterizev2beta1.KafkaTopic{{Name: "orders", Operations: []otterizev2beta1.KafkaOperation{otterizev2beta1.KafkaOperationConsume}}},
+		}},
+		{Kubernetes: &otterizev2beta1.KubernetesTarget{
+			Name: "server",
+			Kind: "StatefulSet",
+			HTTP: []otterizev2beta1.HTTPTarget{{Path: "/api", Methods: []otterizev2beta1.HTTPMethod{otterizev2beta1.HTTPMethodGet, otterizev2beta1.HTTPMethodPost}}},
+		}},
+	}, ci.Spec.Targets)
+
+	s.Require().Equal("other-client", clientIntents[1].Name)
+	s.Require().Equal([]otterizev2beta1.Target{
+		{Service: &otterizev2beta1.ServiceTarget{Name: "server-svc.ns1", HTTP: []otterizev2beta1.HTTPTarget{}}},
+	}, clientIntents[1].Spec.Targets)
+}
+
+func (s *GeneratorSuite) TestGenerateYAMLFiltersNamespaces() {
+	s.intentsHolder.AddIntent(time.Now(), model.Intent{
+		Client: &model.OtterizeServiceIdentity{Name: "client", Namespace: "ns1"},
+		Server: &model.OtterizeServiceIdentity{Name: "server", Namespace: "ns1"},
+	}, nil)
+	s.awsIntentsHolder.AddIntent(awsintentsholder.AWSIntent{
+		Client:  model.OtterizeServiceIdentity{Name: "other-client", Namespace: "ns2"},
+		Actions: []string{"s3:GetObject"},
+		ARN:     "arn:aws:s3:::bucket",
+	})
+
+	clientIntentsYAML, err := s.generator.GenerateYAML([]string{"ns1"})
+	s.Require().NoError(err)
+	s.Require().Equal(`apiVersion: k8s.otterize.com/v2beta1
+kind: ClientIntents
+metadata:
+  name: client
+  namespace: ns1
+spec:
+  targets:
+  - kubernetes:
+      name: server
+  workload:
+    name: client
+`, clientIntentsYAML)
+}
+
+func TestGeneratorSuite(t *testing.T) {
+	suite.Run(t, new(GeneratorSuite))
+}
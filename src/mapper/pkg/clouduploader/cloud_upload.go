@@ -51,9 +51,10 @@ func (c *CloudUploader) NotifyIntents(ctx context.Context, intents []intentsstor
 				ServerNamespace:                   &intent.Intent.Server.Namespace,
 				ServerNameResolvedUsingAnnotation: intent.Intent.Server.NameResolvedUsingAnnotation,
 				Type:                              modelIntentTypeToAPI(intent.Intent.Type),
-				Topics: lo.Map(intent.Intent.KafkaTopics,
-					func(item model.KafkaConfig, _ int) *cloudclient.KafkaConfigInput {
-						return lo.ToPtr(modelKafkaConfToAPI(item))
+				// Otterize Cloud only accepts topics, so consumer groups and other Kafka resources are not uploaded.
+				Topics: lo.FilterMap(intent.Intent.KafkaTopics,
+					func(item model.KafkaConfig, _ int) (*cloudclient.KafkaConfigInput, bool) {
+						return lo.ToPtr(modelKafkaConfToAPI(item)), item.IsTopic()
 					},
 				),
 				Resources: httpResourceToHTTPConfInput(intent.Intent.HTTPResources),
@@ -7,4 +7,7 @@ const (
 	DNSTrafficIntentResolution        string = "handleDNSCaptureResultsAsKubernetesPods"
 	KafkaResultIntentResolution       string = "handleReportKafkaMapperResults"
 	IstioResultIntentResolution       string = "handleReportIstioConnectionResults"
+	HTTPResultIntentResolution        string = "handleReportHTTPRequestResults"
+	LinkerdResultIntentResolution     string = "handleReportLinkerdConnectionResults"
+	AccessLogIntentResolution         string = "handleAccessLogEntries"
 )
@@ -22,8 +22,15 @@ const (
 	OTelEnabledDefault                       = false
 	OTelMetricKey                            = "otel-metric-name"
 	OTelMetricDefault                        = "traces_service_graph_request_total" // same as expected in otel-collector-contrib's servicegraphprocessor
+	OTelTrafficBytesMetricKey                = "otel-traffic-bytes-metric-name"
+	OTelTrafficBytesMetricDefault            = "network_mapper_traffic_bytes_per_second"
+	OTelTrafficFlowsMetricKey                = "otel-traffic-flows-metric-name"
+	OTelTrafficFlowsMetricDefault            = "network_mapper_traffic_flows_per_second"
+	OTelResourceMetricKey                    = "otel-resource-metric-name"
+	OTelResourceMetricDefault                = "network_mapper_edge_resource_total"
 	ExternalTrafficCaptureEnabledKey         = "capture-external-traffic-enabled"
 	ExternalTrafficCaptureEnabledDefault     = true
+	TLSEgressProxiesKey                      = "tls-egress-proxies" // Services or workloads, as <name>.<namespace>, that TLS connections to Internet hosts are made through
 	CreateWebhookCertificateKey              = "create-webhook-certificate"
 	CreateWebhookCertificateDefault          = true
 	DNSCacheItemsMaxCapacityKey              = "dns-cache-items-max-capacity"
@@ -50,14 +57,76 @@ const (
 	IstioCooldownIntervalDefault              = 15 * time.Second
 	MetricFetchTimeoutKey                     = "istio-metric-fetch-timeout"
 	MetricFetchTimeoutDefault                 = 10 * time.Second
+	IstioMetricsSourceKey                     = "istio-metrics-source" // One of "exec", "sidecar-http" or "prometheus"
+	IstioMetricsSourceDefault                 = "exec"
+	IstioSidecarMetricsPortKey                = "istio-sidecar-metrics-port"
+	IstioSidecarMetricsPortDefault            = 15090
+	IstioPrometheusURLKey                     = "istio-prometheus-url"
+	EnableLinkerdCollectionKey                = "enable-linkerd-collection"
+	EnableLinkerdCollectionDefault            = false
+	LinkerdRestrictCollectionToNamespace      = "linkerd-restrict-collection-to-namespace"
+	LinkerdReportIntervalKey                  = "linkerd-report-interval"
+	LinkerdReportIntervalDefault              = 30 * time.Second
+	LinkerdCooldownIntervalKey                = "linkerd-cooldown-interval"
+	LinkerdCooldownIntervalDefault            = 15 * time.Second
+	LinkerdMetricFetchTimeoutKey              = "linkerd-metric-fetch-timeout"
+	LinkerdMetricFetchTimeoutDefault          = 10 * time.Second
+	LinkerdMetricsSourceKey                   = "linkerd-metrics-source" // One of "proxy" or "prometheus"
+	LinkerdMetricsSourceDefault               = "proxy"
+	LinkerdProxyAdminPortKey                  = "linkerd-proxy-admin-port"
+	LinkerdProxyAdminPortDefault              = 4191
+	LinkerdPrometheusURLKey                   = "linkerd-prometheus-url"
+	EnableAccessLogReceiverKey                = "enable-access-log-receiver"
+	EnableAccessLogReceiverDefault            = false
+	AccessLogGRPCPortKey                      = "access-log-grpc-port"
+	AccessLogGRPCPortDefault                  = 9091
+	AccessLogMaxRequestBytesKey               = "access-log-max-request-bytes" // Largest body accepted by the /accesslogs endpoint
+	AccessLogMaxRequestBytesDefault           = 10 * 1024 * 1024
+	AccessLogMaxRequestEntriesKey             = "access-log-max-request-entries"
+	AccessLogMaxRequestEntriesDefault         = 10000
 	TimeServerHasToLiveBeforeWeTrustItKey     = "time-server-has-to-live-before-we-trust-it"
 	TimeServerHasToLiveBeforeWeTrustItDefault = 5 * time.Minute
 
 	ControlPlaneIPv4CidrPrefixLength        = "control-plane-ipv4-cidr-prefix-length"
 	ControlPlaneIPv4CidrPrefixLengthDefault = 32
+	ControlPlaneIPv6CidrPrefixLength        = "control-plane-ipv6-cidr-prefix-length"
+	ControlPlaneIPv6CidrPrefixLengthDefault = 128
 
 	TCPDestResolveOnlyControlPlaneByIp        = "tcp-dest-resolve-only-control-plane-by-ip"
 	TCPDestResolveOnlyControlPlaneByIpDefault = true
+
+	PersistenceEnabledKey              = "persistence-enabled"
+	PersistenceEnabledDefault          = false
+	PersistencePathKey                 = "persistence-path"
+	PersistencePathDefault             = "/var/lib/otterize/network-mapper"
+	PersistenceSnapshotIntervalKey     = "persistence-snapshot-interval"
+	PersistenceSnapshotIntervalDefault = 1 * time.Minute
+	PersistenceRetentionKey            = "persistence-retention"
+	PersistenceRetentionDefault        = 7 * 24 * time.Hour
+
+	IntentsTTLKey                  = "intents-ttl"
+	IntentsTTLDefault              = 0 * time.Second // Disabled by default, intents are kept until resetCapture
+	IntentsEvictionIntervalKey     = "intents-eviction-interval"
+	IntentsEvictionIntervalDefault = 1 * time.Minute
+
+	IntentsDiffMetricsIntervalKey     = "intents-diff-metrics-interval"
+	IntentsDiffMetricsIntervalDefault = 1 * time.Minute
+
+	WebhookExportURLKey                = "webhook-export-url" // Exporting to a webhook is disabled unless a URL is set
+	WebhookExportAuthHeaderNameKey     = "webhook-export-auth-header-name"
+	WebhookExportAuthHeaderNameDefault = "Authorization"
+	WebhookExportAuthHeaderValueKey    = "webhook-export-auth-header-value"
+	WebhookExportBatchSizeKey          = "webhook-export-batch-size"
+	WebhookExportBatchSizeDefault      = 500
+	WebhookExportMaxRetriesKey         = "webhook-export-max-retries"
+	WebhookExportMaxRetriesDefault     = 10
+	WebhookExportTimeoutKey            = "webhook-export-timeout"
+	WebhookExportTimeoutDefault        = 10 * time.Second
+	KafkaExportBrokersKey              = "kafka-export-brokers" // Exporting to Kafka is disabled unless brokers are set
+	KafkaExportTopicKey                = "kafka-export-topic"
+	KafkaExportTopicDefault            = "otterize-network-mapper"
+	ServiceGraphMetricsEnabledKey      = "service-graph-metrics-enabled"
+	ServiceGraphMetricsEnabledDefault  = false
 )
 
 var excludedNamespaces *goset.Set[string]
@@ -74,7 +143,11 @@ func init() {
 	viper.SetDefault(UploadBatchSizeKey, UploadBatchSizeDefault)
 	viper.SetDefault(OTelEnabledKey, OTelEnabledDefault)
 	viper.SetDefault(OTelMetricKey, OTelMetricDefault)
+	viper.SetDefault(OTelTrafficBytesMetricKey, OTelTrafficBytesMetricDefault)
+	viper.SetDefault(OTelTrafficFlowsMetricKey, OTelTrafficFlowsMetricDefault)
+	viper.SetDefault(OTelResourceMetricKey, OTelResourceMetricDefault)
 	viper.SetDefault(ExternalTrafficCaptureEnabledKey, ExternalTrafficCaptureEnabledDefault)
+	viper.SetDefault(TLSEgressProxiesKey, []string{})
 	viper.SetDefault(CreateWebhookCertificateKey, CreateWebhookCertificateDefault)
 	viper.SetDefault(DNSCacheItemsMaxCapacityKey, DNSCacheItemsMaxCapacityDefault)
 	viper.SetDefault(DNSClientIntentsUpdateIntervalKey, DNSClientIntentsUpdateIntervalDefault)
@@ -82,15 +155,47 @@ func init() {
 	viper.SetDefault(IstioReportIntervalKey, IstioReportIntervalDefault)
 	viper.SetDefault(MetricFetchTimeoutKey, MetricFetchTimeoutDefault)
 	viper.SetDefault(IstioCooldownIntervalKey, IstioCooldownIntervalDefault)
+	viper.SetDefault(IstioMetricsSourceKey, IstioMetricsSourceDefault)
+	viper.SetDefault(IstioSidecarMetricsPortKey, IstioSidecarMetricsPortDefault)
+	viper.SetDefault(IstioPrometheusURLKey, "")
 	viper.SetDefault(IstioRestrictCollectionToNamespace, "")
 	viper.SetDefault(EnableIstioCollectionKey, EnableIstioCollectionDefault)
+	viper.SetDefault(EnableLinkerdCollectionKey, EnableLinkerdCollectionDefault)
+	viper.SetDefault(EnableAccessLogReceiverKey, EnableAccessLogReceiverDefault)
+	viper.SetDefault(AccessLogGRPCPortKey, AccessLogGRPCPortDefault)
+	viper.SetDefault(AccessLogMaxRequestBytesKey, AccessLogMaxRequestBytesDefault)
+	viper.SetDefault(AccessLogMaxRequestEntriesKey, AccessLogMaxRequestEntriesDefault)
+	viper.SetDefault(LinkerdRestrictCollectionToNamespace, "")
+	viper.SetDefault(LinkerdReportIntervalKey, LinkerdReportIntervalDefault)
+	viper.SetDefault(LinkerdCooldownIntervalKey, LinkerdCooldownIntervalDefault)
+	viper.SetDefault(LinkerdMetricFetchTimeoutKey, LinkerdMetricFetchTimeoutDefault)
+	viper.SetDefault(LinkerdMetricsSourceKey, LinkerdMetricsSourceDefault)
+	viper.SetDefault(LinkerdProxyAdminPortKey, LinkerdProxyAdminPortDefault)
+	viper.SetDefault(LinkerdPrometheusURLKey, "")
 	viper.SetDefault(ServiceCacheTTLDurationKey, ServiceCacheTTLDurationDefault)
 	viper.SetDefault(ServiceCacheSizeKey, ServiceCacheSizeDefault)
 	viper.SetDefault(MetricsCollectionTrafficCacheSizeKey, MetricsCollectionTrafficCacheSizeDefault)
 	viper.SetDefault(WebhookServicesCacheSizeKey, WebhookServicesCacheSizeDefault)
 	viper.SetDefault(TimeServerHasToLiveBeforeWeTrustItKey, TimeServerHasToLiveBeforeWeTrustItDefault)
 	viper.SetDefault(ControlPlaneIPv4CidrPrefixLength, ControlPlaneIPv4CidrPrefixLengthDefault)
+	viper.SetDefault(ControlPlaneIPv6CidrPrefixLength, ControlPlaneIPv6CidrPrefixLengthDefault)
 	viper.SetDefault(TCPDestResolveOnlyControlPlaneByIp, TCPDestResolveOnlyControlPlaneByIpDefault)
+	viper.SetDefault(PersistenceEnabledKey, PersistenceEnabledDefault)
+	viper.SetDefault(PersistencePathKey, PersistencePathDefault)
+	viper.SetDefault(PersistenceSnapshotIntervalKey, PersistenceSnapshotIntervalDefault)
+	viper.SetDefault(PersistenceRetentionKey, PersistenceRetentionDefault)
+	viper.SetDefault(IntentsTTLKey, IntentsTTLDefault)
+	viper.SetDefault(IntentsEvictionIntervalKey, IntentsEvictionIntervalDefault)
+	viper.SetDefault(IntentsDiffMetricsIntervalKey, IntentsDiffMetricsIntervalDefault)
+	viper.SetDefault(WebhookExportURLKey, "")
+	viper.SetDefault(WebhookExportAuthHeaderNameKey, WebhookExportAuthHeaderNameDefault)
+	viper.SetDefault(WebhookExportAuthHeaderValueKey, "")
+	viper.SetDefault(WebhookExportBatchSizeKey, WebhookExportBatchSizeDefault)
+	viper.SetDefault(WebhookExportMaxRetriesKey, WebhookExportMaxRetriesDefault)
+	viper.SetDefault(WebhookExportTimeoutKey, WebhookExportTimeoutDefault)
+	viper.SetDefault(KafkaExportBrokersKey, []string{})
+	viper.SetDefault(KafkaExportTopicKey, KafkaExportTopicDefault)
+	viper.SetDefault(ServiceGraphMetricsEnabledKey, ServiceGraphMetricsEnabledDefault)
 
 	excludedNamespaces = goset.FromSlice(viper.GetStringSlice(ExcludedNamespacesKey))
 }
@@ -0,0 +1,134 @@
+package exportpayload
+
+import (
+	"github.com/otterize/network-mapper/src/mapper/pkg/awsintentsholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/cloudclient"
+	"github.com/otterize/network-mapper/src/mapper/pkg/collectors/traffic"
+	"github.com/otterize/network-mapper/src/mapper/pkg/externaltrafficholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/gcpintentsholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
+	"github.com/otterize/network-mapper/src/mapper/pkg/incomingtrafficholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/intentsstore"
+	"github.com/samber/lo"
+)
+
+func fromConnectionsCount(count *cloudclient.ConnectionsCount) *ConnectionsCount {
+	if count == nil {
+		return nil
+	}
+	return &ConnectionsCount{
+		Current: lo.FromPtr(count.Current),
+		Added:   lo.FromPtr(count.Added),
+		Removed: lo.FromPtr(count.Removed),
+	}
+}
+
+func fromServiceIdentity(identity model.OtterizeServiceIdentity) Workload {
+	workload := Workload{
+		Name:              identity.Name,
+		Namespace:         identity.Namespace,
+		KubernetesService: lo.FromPtr(identity.KubernetesService),
+	}
+	if identity.PodOwnerKind != nil {
+		workload.Kind = identity.PodOwnerKind.Kind
+	}
+	return workload
+}
+
+func FromIntents(intents []intentsstore.TimestampedIntent) []Intent {
+	return lo.Map(intents, func(intent intentsstore.TimestampedIntent, _ int) Intent {
+		return Intent{
+			DiscoveredAt: intent.Timestamp,
+			Client:       fromServiceIdentity(*intent.Intent.Client),
+			Server:       fromServiceIdentity(*intent.Intent.Server),
+			Type:         string(lo.FromPtr(intent.Intent.Type)),
+			Protocol:     string(lo.FromPtr(intent.Intent.Protocol)),
+			KafkaTopics: lo.Map(intent.Intent.KafkaTopics, func(topic model.KafkaConfig, _ int) KafkaTopic {
+				return KafkaTopic{
+					Name:         topic.Name,
+					ResourceType: string(lo.FromPtr(topic.ResourceType)),
+					Operations: lo.Map(topic.Operations, func(op model.KafkaOperation, _ int) string {
+						return string(op)
+					}),
+				}
+			}),
+			HTTPResources: lo.Map(intent.Intent.HTTPResources, func(resource model.HTTPResource, _ int) HTTPResource {
+				return HTTPResource{
+					Path: resource.Path,
+					Methods: lo.Map(resource.Methods, func(method model.HTTPMethod, _ int) string {
+						return string(method)
+					}),
+				}
+			}),
+			ConnectionsCount: fromConnectionsCount(intent.ConnectionsCount),
+		}
+	})
+}
+
+func FromExternalTrafficIntents(intents []externaltrafficholder.TimestampedExternalTrafficIntent) []ExternalTrafficIntent {
+	return lo.Map(intents, func(intent externaltrafficholder.TimestampedExternalTrafficIntent, _ int) ExternalTrafficIntent {
+		return ExternalTrafficIntent{
+			DiscoveredAt: intent.Timestamp,
+			Client:       fromServiceIdentity(intent.Intent.Client),
+			DNSName:      intent.Intent.DNSName,
+			IPs: lo.MapToSlice(intent.Intent.IPs, func(ip externaltrafficholder.IP, _ struct{}) string {
+				return string(ip)
+			}),
+			ConnectionsCount: fromConnectionsCount(intent.ConnectionsCount),
+		}
+	})
+}
+
+func FromIncomingTrafficIntents(intents []incomingtrafficholder.TimestampedIncomingTrafficIntent) []IncomingTrafficIntent {
+	return lo.Map(intents, func(intent incomingtrafficholder.TimestampedIncomingTrafficIntent, _ int) IncomingTrafficIntent {
+		return IncomingTrafficIntent{
+			DiscoveredAt:     intent.Timestamp,
+			Server:           fromServiceIdentity(intent.Intent.Server),
+			SourceIP:         intent.Intent.IP,
+			ConnectionsCount: fromConnectionsCount(intent.ConnectionsCount),
+		}
+	})
+}
+
+func FromAWSIntents(intents []awsintentsholder.AWSIntent) []AWSIntent {
+	return lo.Map(intents, func(intent awsintentsholder.AWSIntent, _ int) AWSIntent {
+		return AWSIntent{
+			Client:  fromServiceIdentity(intent.Client),
+			ARN:     intent.ARN,
+			Actions: intent.Actions,
+			IAMRole: intent.IamRole,
+		}
+	})
+}
+
+func FromGCPIntents(intents []gcpintentsholder.GCPIntent) []GCPIntent {
+	return lo.Map(intents, func(intent gcpintentsholder.GCPIntent, _ int) GCPIntent {
+		return GCPIntent{
+			Client:      fromServiceIdentity(intent.Client),
+			Resource:    intent.Resource,
+			Permissions: intent.Permissions,
+		}
+	})
+}
+
+func FromAzureIntents(ops []model.AzureOperation) []AzureIntent {
+	return lo.Map(ops, func(op model.AzureOperation, _ int) AzureIntent {
+		return AzureIntent{
+			Client:      Workload{Name: op.ClientName, Namespace: op.ClientNamespace},
+			Scope:       op.Scope,
+			Actions:     op.Actions,
+			DataActions: op.DataActions,
+		}
+	})
+}
+
+func FromTrafficLevels(trafficLevels traffic.TrafficLevelMap) []TrafficLevel {
+	return lo.MapToSlice(trafficLevels, func(key traffic.TrafficLevelKey, data traffic.TrafficLevelData) TrafficLevel {
+		return TrafficLevel{
+			Client:              Workload{Name: key.SourceName, Namespace: key.SourceNamespace},
+			Server:              Workload{Name: key.DestinationName, Namespace: key.DestinationNamespace},
+			DataBytesPerSecond:  data.Bytes,
+			FlowsCountPerSecond: data.Flows,
+		}
+	})
+}
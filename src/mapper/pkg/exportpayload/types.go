@@ -0,0 +1,110 @@
+package exportpayload
+
+import "time"
+
+// The types in this package define the JSON documents published by the network mapper's exporters, and are part of
+// their public contract: fields may be added, but existing fields must not be renamed or removed.
+//
+// Every document carries a Kind which determines the type of the items it holds:
+//
+//	intents                - Intent
+//	externalTrafficIntents - ExternalTrafficIntent
+//	incomingTrafficIntents - IncomingTrafficIntent
+//	awsIntents             - AWSIntent
+//	gcpIntents             - GCPIntent
+//	azureIntents           - AzureIntent
+//	trafficLevels          - TrafficLevel
+
+type Kind string
+
+const (
+	KindIntents                Kind = "intents"
+	KindExternalTrafficIntents Kind = "externalTrafficIntents"
+	KindIncomingTrafficIntents Kind = "incomingTrafficIntents"
+	KindAWSIntents             Kind = "awsIntents"
+	KindGCPIntents             Kind = "gcpIntents"
+	KindAzureIntents           Kind = "azureIntents"
+	KindTrafficLevels          Kind = "trafficLevels"
+)
+
+type Workload struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// Kind of the pod owner, e.g. Deployment, if known.
+	Kind string `json:"kind,omitempty"`
+	// Name of the Kubernetes service the workload was accessed through, if any.
+	KubernetesService string `json:"kubernetesService,omitempty"`
+}
+
+// ConnectionsCount is the number of distinct connections since the previous payload of the same kind.
+type ConnectionsCount struct {
+	Current int `json:"current"`
+	Added   int `json:"added"`
+	Removed int `json:"removed"`
+}
+
+type KafkaTopic struct {
+	Name       string   `json:"name"`
+	Operations []string `json:"operations,omitempty"`
+	// Type of the Kafka resource - GROUP, TRANSACTIONAL_ID or CLUSTER - when it is not a topic.
+	ResourceType string `json:"resourceType,omitempty"`
+}
+
+type HTTPResource struct {
+	Path    string   `json:"path"`
+	Methods []string `json:"methods,omitempty"`
+}
+
+type Intent struct {
+	DiscoveredAt     time.Time         `json:"discoveredAt"`
+	Client           Workload          `json:"client"`
+	Server           Workload          `json:"server"`
+	Type             string            `json:"type,omitempty"`
+	Protocol         string            `json:"protocol,omitempty"`
+	KafkaTopics      []KafkaTopic      `json:"kafkaTopics,omitempty"`
+	HTTPResources    []HTTPResource    `json:"httpResources,omitempty"`
+	ConnectionsCount *ConnectionsCount `json:"connectionsCount,omitempty"`
+}
+
+type ExternalTrafficIntent struct {
+	DiscoveredAt     time.Time         `json:"discoveredAt"`
+	Client           Workload          `json:"client"`
+	DNSName          string            `json:"dnsName,omitempty"`
+	IPs              []string          `json:"ips"`
+	ConnectionsCount *ConnectionsCount `json:"connectionsCount,omitempty"`
+}
+
+type IncomingTrafficIntent struct {
+	DiscoveredAt     time.Time         `json:"discoveredAt"`
+	Server           Workload          `json:"server"`
+	SourceIP         string            `json:"sourceIp"`
+	ConnectionsCount *ConnectionsCount `json:"connectionsCount,omitempty"`
+}
+
+type AWSIntent struct {
+	Client  Workload `json:"client"`
+	ARN     string   `json:"arn"`
+	Actions []string `json:"actions"`
+	IAMRole string   `json:"iamRole,omitempty"`
+}
+
+type GCPIntent struct {
+	Client      Workload `json:"client"`
+	Resource    string   `json:"resource"`
+	Permissions []string `json:"permissions"`
+}
+
+type AzureIntent struct {
+	Client      Workload `json:"client"`
+	Scope       string   `json:"scope"`
+	Actions     []string `json:"actions"`
+	DataActions []string `json:"dataActions"`
+}
+
+// TrafficLevel is the average traffic between two workloads over the last hour.
+type TrafficLevel struct {
+	Client              Workload `json:"client"`
+	Server              Workload `json:"server"`
+	DataBytesPerSecond  int      `json:"dataBytesPerSecond"`
+	FlowsCountPerSecond int      `json:"flowsCountPerSecond"`
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/otterize/network-mapper/src/mapper/pkg/concurrentconnectioncounter"
 	"github.com/otterize/network-mapper/src/mapper/pkg/config"
 	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
+	"github.com/otterize/network-mapper/src/mapper/pkg/intentsstore"
 	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 	"sync"
@@ -37,8 +38,10 @@ type IntentsConnectionCounter map[ExternalTrafficKey]*concurrentconnectioncounte
 
 type ExternalTrafficIntentsHolder struct {
 	intents               map[ExternalTrafficKey]TimestampedExternalTrafficIntent
+	accumulatingIntents   map[ExternalTrafficKey]TimestampedExternalTrafficIntent
 	lock                  sync.Mutex
 	callbacks             []ExternalTrafficCallbackFunc
+	newIntentCallbacks    []func(TimestampedExternalTrafficIntent)
 	connectionCountDiffer *concurrentconnectioncounter.ConnectionCountDiffer[ExternalTrafficKey, *concurrentconnectioncounter.CountableIntentExternalTrafficIntent]
 }
 
@@ -47,6 +50,7 @@ type ExternalTrafficCallbackFunc func(context.Context, []TimestampedExternalTraf
 func NewExternalTrafficIntentsHolder() *ExternalTrafficIntentsHolder {
 	return &ExternalTrafficIntentsHolder{
 		intents:               make(map[ExternalTrafficKey]TimestampedExternalTrafficIntent),
+		accumulatingIntents:   make(map[ExternalTrafficKey]TimestampedExternalTrafficIntent),
 		connectionCountDiffer: concurrentconnectioncounter.NewConnectionCountDiffer[ExternalTrafficKey, *concurrentconnectioncounter.CountableIntentExternalTrafficIntent](),
 	}
 }
@@ -55,6 +59,12 @@ func (h *ExternalTrafficIntentsHolder) RegisterNotifyIntents(callback ExternalTr
 	h.callbacks = append(h.callbacks, callback)
 }
 
+// RegisterNotifyNewIntent registers a callback that is called whenever an intent is added for the first time.
+// Callbacks are called while the holder is locked, and must not block.
+func (h *ExternalTrafficIntentsHolder) RegisterNotifyNewIntent(callback func(TimestampedExternalTrafficIntent)) {
+	h.newIntentCallbacks = append(h.newIntentCallbacks, callback)
+}
+
 func (h *ExternalTrafficIntentsHolder) PeriodicIntentsUpload(ctx context.Context, interval time.Duration) {
 	logrus.Info("Starting periodic external traffic intents upload")
 
@@ -114,22 +124,35 @@ func (h *ExternalTrafficIntentsHolder) AddIntent(intent ExternalTrafficIntent) {
 		ClientNamespace: intent.Client.Namespace,
 		DestDNSName:     intent.DNSName,
 	}
-	_, found := h.intents[key]
 	h.connectionCountDiffer.Increment(key, concurrentconnectioncounter.CounterInput[*concurrentconnectioncounter.CountableIntentExternalTrafficIntent]{
 		Intent:      concurrentconnectioncounter.NewCountableIntentExternalTrafficIntent(),
 		SourcePorts: make([]int64, 0),
 	})
 
+	_, found := h.accumulatingIntents[key]
+
+	mergeIntent(h.intents, key, intent)
+	mergeIntent(h.accumulatingIntents, key, intent)
+
 	if !found {
-		h.intents[key] = TimestampedExternalTrafficIntent{
+		for _, callback := range h.newIntentCallbacks {
+			callback(h.accumulatingIntents[key])
+		}
+	}
+}
+
+func mergeIntent(intents map[ExternalTrafficKey]TimestampedExternalTrafficIntent, key ExternalTrafficKey, intent ExternalTrafficIntent) {
+	mergedIntent, found := intents[key]
+	if !found {
+		// Each store gets its own copy of the IPs, as they are merged in place.
+		intent.IPs = lo.Assign(intent.IPs)
+		intents[key] = TimestampedExternalTrafficIntent{
 			Timestamp: intent.LastSeen,
 			Intent:    intent,
 		}
 		return
 	}
 
-	mergedIntent := h.intents[key]
-
 	for ip := range intent.IPs {
 		mergedIntent.Intent.IPs[ip] = struct{}{}
 	}
@@ -137,5 +160,32 @@ func (h *ExternalTrafficIntentsHolder) AddIntent(intent ExternalTrafficIntent) {
 		mergedIntent.Timestamp = intent.LastSeen
 	}
 
-	h.intents[key] = mergedIntent
+	intents[key] = mergedIntent
+}
+
+// GetIntents returns all the external traffic intents seen since startup or since the last Reset, regardless of
+// whether they were already uploaded.
+func (h *ExternalTrafficIntentsHolder) GetIntents() []TimestampedExternalTrafficIntent {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return lo.MapToSlice(h.accumulatingIntents, func(_ ExternalTrafficKey, intent TimestampedExternalTrafficIntent) TimestampedExternalTrafficIntent {
+		intent.Intent.IPs = lo.Assign(intent.Intent.IPs)
+		return intent
+	})
+}
+
+// EvictIntentsNotSeenSince removes intents last seen before cutoff, returning the number of intents removed.
+func (h *ExternalTrafficIntentsHolder) EvictIntentsNotSeenSince(cutoff time.Time) int {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return intentsstore.DropStale(h.accumulatingIntents, cutoff, func(intent TimestampedExternalTrafficIntent) time.Time { return intent.Timestamp })
+}
+
+func (h *ExternalTrafficIntentsHolder) Reset() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.accumulatingIntents = make(map[ExternalTrafficKey]TimestampedExternalTrafficIntent)
 }
@@ -0,0 +1,75 @@
+package externaltrafficholder
+
+import (
+	"encoding/json"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"time"
+)
+
+type externalTrafficSnapshot struct {
+	Accumulating []TimestampedExternalTrafficIntent
+	SinceLastGet []TimestampedExternalTrafficIntent
+}
+
+func snapshotEntries(intents map[ExternalTrafficKey]TimestampedExternalTrafficIntent, cutoff time.Time) []TimestampedExternalTrafficIntent {
+	entries := make([]TimestampedExternalTrafficIntent, 0, len(intents))
+	for _, intent := range intents {
+		if intent.Timestamp.Before(cutoff) {
+			continue
+		}
+		intent.ConnectionsCount = nil
+		entries = append(entries, intent)
+	}
+	return entries
+}
+
+func restoreEntries(intents map[ExternalTrafficKey]TimestampedExternalTrafficIntent, entries []TimestampedExternalTrafficIntent, cutoff time.Time) {
+	for _, intent := range entries {
+		if intent.Timestamp.Before(cutoff) {
+			continue
+		}
+		key := ExternalTrafficKey{
+			ClientName:      intent.Intent.Client.Name,
+			ClientNamespace: intent.Intent.Client.Namespace,
+			DestDNSName:     intent.Intent.DNSName,
+		}
+		if _, found := intents[key]; found {
+			continue
+		}
+		if intent.Intent.IPs == nil {
+			intent.Intent.IPs = make(map[IP]struct{})
+		}
+		intents[key] = intent
+	}
+}
+
+// Snapshot returns the intents last seen since cutoff, serialized. Older intents are left for the TTL eviction.
+func (h *ExternalTrafficIntentsHolder) Snapshot(cutoff time.Time) ([]byte, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	data, err := json.Marshal(externalTrafficSnapshot{
+		Accumulating: snapshotEntries(h.accumulatingIntents, cutoff),
+		SinceLastGet: snapshotEntries(h.intents, cutoff),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	return data, nil
+}
+
+// Restore adds intents from a snapshot created by Snapshot to the holder, skipping those last seen before cutoff.
+// Intents already in the holder take precedence over restored ones.
+func (h *ExternalTrafficIntentsHolder) Restore(data []byte, cutoff time.Time) error {
+	var snapshot externalTrafficSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return errors.Wrap(err)
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	restoreEntries(h.accumulatingIntents, snapshot.Accumulating, cutoff)
+	restoreEntries(h.intents, snapshot.SinceLastGet, cutoff)
+	return nil
+}
@@ -3,6 +3,7 @@ package gcpintentsholder
 import (
 	"context"
 	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
+	"github.com/otterize/network-mapper/src/mapper/pkg/intentsstore"
 	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 	"sync"
@@ -27,16 +28,18 @@ type TimestampedGCPIntent struct {
 }
 
 type GCPIntentsHolder struct {
-	intents   map[GCPIntentKey]TimestampedGCPIntent
-	lock      sync.Mutex
-	callbacks []GCPIntentCallbackFunc
+	intents             map[GCPIntentKey]TimestampedGCPIntent
+	accumulatingIntents map[GCPIntentKey]TimestampedGCPIntent
+	lock                sync.Mutex
+	callbacks           []GCPIntentCallbackFunc
 }
 
 type GCPIntentCallbackFunc func(context.Context, []GCPIntent)
 
 func New() *GCPIntentsHolder {
 	notifier := &GCPIntentsHolder{
-		intents: make(map[GCPIntentKey]TimestampedGCPIntent),
+		intents:             make(map[GCPIntentKey]TimestampedGCPIntent),
+		accumulatingIntents: make(map[GCPIntentKey]TimestampedGCPIntent),
 	}
 
 	return notifier
@@ -58,20 +61,25 @@ func (h *GCPIntentsHolder) AddIntent(intent GCPIntent) {
 		Resource:        intent.Resource,
 	}
 
-	_, found := h.intents[key]
 	now := time.Now()
+	mergeIntent(h.intents, key, intent, now)
+	mergeIntent(h.accumulatingIntents, key, intent, now)
+}
+
+func mergeIntent(intents map[GCPIntentKey]TimestampedGCPIntent, key GCPIntentKey, intent GCPIntent, now time.Time) {
+	_, found := intents[key]
 
 	if !found {
-		h.intents[key] = TimestampedGCPIntent{
+		intents[key] = TimestampedGCPIntent{
 			Timestamp: now,
 			GCPIntent: intent,
 		}
 	}
 
-	mergedIntent := h.intents[key]
+	mergedIntent := intents[key]
 	mergedIntent.Timestamp = now
 	mergedIntent.Permissions = lo.Union(mergedIntent.Permissions, intent.Permissions)
-	h.intents[key] = mergedIntent
+	intents[key] = mergedIntent
 }
 
 func (h *GCPIntentsHolder) PeriodicIntentsUpload(ctx context.Context, interval time.Duration) {
@@ -110,3 +118,27 @@ func (h *GCPIntentsHolder) GetNewIntentsSinceLastGet() []GCPIntent {
 
 	return intents
 }
+
+// GetIntents returns all the intents seen since startup or since the last Reset, regardless of whether they were
+// already uploaded.
+func (h *GCPIntentsHolder) GetIntents() []TimestampedGCPIntent {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return lo.Values(h.accumulatingIntents)
+}
+
+// EvictIntentsNotSeenSince removes intents last seen before cutoff, returning the number of intents removed.
+func (h *GCPIntentsHolder) EvictIntentsNotSeenSince(cutoff time.Time) int {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return intentsstore.DropStale(h.accumulatingIntents, cutoff, func(intent TimestampedGCPIntent) time.Time { return intent.Timestamp })
+}
+
+func (h *GCPIntentsHolder) Reset() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.accumulatingIntents = make(map[GCPIntentKey]TimestampedGCPIntent)
+}
@@ -0,0 +1,71 @@
+package gcpintentsholder
+
+import (
+	"encoding/json"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"time"
+)
+
+type gcpSnapshot struct {
+	Accumulating []TimestampedGCPIntent
+	SinceLastGet []TimestampedGCPIntent
+}
+
+func snapshotEntries(intents map[GCPIntentKey]TimestampedGCPIntent, cutoff time.Time) []TimestampedGCPIntent {
+	entries := make([]TimestampedGCPIntent, 0, len(intents))
+	for _, intent := range intents {
+		if intent.Timestamp.Before(cutoff) {
+			continue
+		}
+		entries = append(entries, intent)
+	}
+	return entries
+}
+
+func restoreEntries(intents map[GCPIntentKey]TimestampedGCPIntent, entries []TimestampedGCPIntent, cutoff time.Time) {
+	for _, intent := range entries {
+		if intent.Timestamp.Before(cutoff) {
+			continue
+		}
+		key := GCPIntentKey{
+			ClientName:      intent.Client.Name,
+			ClientNamespace: intent.Client.Namespace,
+			Resource:        intent.Resource,
+		}
+		if _, found := intents[key]; found {
+			continue
+		}
+		intents[key] = intent
+	}
+}
+
+// Snapshot returns the intents last seen since cutoff, serialized. Older intents are left for the TTL eviction.
+func (h *GCPIntentsHolder) Snapshot(cutoff time.Time) ([]byte, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	data, err := json.Marshal(gcpSnapshot{
+		Accumulating: snapshotEntries(h.accumulatingIntents, cutoff),
+		SinceLastGet: snapshotEntries(h.intents, cutoff),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	return data, nil
+}
+
+// Restore adds intents from a snapshot created by Snapshot to the holder, skipping those last seen before cutoff.
+// Intents already in the holder take precedence over restored ones.
+func (h *GCPIntentsHolder) Restore(data []byte, cutoff time.Time) error {
+	var snapshot gcpSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return errors.Wrap(err)
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	restoreEntries(h.accumulatingIntents, snapshot.Accumulating, cutoff)
+	restoreEntries(h.intents, snapshot.SinceLastGet, cutoff)
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -41,12 +42,33 @@ type Config struct {
 type ResolverRoot interface {
 	Mutation() MutationResolver
 	Query() QueryResolver
+	Subscription() SubscriptionResolver
 }
 
 type DirectiveRoot struct {
 }
 
 type ComplexityRoot struct {
+	ClientIntentsDiff struct {
+		Client   func(childComplexity int) int
+		Matching func(childComplexity int) int
+		Missing  func(childComplexity int) int
+		Unused   func(childComplexity int) int
+	}
+
+	DiscoveredIntent struct {
+		DiscoveredAt          func(childComplexity int) int
+		ExternalTrafficIntent func(childComplexity int) int
+		IncomingTrafficIntent func(childComplexity int) int
+		Intent                func(childComplexity int) int
+	}
+
+	ExternalTrafficIntent struct {
+		Client  func(childComplexity int) int
+		DNSName func(childComplexity int) int
+		Ips     func(childComplexity int) int
+	}
+
 	GroupVersionKind struct {
 		Group   func(childComplexity int) int
 		Kind    func(childComplexity int) int
@@ -71,32 +93,42 @@ type ComplexityRoot struct {
 		Uptime                func(childComplexity int) int
 	}
 
+	IncomingTrafficIntent struct {
+		IP     func(childComplexity int) int
+		Server func(childComplexity int) int
+	}
+
 	Intent struct {
 		AwsActions     func(childComplexity int) int
 		Client         func(childComplexity int) int
 		HTTPResources  func(childComplexity int) int
 		KafkaTopics    func(childComplexity int) int
+		Protocol       func(childComplexity int) int
 		ResolutionData func(childComplexity int) int
 		Server         func(childComplexity int) int
 		Type           func(childComplexity int) int
 	}
 
 	KafkaConfig struct {
-		Name       func(childComplexity int) int
-		Operations func(childComplexity int) int
+		Name         func(childComplexity int) int
+		Operations   func(childComplexity int) int
+		ResourceType func(childComplexity int) int
 	}
 
 	Mutation struct {
-		ReportAWSOperation           func(childComplexity int, operation []model.AWSOperation) int
-		ReportAzureOperation         func(childComplexity int, operation []model.AzureOperation) int
-		ReportCaptureResults         func(childComplexity int, results model.CaptureResults) int
-		ReportGCPOperation           func(childComplexity int, operation []model.GCPOperation) int
-		ReportIstioConnectionResults func(childComplexity int, results model.IstioConnectionResults) int
-		ReportKafkaMapperResults     func(childComplexity int, results model.KafkaMapperResults) int
-		ReportSocketScanResults      func(childComplexity int, results model.SocketScanResults) int
-		ReportTCPCaptureResults      func(childComplexity int, results model.CaptureTCPResults) int
-		ReportTrafficLevelResults    func(childComplexity int, results model.TrafficLevelResults) int
-		ResetCapture                 func(childComplexity int) int
+		ReportAWSOperation             func(childComplexity int, operation []model.AWSOperation) int
+		ReportAzureOperation           func(childComplexity int, operation []model.AzureOperation) int
+		ReportCaptureResults           func(childComplexity int, results model.CaptureResults) int
+		ReportGCPOperation             func(childComplexity int, operation []model.GCPOperation) int
+		ReportHTTPRequestResults       func(childComplexity int, results model.HTTPRequestResults) int
+		ReportIstioConnectionResults   func(childComplexity int, results model.IstioConnectionResults) int
+		ReportKafkaMapperResults       func(childComplexity int, results model.KafkaMapperResults) int
+		ReportLinkerdConnectionResults func(childComplexity int, results model.LinkerdConnectionResults) int
+		ReportSocketScanResults        func(childComplexity int, results model.SocketScanResults) int
+		ReportTCPCaptureResults        func(childComplexity int, results model.CaptureTCPResults) int
+		ReportTLSCaptureResults        func(childComplexity int, results model.CaptureTLSResults) int
+		ReportTrafficLevelResults      func(childComplexity int, results model.TrafficLevelResults) int
+		ResetCapture                   func(childComplexity int) int
 	}
 
 	OtterizeServiceIdentity struct {
@@ -115,9 +147,11 @@ type ComplexityRoot struct {
 	}
 
 	Query struct {
-		Health         func(childComplexity int) int
-		Intents        func(childComplexity int, namespaces []string, includeLabels []string, excludeServiceWithLabels []string, includeAllLabels *bool, server *model.ServerFilter) int
-		ServiceIntents func(childComplexity int, namespaces []string, includeLabels []string, includeAllLabels *bool) int
+		ClientIntentsYaml func(childComplexity int, namespaces []string) int
+		Health            func(childComplexity int) int
+		Intents           func(childComplexity int, namespaces []string, includeLabels []string, excludeServiceWithLabels []string, includeAllLabels *bool, server *model.ServerFilter, since *time.Time, until *time.Time) int
+		IntentsDiff       func(childComplexity int, namespaces []string) int
+		ServiceIntents    func(childComplexity int, namespaces []string, includeLabels []string, includeAllLabels *bool, since *time.Time, until *time.Time) int
 	}
 
 	ServiceIntents struct {
@@ -125,6 +159,10 @@ type ComplexityRoot struct {
 		Intents func(childComplexity int) int
 	}
 
+	Subscription struct {
+		IntentDiscovered func(childComplexity int, namespaces []string) int
+	}
+
 	TCPDestResolveBugfixData struct {
 		IsSrcControlPlane func(childComplexity int) int
 		ResolvedUsingIP   func(childComplexity int) int
@@ -135,19 +173,27 @@ type MutationResolver interface {
 	ResetCapture(ctx context.Context) (bool, error)
 	ReportCaptureResults(ctx context.Context, results model.CaptureResults) (bool, error)
 	ReportTCPCaptureResults(ctx context.Context, results model.CaptureTCPResults) (bool, error)
+	ReportTLSCaptureResults(ctx context.Context, results model.CaptureTLSResults) (bool, error)
 	ReportSocketScanResults(ctx context.Context, results model.SocketScanResults) (bool, error)
 	ReportKafkaMapperResults(ctx context.Context, results model.KafkaMapperResults) (bool, error)
 	ReportIstioConnectionResults(ctx context.Context, results model.IstioConnectionResults) (bool, error)
+	ReportLinkerdConnectionResults(ctx context.Context, results model.LinkerdConnectionResults) (bool, error)
+	ReportHTTPRequestResults(ctx context.Context, results model.HTTPRequestResults) (bool, error)
 	ReportAWSOperation(ctx context.Context, operation []model.AWSOperation) (bool, error)
 	ReportAzureOperation(ctx context.Context, operation []model.AzureOperation) (bool, error)
 	ReportGCPOperation(ctx context.Context, operation []model.GCPOperation) (bool, error)
 	ReportTrafficLevelResults(ctx context.Context, results model.TrafficLevelResults) (bool, error)
 }
 type QueryResolver interface {
-	ServiceIntents(ctx context.Context, namespaces []string, includeLabels []string, includeAllLabels *bool) ([]model.ServiceIntents, error)
-	Intents(ctx context.Context, namespaces []string, includeLabels []string, excludeServiceWithLabels []string, includeAllLabels *bool, server *model.ServerFilter) ([]model.Intent, error)
+	ServiceIntents(ctx context.Context, namespaces []string, includeLabels []string, includeAllLabels *bool, since *time.Time, until *time.Time) ([]model.ServiceIntents, error)
+	Intents(ctx context.Context, namespaces []string, includeLabels []string, excludeServiceWithLabels []string, includeAllLabels *bool, server *model.ServerFilter, since *time.Time, until *time.Time) ([]model.Intent, error)
+	ClientIntentsYaml(ctx context.Context, namespaces []string) (string, error)
+	IntentsDiff(ctx context.Context, namespaces []string) ([]model.ClientIntentsDiff, error)
 	Health(ctx context.Context) (bool, error)
 }
+type SubscriptionResolver interface {
+	IntentDiscovered(ctx context.Context, namespaces []string) (<-chan *model.DiscoveredIntent, error)
+}
 
 type executableSchema struct {
 	schema     *ast.Schema
@@ -168,6 +214,83 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 	_ = ec
 	switch typeName + "." + field {
 
+	case "ClientIntentsDiff.client":
+		if e.complexity.ClientIntentsDiff.Client == nil {
+			break
+		}
+
+		return e.complexity.ClientIntentsDiff.Client(childComplexity), true
+
+	case "ClientIntentsDiff.matching":
+		if e.complexity.ClientIntentsDiff.Matching == nil {
+			break
+		}
+
+		return e.complexity.ClientIntentsDiff.Matching(childComplexity), true
+
+	case "ClientIntentsDiff.missing":
+		if e.complexity.ClientIntentsDiff.Missing == nil {
+			break
+		}
+
+		return e.complexity.ClientIntentsDiff.Missing(childComplexity), true
+
+	case "ClientIntentsDiff.unused":
+		if e.complexity.ClientIntentsDiff.Unused == nil {
+			break
+		}
+
+		return e.complexity.ClientIntentsDiff.Unused(childComplexity), true
+
+	case "DiscoveredIntent.discoveredAt":
+		if e.complexity.DiscoveredIntent.DiscoveredAt == nil {
+			break
+		}
+
+		return e.complexity.DiscoveredIntent.DiscoveredAt(childComplexity), true
+
+	case "DiscoveredIntent.externalTrafficIntent":
+		if e.complexity.DiscoveredIntent.ExternalTrafficIntent == nil {
+			break
+		}
+
+		return e.complexity.DiscoveredIntent.ExternalTrafficIntent(childComplexity), true
+
+	case "DiscoveredIntent.incomingTrafficIntent":
+		if e.complexity.DiscoveredIntent.IncomingTrafficIntent == nil {
+			break
+		}
+
+		return e.complexity.DiscoveredIntent.IncomingTrafficIntent(childComplexity), true
+
+	case "DiscoveredIntent.intent":
+		if e.complexity.DiscoveredIntent.Intent == nil {
+			break
+		}
+
+		return e.complexity.DiscoveredIntent.Intent(childComplexity), true
+
+	case "ExternalTrafficIntent.client":
+		if e.complexity.ExternalTrafficIntent.Client == nil {
+			break
+		}
+
+		return e.complexity.ExternalTrafficIntent.Client(childComplexity), true
+
+	case "ExternalTrafficIntent.dnsName":
+		if e.complexity.ExternalTrafficIntent.DNSName == nil {
+			break
+		}
+
+		return e.complexity.ExternalTrafficIntent.DNSName(childComplexity), true
+
+	case "ExternalTrafficIntent.ips":
+		if e.complexity.ExternalTrafficIntent.Ips == nil {
+			break
+		}
+
+		return e.complexity.ExternalTrafficIntent.Ips(childComplexity), true
+
 	case "GroupVersionKind.group":
 		if e.complexity.GroupVersionKind.Group == nil {
 			break
@@ -273,6 +396,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.IdentityResolutionData.Uptime(childComplexity), true
 
+	case "IncomingTrafficIntent.ip":
+		if e.complexity.IncomingTrafficIntent.IP == nil {
+			break
+		}
+
+		return e.complexity.IncomingTrafficIntent.IP(childComplexity), true
+
+	case "IncomingTrafficIntent.server":
+		if e.complexity.IncomingTrafficIntent.Server == nil {
+			break
+		}
+
+		return e.complexity.IncomingTrafficIntent.Server(childComplexity), true
+
 	case "Intent.awsActions":
 		if e.complexity.Intent.AwsActions == nil {
 			break
@@ -301,6 +438,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Intent.KafkaTopics(childComplexity), true
 
+	case "Intent.protocol":
+		if e.complexity.Intent.Protocol == nil {
+			break
+		}
+
+		return e.complexity.Intent.Protocol(childComplexity), true
+
 	case "Intent.resolutionData":
 		if e.complexity.Intent.ResolutionData == nil {
 			break
@@ -336,6 +480,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.KafkaConfig.Operations(childComplexity), true
 
+	case "KafkaConfig.resourceType":
+		if e.complexity.KafkaConfig.ResourceType == nil {
+			break
+		}
+
+		return e.complexity.KafkaConfig.ResourceType(childComplexity), true
+
 	case "Mutation.reportAWSOperation":
 		if e.complexity.Mutation.ReportAWSOperation == nil {
 			break
@@ -384,6 +535,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Mutation.ReportGCPOperation(childComplexity, args["operation"].([]model.GCPOperation)), true
 
+	case "Mutation.reportHTTPRequestResults":
+		if e.complexity.Mutation.ReportHTTPRequestResults == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_reportHTTPRequestResults_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.ReportHTTPRequestResults(childComplexity, args["results"].(model.HTTPRequestResults)), true
+
 	case "Mutation.reportIstioConnectionResults":
 		if e.complexity.Mutation.ReportIstioConnectionResults == nil {
 			break
@@ -408,6 +571,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Mutation.ReportKafkaMapperResults(childComplexity, args["results"].(model.KafkaMapperResults)), true
 
+	case "Mutation.reportLinkerdConnectionResults":
+		if e.complexity.Mutation.ReportLinkerdConnectionResults == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_reportLinkerdConnectionResults_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.ReportLinkerdConnectionResults(childComplexity, args["results"].(model.LinkerdConnectionResults)), true
+
 	case "Mutation.reportSocketScanResults":
 		if e.complexity.Mutation.ReportSocketScanResults == nil {
 			break
@@ -432,6 +607,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Mutation.ReportTCPCaptureResults(childComplexity, args["results"].(model.CaptureTCPResults)), true
 
+	case "Mutation.reportTLSCaptureResults":
+		if e.complexity.Mutation.ReportTLSCaptureResults == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_reportTLSCaptureResults_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.ReportTLSCaptureResults(childComplexity, args["results"].(model.CaptureTLSResults)), true
+
 	case "Mutation.reportTrafficLevelResults":
 		if e.complexity.Mutation.ReportTrafficLevelResults == nil {
 			break
@@ -514,6 +701,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.PodLabel.Value(childComplexity), true
 
+	case "Query.clientIntentsYAML":
+		if e.complexity.Query.ClientIntentsYaml == nil {
+			break
+		}
+
+		args, err := ec.field_Query_clientIntentsYAML_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.ClientIntentsYaml(childComplexity, args["namespaces"].([]string)), true
+
 	case "Query.health":
 		if e.complexity.Query.Health == nil {
 			break
@@ -531,7 +730,19 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			return 0, false
 		}
 
-		return e.complexity.Query.Intents(childComplexity, args["namespaces"].([]string), args["includeLabels"].([]string), args["excludeServiceWithLabels"].([]string), args["includeAllLabels"].(*bool), args["server"].(*model.ServerFilter)), true
+		return e.complexity.Query.Intents(childComplexity, args["namespaces"].([]string), args["includeLabels"].([]string), args["excludeServiceWithLabels"].([]string), args["includeAllLabels"].(*bool), args["server"].(*model.ServerFilter), args["since"].(*time.Time), args["until"].(*time.Time)), true
+
+	case "Query.intentsDiff":
+		if e.complexity.Query.IntentsDiff == nil {
+			break
+		}
+
+		args, err := ec.field_Query_intentsDiff_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.IntentsDiff(childComplexity, args["namespaces"].([]string)), true
 
 	case "Query.serviceIntents":
 		if e.complexity.Query.ServiceIntents == nil {
@@ -543,7 +754,7 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			return 0, false
 		}
 
-		return e.complexity.Query.ServiceIntents(childComplexity, args["namespaces"].([]string), args["includeLabels"].([]string), args["includeAllLabels"].(*bool)), true
+		return e.complexity.Query.ServiceIntents(childComplexity, args["namespaces"].([]string), args["includeLabels"].([]string), args["includeAllLabels"].(*bool), args["since"].(*time.Time), args["until"].(*time.Time)), true
 
 	case "ServiceIntents.client":
 		if e.complexity.ServiceIntents.Client == nil {
@@ -559,6 +770,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ServiceIntents.Intents(childComplexity), true
 
+	case "Subscription.intentDiscovered":
+		if e.complexity.Subscription.IntentDiscovered == nil {
+			break
+		}
+
+		args, err := ec.field_Subscription_intentDiscovered_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Subscription.IntentDiscovered(childComplexity, args["namespaces"].([]string)), true
+
 	case "TCPDestResolveBugfixData.isSrcControlPlane":
 		if e.complexity.TCPDestResolveBugfixData.IsSrcControlPlane == nil {
 			break
@@ -585,12 +808,17 @@ func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
 		ec.unmarshalInputAzureOperation,
 		ec.unmarshalInputCaptureResults,
 		ec.unmarshalInputCaptureTCPResults,
+		ec.unmarshalInputCaptureTLSResults,
 		ec.unmarshalInputDestination,
 		ec.unmarshalInputGCPOperation,
+		ec.unmarshalInputHTTPRequestResult,
+		ec.unmarshalInputHTTPRequestResults,
 		ec.unmarshalInputIstioConnection,
 		ec.unmarshalInputIstioConnectionResults,
 		ec.unmarshalInputKafkaMapperResult,
 		ec.unmarshalInputKafkaMapperResults,
+		ec.unmarshalInputLinkerdConnection,
+		ec.unmarshalInputLinkerdConnectionResults,
 		ec.unmarshalInputNamespacedName,
 		ec.unmarshalInputRecordedDestinationsForSrc,
 		ec.unmarshalInputServerFilter,
@@ -642,6 +870,23 @@ func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
 			var buf bytes.Buffer
 			data.MarshalGQL(&buf)
 
+			return &graphql.Response{
+				Data: buf.Bytes(),
+			}
+		}
+	case ast.Subscription:
+		next := ec._Subscription(ctx, rc.Operation.SelectionSet)
+
+		var buf bytes.Buffer
+		return func(ctx context.Context) *graphql.Response {
+			buf.Reset()
+			data := next(ctx)
+
+			if data == nil {
+				return nil
+			}
+			data.MarshalGQL(&buf)
+
 			return &graphql.Response{
 				Data: buf.Bytes(),
 			}
@@ -696,6 +941,11 @@ func (ec *executionContext) introspectType(name string) (*introspection.Type, er
 var sources = []*ast.Source{
 	{Name: "../../../../mappergraphql/schema.graphql", Input: `scalar Time # Equivalent of Go's time.Time provided by gqlgen
 
+enum TransportProtocol {
+    TCP
+    UDP
+}
+
 input Destination {
     # Could be either IP addr or hostname
     destination: String!
@@ -708,11 +958,18 @@ input Destination {
     # only for counting unique connections to to the same destination. By putting it here, we reduce the amount of traffic
     # we pass from the sniffer to the mapper (this way we can send the src&dest ip only once).
     srcPorts: [Int!]
+    # The transport protocol of connections to the destination, which is TCP when not set.
+    protocol: TransportProtocol
 }
 
 input RecordedDestinationsForSrc {
     srcIp: String!
     srcHostname: String!
+    """
+    The ID of the container the requests were made from, when traced at the socket. It identifies the source pod even
+    if srcIp was since reused by another pod.
+    """
+    srcContainerId: String
     destinations: [Destination!]!
 }
 
@@ -724,6 +981,12 @@ input CaptureTCPResults {
     results: [RecordedDestinationsForSrc!]!
 }
 
+# TLS connections captured by the server name (SNI) clients sent in the TLS ClientHello. The destination is the server
+# name, and destinationIP is the IP the connection was made to.
+input CaptureTLSResults {
+    results: [RecordedDestinationsForSrc!]!
+}
+
 input SocketScanResults {
     results: [RecordedDestinationsForSrc!]!
 }
@@ -795,9 +1058,28 @@ enum KafkaOperation {
     IDEMPOTENT_WRITE
 }
 
+enum KafkaResourceType {
+    TOPIC
+    GROUP
+    TRANSACTIONAL_ID
+    CLUSTER
+}
+
+enum KafkaPatternType {
+    LITERAL
+    PREFIXED
+}
+
 type KafkaConfig {
+    """
+    The name of the resource. Prefixed resource patterns are named after their prefix, followed by '*'.
+    """
     name: String!
     operations: [KafkaOperation!]
+    """
+    The type of the resource, which is a topic when not set.
+    """
+    resourceType: KafkaResourceType
 }
 
 type HttpResource {
@@ -825,6 +1107,7 @@ type Intent {
     kafkaTopics: [KafkaConfig!]
     httpResources: [HttpResource!]
     awsActions: [String!]
+    protocol: TransportProtocol
 }
 
 type ServiceIntents {
@@ -832,13 +1115,69 @@ type ServiceIntents {
     intents: [OtterizeServiceIdentity!]!
 }
 
+type ExternalTrafficIntent {
+    client: OtterizeServiceIdentity!
+    dnsName: String!
+    ips: [String!]!
+}
+
+type IncomingTrafficIntent {
+    server: OtterizeServiceIdentity!
+    ip: String!
+}
+
+"""
+A newly discovered intent. Exactly one of intent, externalTrafficIntent and incomingTrafficIntent is set.
+"""
+type DiscoveredIntent {
+    discoveredAt: Time!
+    intent: Intent
+    externalTrafficIntent: ExternalTrafficIntent
+    incomingTrafficIntent: IncomingTrafficIntent
+}
+
+type ClientIntentsDiff {
+    client: OtterizeServiceIdentity!
+    """
+    Servers the client was seen calling, with no matching target in its applied ClientIntents.
+    """
+    missing: [OtterizeServiceIdentity!]!
+    """
+    Targets in the client's applied ClientIntents that the client was not seen calling.
+    """
+    unused: [OtterizeServiceIdentity!]!
+    """
+    Servers the client was seen calling, that have a matching target in its applied ClientIntents.
+    """
+    matching: [OtterizeServiceIdentity!]!
+}
+
 input KafkaMapperResult {
     srcIp: String!
-    serverPodName: String!
-    serverNamespace: String!
+    """
+    The server pod. When not set, the server is resolved from serverIp.
+    """
+    serverPodName: String
+    serverNamespace: String
+    """
+    The IP of the server, for results that were not read from the server itself, such as results sniffed from the
+    Kafka protocol.
+    """
+    serverIp: String
+    """
+    The name of the resource, which is a topic unless resourceType is set.
+    """
     topic: String!
     operation: String!
     lastSeen: Time!
+    principal: String
+    resourceType: KafkaResourceType
+    patternType: KafkaPatternType
+    """
+    The ServiceAccount the principal was mapped to. When its pods belong to a single workload, that workload is used as
+    the client instead of resolving srcIp.
+    """
+    srcServiceAccount: NamespacedName
 }
 
 input KafkaMapperResults {
@@ -860,6 +1199,47 @@ input IstioConnectionResults {
     results: [IstioConnection!]!
 }
 
+"""
+HTTP traffic from a pod meshed by Linkerd, read from the metrics of the pod's linkerd-proxy.
+"""
+input LinkerdConnection {
+    srcPodName: String!
+    srcNamespace: String!
+    """
+    The authority the client addressed, such as server.namespace.svc.cluster.local:8080.
+    """
+    authority: String!
+    """
+    The path of the ServiceProfile route the requests matched, if the route is named after its method and path.
+    """
+    path: String
+    methods: [HttpMethod!]!
+    lastSeen: Time!
+}
+
+input LinkerdConnectionResults {
+    results: [LinkerdConnection!]!
+}
+
+"""
+A plaintext HTTP request, sniffed from the traffic between a client and a server.
+"""
+input HTTPRequestResult {
+    srcIp: String!
+    dstIp: String!
+    dstPort: Int!
+    method: HttpMethod!
+    """
+    The path of the request, without its query string.
+    """
+    path: String!
+    lastSeen: Time!
+}
+
+input HTTPRequestResults {
+    results: [HTTPRequestResult!]!
+}
+
 input NamespacedName {
     name: String!
     namespace: String!
@@ -912,8 +1292,10 @@ type Query {
     namespaces: Namespaces filter.
     includeLabels: Labels to include in the response. Ignored if includeAllLabels is specified.
     includeAllLabels: Return all labels for the pod in the response.
+    since: Only include intents last seen at or after this time.
+    until: Only include intents last seen at or before this time.
     """
-    serviceIntents(namespaces: [String!], includeLabels: [String!], includeAllLabels: Boolean): [ServiceIntents!]!
+    serviceIntents(namespaces: [String!], includeLabels: [String!], includeAllLabels: Boolean, since: Time, until: Time): [ServiceIntents!]!
 
     """
     Query intents list.
@@ -921,6 +1303,8 @@ type Query {
     includeLabels: Labels to include in the response. Ignored if includeAllLabels is specified.
     excludeLabels: Labels to exclude from the response. Ignored if includeAllLabels is specified.
     includeAllLabels: Return all labels for the pod in the response.
+    since: Only include intents last seen at or after this time.
+    until: Only include intents last seen at or before this time.
     """
     intents(
         namespaces: [String!],
@@ -928,8 +1312,23 @@ type Query {
         excludeServiceWithLabels: [String!],
         includeAllLabels: Boolean,
         server: ServerFilter,
+        since: Time,
+        until: Time,
     ): [Intent!]!
 
+    """
+    Render the discovered intents as ready-to-apply ClientIntents YAML documents, one per client.
+    Includes in-cluster, Kafka, internet, AWS, GCP and Azure targets.
+    namespaces: Client namespaces filter.
+    """
+    clientIntentsYAML(namespaces: [String!]): String!
+
+    """
+    Compare the discovered in-cluster intents against the ClientIntents applied in the cluster.
+    namespaces: Client namespaces filter.
+    """
+    intentsDiff(namespaces: [String!]): [ClientIntentsDiff!]!
+
     health: Boolean!
 }
 
@@ -937,14 +1336,26 @@ type Mutation {
     resetCapture: Boolean!
     reportCaptureResults(results: CaptureResults!): Boolean!
     reportTCPCaptureResults(results: CaptureTCPResults!): Boolean!
+    reportTLSCaptureResults(results: CaptureTLSResults!): Boolean!
     reportSocketScanResults(results: SocketScanResults!): Boolean!
     reportKafkaMapperResults(results: KafkaMapperResults!): Boolean!
     reportIstioConnectionResults(results: IstioConnectionResults!): Boolean!
+    reportLinkerdConnectionResults(results: LinkerdConnectionResults!): Boolean!
+    reportHTTPRequestResults(results: HTTPRequestResults!): Boolean!
     reportAWSOperation(operation: [AWSOperation!]!): Boolean!
     reportAzureOperation(operation: [AzureOperation!]!): Boolean!
     reportGCPOperation(operation: [GCPOperation!]!): Boolean!
     reportTrafficLevelResults(results: TrafficLevelResults!): Boolean!
 }
+
+type Subscription {
+    """
+    Stream intents as they are discovered, including external and incoming traffic intents.
+    namespaces: Namespaces filter, matched against the client of intents and external traffic intents, and against
+    the server of incoming traffic intents.
+    """
+    intentDiscovered(namespaces: [String!]): DiscoveredIntent!
+}
 `, BuiltIn: false},
 }
 var parsedSchema = gqlparser.MustLoadSchema(sources...)
@@ -1013,6 +1424,21 @@ func (ec *executionContext) field_Mutation_reportGCPOperation_args(ctx context.C
 	return args, nil
 }
 
+func (ec *executionContext) field_Mutation_reportHTTPRequestResults_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 model.HTTPRequestResults
+	if tmp, ok := rawArgs["results"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("results"))
+		arg0, err = ec.unmarshalNHTTPRequestResults2githubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐHTTPRequestResults(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["results"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Mutation_reportIstioConnectionResults_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -1043,6 +1469,21 @@ func (ec *executionContext) field_Mutation_reportKafkaMapperResults_args(ctx con
 	return args, nil
 }
 
+func (ec *executionContext) field_Mutation_reportLinkerdConnectionResults_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 model.LinkerdConnectionResults
+	if tmp, ok := rawArgs["results"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("results"))
+		arg0, err = ec.unmarshalNLinkerdConnectionResults2githubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐLinkerdConnectionResults(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["results"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Mutation_reportSocketScanResults_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -1073,6 +1514,21 @@ func (ec *executionContext) field_Mutation_reportTCPCaptureResults_args(ctx cont
 	return args, nil
 }
 
+func (ec *executionContext) field_Mutation_reportTLSCaptureResults_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 model.CaptureTLSResults
+	if tmp, ok := rawArgs["results"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("results"))
+		arg0, err = ec.unmarshalNCaptureTLSResults2githubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐCaptureTLSResults(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["results"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Mutation_reportTrafficLevelResults_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -1103,7 +1559,7 @@ func (ec *executionContext) field_Query___type_args(ctx context.Context, rawArgs
 	return args, nil
 }
 
-func (ec *executionContext) field_Query_intents_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Query_clientIntentsYAML_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
 	var arg0 []string
@@ -1115,20 +1571,50 @@ func (ec *executionContext) field_Query_intents_args(ctx context.Context, rawArg
 		}
 	}
 	args["namespaces"] = arg0
-	var arg1 []string
-	if tmp, ok := rawArgs["includeLabels"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("includeLabels"))
-		arg1, err = ec.unmarshalOString2ᚕstringᚄ(ctx, tmp)
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_intentsDiff_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 []string
+	if tmp, ok := rawArgs["namespaces"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("namespaces"))
+		arg0, err = ec.unmarshalOString2ᚕstringᚄ(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["includeLabels"] = arg1
-	var arg2 []string
-	if tmp, ok := rawArgs["excludeServiceWithLabels"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("excludeServiceWithLabels"))
-		arg2, err = ec.unmarshalOString2ᚕstringᚄ(ctx, tmp)
-		if err != nil {
+	args["namespaces"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_intents_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 []string
+	if tmp, ok := rawArgs["namespaces"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("namespaces"))
+		arg0, err = ec.unmarshalOString2ᚕstringᚄ(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["namespaces"] = arg0
+	var arg1 []string
+	if tmp, ok := rawArgs["includeLabels"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("includeLabels"))
+		arg1, err = ec.unmarshalOString2ᚕstringᚄ(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["includeLabels"] = arg1
+	var arg2 []string
+	if tmp, ok := rawArgs["excludeServiceWithLabels"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("excludeServiceWithLabels"))
+		arg2, err = ec.unmarshalOString2ᚕstringᚄ(ctx, tmp)
+		if err != nil {
 			return nil, err
 		}
 	}
@@ -1141,89 +1627,727 @@ func (ec *executionContext) field_Query_intents_args(ctx context.Context, rawArg
 			return nil, err
 		}
 	}
-	args["includeAllLabels"] = arg3
-	var arg4 *model.ServerFilter
-	if tmp, ok := rawArgs["server"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("server"))
-		arg4, err = ec.unmarshalOServerFilter2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐServerFilter(ctx, tmp)
-		if err != nil {
-			return nil, err
+	args["includeAllLabels"] = arg3
+	var arg4 *model.ServerFilter
+	if tmp, ok := rawArgs["server"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("server"))
+		arg4, err = ec.unmarshalOServerFilter2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐServerFilter(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["server"] = arg4
+	var arg5 *time.Time
+	if tmp, ok := rawArgs["since"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("since"))
+		arg5, err = ec.unmarshalOTime2ᚖtimeᚐTime(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["since"] = arg5
+	var arg6 *time.Time
+	if tmp, ok := rawArgs["until"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("until"))
+		arg6, err = ec.unmarshalOTime2ᚖtimeᚐTime(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["until"] = arg6
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_serviceIntents_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 []string
+	if tmp, ok := rawArgs["namespaces"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("namespaces"))
+		arg0, err = ec.unmarshalOString2ᚕstringᚄ(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["namespaces"] = arg0
+	var arg1 []string
+	if tmp, ok := rawArgs["includeLabels"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("includeLabels"))
+		arg1, err = ec.unmarshalOString2ᚕstringᚄ(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["includeLabels"] = arg1
+	var arg2 *bool
+	if tmp, ok := rawArgs["includeAllLabels"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("includeAllLabels"))
+		arg2, err = ec.unmarshalOBoolean2ᚖbool(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["includeAllLabels"] = arg2
+	var arg3 *time.Time
+	if tmp, ok := rawArgs["since"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("since"))
+		arg3, err = ec.unmarshalOTime2ᚖtimeᚐTime(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["since"] = arg3
+	var arg4 *time.Time
+	if tmp, ok := rawArgs["until"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("until"))
+		arg4, err = ec.unmarshalOTime2ᚖtimeᚐTime(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["until"] = arg4
+	return args, nil
+}
+
+func (ec *executionContext) field_Subscription_intentDiscovered_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 []string
+	if tmp, ok := rawArgs["namespaces"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("namespaces"))
+		arg0, err = ec.unmarshalOString2ᚕstringᚄ(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["namespaces"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field___Type_enumValues_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 bool
+	if tmp, ok := rawArgs["includeDeprecated"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("includeDeprecated"))
+		arg0, err = ec.unmarshalOBoolean2bool(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["includeDeprecated"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field___Type_fields_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 bool
+	if tmp, ok := rawArgs["includeDeprecated"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("includeDeprecated"))
+		arg0, err = ec.unmarshalOBoolean2bool(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["includeDeprecated"] = arg0
+	return args, nil
+}
+
+// endregion ***************************** args.gotpl *****************************
+
+// region    ************************** directives.gotpl **************************
+
+// endregion ************************** directives.gotpl **************************
+
+// region    **************************** field.gotpl *****************************
+
+func (ec *executionContext) _ClientIntentsDiff_client(ctx context.Context, field graphql.CollectedField, obj *model.ClientIntentsDiff) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ClientIntentsDiff_client(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Client, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.OtterizeServiceIdentity)
+	fc.Result = res
+	return ec.marshalNOtterizeServiceIdentity2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐOtterizeServiceIdentity(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_ClientIntentsDiff_client(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ClientIntentsDiff",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "name":
+				return ec.fieldContext_OtterizeServiceIdentity_name(ctx, field)
+			case "namespace":
+				return ec.fieldContext_OtterizeServiceIdentity_namespace(ctx, field)
+			case "labels":
+				return ec.fieldContext_OtterizeServiceIdentity_labels(ctx, field)
+			case "nameResolvedUsingAnnotation":
+				return ec.fieldContext_OtterizeServiceIdentity_nameResolvedUsingAnnotation(ctx, field)
+			case "resolutionData":
+				return ec.fieldContext_OtterizeServiceIdentity_resolutionData(ctx, field)
+			case "podOwnerKind":
+				return ec.fieldContext_OtterizeServiceIdentity_podOwnerKind(ctx, field)
+			case "kubernetesService":
+				return ec.fieldContext_OtterizeServiceIdentity_kubernetesService(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type OtterizeServiceIdentity", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ClientIntentsDiff_missing(ctx context.Context, field graphql.CollectedField, obj *model.ClientIntentsDiff) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ClientIntentsDiff_missing(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Missing, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]model.OtterizeServiceIdentity)
+	fc.Result = res
+	return ec.marshalNOtterizeServiceIdentity2ᚕgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐOtterizeServiceIdentityᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_ClientIntentsDiff_missing(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ClientIntentsDiff",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "name":
+				return ec.fieldContext_OtterizeServiceIdentity_name(ctx, field)
+			case "namespace":
+				return ec.fieldContext_OtterizeServiceIdentity_namespace(ctx, field)
+			case "labels":
+				return ec.fieldContext_OtterizeServiceIdentity_labels(ctx, field)
+			case "nameResolvedUsingAnnotation":
+				return ec.fieldContext_OtterizeServiceIdentity_nameResolvedUsingAnnotation(ctx, field)
+			case "resolutionData":
+				return ec.fieldContext_OtterizeServiceIdentity_resolutionData(ctx, field)
+			case "podOwnerKind":
+				return ec.fieldContext_OtterizeServiceIdentity_podOwnerKind(ctx, field)
+			case "kubernetesService":
+				return ec.fieldContext_OtterizeServiceIdentity_kubernetesService(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type OtterizeServiceIdentity", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ClientIntentsDiff_unused(ctx context.Context, field graphql.CollectedField, obj *model.ClientIntentsDiff) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ClientIntentsDiff_unused(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Unused, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]model.OtterizeServiceIdentity)
+	fc.Result = res
+	return ec.marshalNOtterizeServiceIdentity2ᚕgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐOtterizeServiceIdentityᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_ClientIntentsDiff_unused(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ClientIntentsDiff",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "name":
+				return ec.fieldContext_OtterizeServiceIdentity_name(ctx, field)
+			case "namespace":
+				return ec.fieldContext_OtterizeServiceIdentity_namespace(ctx, field)
+			case "labels":
+				return ec.fieldContext_OtterizeServiceIdentity_labels(ctx, field)
+			case "nameResolvedUsingAnnotation":
+				return ec.fieldContext_OtterizeServiceIdentity_nameResolvedUsingAnnotation(ctx, field)
+			case "resolutionData":
+				return ec.fieldContext_OtterizeServiceIdentity_resolutionData(ctx, field)
+			case "podOwnerKind":
+				return ec.fieldContext_OtterizeServiceIdentity_podOwnerKind(ctx, field)
+			case "kubernetesService":
+				return ec.fieldContext_OtterizeServiceIdentity_kubernetesService(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type OtterizeServiceIdentity", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ClientIntentsDiff_matching(ctx context.Context, field graphql.CollectedField, obj *model.ClientIntentsDiff) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ClientIntentsDiff_matching(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Matching, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]model.OtterizeServiceIdentity)
+	fc.Result = res
+	return ec.marshalNOtterizeServiceIdentity2ᚕgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐOtterizeServiceIdentityᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_ClientIntentsDiff_matching(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ClientIntentsDiff",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "name":
+				return ec.fieldContext_OtterizeServiceIdentity_name(ctx, field)
+			case "namespace":
+				return ec.fieldContext_OtterizeServiceIdentity_namespace(ctx, field)
+			case "labels":
+				return ec.fieldContext_OtterizeServiceIdentity_labels(ctx, field)
+			case "nameResolvedUsingAnnotation":
+				return ec.fieldContext_OtterizeServiceIdentity_nameResolvedUsingAnnotation(ctx, field)
+			case "resolutionData":
+				return ec.fieldContext_OtterizeServiceIdentity_resolutionData(ctx, field)
+			case "podOwnerKind":
+				return ec.fieldContext_OtterizeServiceIdentity_podOwnerKind(ctx, field)
+			case "kubernetesService":
+				return ec.fieldContext_OtterizeServiceIdentity_kubernetesService(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type OtterizeServiceIdentity", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _DiscoveredIntent_discoveredAt(ctx context.Context, field graphql.CollectedField, obj *model.DiscoveredIntent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DiscoveredIntent_discoveredAt(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.DiscoveredAt, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(time.Time)
+	fc.Result = res
+	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_DiscoveredIntent_discoveredAt(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "DiscoveredIntent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Time does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _DiscoveredIntent_intent(ctx context.Context, field graphql.CollectedField, obj *model.DiscoveredIntent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DiscoveredIntent_intent(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Intent, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.Intent)
+	fc.Result = res
+	return ec.marshalOIntent2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐIntent(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_DiscoveredIntent_intent(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "DiscoveredIntent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "client":
+				return ec.fieldContext_Intent_client(ctx, field)
+			case "server":
+				return ec.fieldContext_Intent_server(ctx, field)
+			case "type":
+				return ec.fieldContext_Intent_type(ctx, field)
+			case "resolutionData":
+				return ec.fieldContext_Intent_resolutionData(ctx, field)
+			case "kafkaTopics":
+				return ec.fieldContext_Intent_kafkaTopics(ctx, field)
+			case "httpResources":
+				return ec.fieldContext_Intent_httpResources(ctx, field)
+			case "awsActions":
+				return ec.fieldContext_Intent_awsActions(ctx, field)
+			case "protocol":
+				return ec.fieldContext_Intent_protocol(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Intent", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _DiscoveredIntent_externalTrafficIntent(ctx context.Context, field graphql.CollectedField, obj *model.DiscoveredIntent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DiscoveredIntent_externalTrafficIntent(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ExternalTrafficIntent, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.ExternalTrafficIntent)
+	fc.Result = res
+	return ec.marshalOExternalTrafficIntent2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐExternalTrafficIntent(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_DiscoveredIntent_externalTrafficIntent(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "DiscoveredIntent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "client":
+				return ec.fieldContext_ExternalTrafficIntent_client(ctx, field)
+			case "dnsName":
+				return ec.fieldContext_ExternalTrafficIntent_dnsName(ctx, field)
+			case "ips":
+				return ec.fieldContext_ExternalTrafficIntent_ips(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ExternalTrafficIntent", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _DiscoveredIntent_incomingTrafficIntent(ctx context.Context, field graphql.CollectedField, obj *model.DiscoveredIntent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DiscoveredIntent_incomingTrafficIntent(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.IncomingTrafficIntent, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.IncomingTrafficIntent)
+	fc.Result = res
+	return ec.marshalOIncomingTrafficIntent2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐIncomingTrafficIntent(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_DiscoveredIntent_incomingTrafficIntent(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "DiscoveredIntent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "server":
+				return ec.fieldContext_IncomingTrafficIntent_server(ctx, field)
+			case "ip":
+				return ec.fieldContext_IncomingTrafficIntent_ip(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type IncomingTrafficIntent", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ExternalTrafficIntent_client(ctx context.Context, field graphql.CollectedField, obj *model.ExternalTrafficIntent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ExternalTrafficIntent_client(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Client, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
 		}
+		return graphql.Null
 	}
-	args["server"] = arg4
-	return args, nil
+	res := resTmp.(*model.OtterizeServiceIdentity)
+	fc.Result = res
+	return ec.marshalNOtterizeServiceIdentity2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐOtterizeServiceIdentity(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) field_Query_serviceIntents_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
-	var err error
-	args := map[string]interface{}{}
-	var arg0 []string
-	if tmp, ok := rawArgs["namespaces"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("namespaces"))
-		arg0, err = ec.unmarshalOString2ᚕstringᚄ(ctx, tmp)
-		if err != nil {
-			return nil, err
-		}
+func (ec *executionContext) fieldContext_ExternalTrafficIntent_client(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ExternalTrafficIntent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "name":
+				return ec.fieldContext_OtterizeServiceIdentity_name(ctx, field)
+			case "namespace":
+				return ec.fieldContext_OtterizeServiceIdentity_namespace(ctx, field)
+			case "labels":
+				return ec.fieldContext_OtterizeServiceIdentity_labels(ctx, field)
+			case "nameResolvedUsingAnnotation":
+				return ec.fieldContext_OtterizeServiceIdentity_nameResolvedUsingAnnotation(ctx, field)
+			case "resolutionData":
+				return ec.fieldContext_OtterizeServiceIdentity_resolutionData(ctx, field)
+			case "podOwnerKind":
+				return ec.fieldContext_OtterizeServiceIdentity_podOwnerKind(ctx, field)
+			case "kubernetesService":
+				return ec.fieldContext_OtterizeServiceIdentity_kubernetesService(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type OtterizeServiceIdentity", field.Name)
+		},
 	}
-	args["namespaces"] = arg0
-	var arg1 []string
-	if tmp, ok := rawArgs["includeLabels"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("includeLabels"))
-		arg1, err = ec.unmarshalOString2ᚕstringᚄ(ctx, tmp)
-		if err != nil {
-			return nil, err
+	return fc, nil
+}
+
+func (ec *executionContext) _ExternalTrafficIntent_dnsName(ctx context.Context, field graphql.CollectedField, obj *model.ExternalTrafficIntent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ExternalTrafficIntent_dnsName(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
 		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.DNSName, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
 	}
-	args["includeLabels"] = arg1
-	var arg2 *bool
-	if tmp, ok := rawArgs["includeAllLabels"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("includeAllLabels"))
-		arg2, err = ec.unmarshalOBoolean2ᚖbool(ctx, tmp)
-		if err != nil {
-			return nil, err
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
 		}
+		return graphql.Null
 	}
-	args["includeAllLabels"] = arg2
-	return args, nil
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) field___Type_enumValues_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
-	var err error
-	args := map[string]interface{}{}
-	var arg0 bool
-	if tmp, ok := rawArgs["includeDeprecated"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("includeDeprecated"))
-		arg0, err = ec.unmarshalOBoolean2bool(ctx, tmp)
-		if err != nil {
-			return nil, err
-		}
+func (ec *executionContext) fieldContext_ExternalTrafficIntent_dnsName(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ExternalTrafficIntent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
 	}
-	args["includeDeprecated"] = arg0
-	return args, nil
+	return fc, nil
 }
 
-func (ec *executionContext) field___Type_fields_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
-	var err error
-	args := map[string]interface{}{}
-	var arg0 bool
-	if tmp, ok := rawArgs["includeDeprecated"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("includeDeprecated"))
-		arg0, err = ec.unmarshalOBoolean2bool(ctx, tmp)
-		if err != nil {
-			return nil, err
+func (ec *executionContext) _ExternalTrafficIntent_ips(ctx context.Context, field graphql.CollectedField, obj *model.ExternalTrafficIntent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ExternalTrafficIntent_ips(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Ips, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
 		}
+		return graphql.Null
 	}
-	args["includeDeprecated"] = arg0
-	return args, nil
+	res := resTmp.([]string)
+	fc.Result = res
+	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
 }
 
-// endregion ***************************** args.gotpl *****************************
-
-// region    ************************** directives.gotpl **************************
-
-// endregion ************************** directives.gotpl **************************
-
-// region    **************************** field.gotpl *****************************
+func (ec *executionContext) fieldContext_ExternalTrafficIntent_ips(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ExternalTrafficIntent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
 
 func (ec *executionContext) _GroupVersionKind_group(ctx context.Context, field graphql.CollectedField, obj *model.GroupVersionKind) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_GroupVersionKind_group(ctx, field)
@@ -1761,14 +2885,102 @@ func (ec *executionContext) fieldContext_IdentityResolutionData_extraInfo(ctx co
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _IdentityResolutionData_hasLinkerdSidecar(ctx context.Context, field graphql.CollectedField, obj *model.IdentityResolutionData) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_IdentityResolutionData_hasLinkerdSidecar(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.HasLinkerdSidecar, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*bool)
+	fc.Result = res
+	return ec.marshalOBoolean2ᚖbool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_IdentityResolutionData_hasLinkerdSidecar(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "IdentityResolutionData",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _IdentityResolutionData_tcpDestResolveFixData(ctx context.Context, field graphql.CollectedField, obj *model.IdentityResolutionData) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_IdentityResolutionData_tcpDestResolveFixData(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.TCPDestResolveFixData, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.TCPDestResolveBugfixData)
+	fc.Result = res
+	return ec.marshalOTCPDestResolveBugfixData2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐTCPDestResolveBugfixData(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_IdentityResolutionData_tcpDestResolveFixData(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "IdentityResolutionData",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "isSrcControlPlane":
+				return ec.fieldContext_TCPDestResolveBugfixData_isSrcControlPlane(ctx, field)
+			case "resolvedUsingIp":
+				return ec.fieldContext_TCPDestResolveBugfixData_resolvedUsingIp(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type TCPDestResolveBugfixData", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _IdentityResolutionData_hasLinkerdSidecar(ctx context.Context, field graphql.CollectedField, obj *model.IdentityResolutionData) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_IdentityResolutionData_hasLinkerdSidecar(ctx, field)
+func (ec *executionContext) _IncomingTrafficIntent_server(ctx context.Context, field graphql.CollectedField, obj *model.IncomingTrafficIntent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_IncomingTrafficIntent_server(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1781,35 +2993,54 @@ func (ec *executionContext) _IdentityResolutionData_hasLinkerdSidecar(ctx contex
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.HasLinkerdSidecar, nil
+		return obj.Server, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*bool)
+	res := resTmp.(*model.OtterizeServiceIdentity)
 	fc.Result = res
-	return ec.marshalOBoolean2ᚖbool(ctx, field.Selections, res)
+	return ec.marshalNOtterizeServiceIdentity2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐOtterizeServiceIdentity(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_IdentityResolutionData_hasLinkerdSidecar(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_IncomingTrafficIntent_server(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "IdentityResolutionData",
+		Object:     "IncomingTrafficIntent",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			switch field.Name {
+			case "name":
+				return ec.fieldContext_OtterizeServiceIdentity_name(ctx, field)
+			case "namespace":
+				return ec.fieldContext_OtterizeServiceIdentity_namespace(ctx, field)
+			case "labels":
+				return ec.fieldContext_OtterizeServiceIdentity_labels(ctx, field)
+			case "nameResolvedUsingAnnotation":
+				return ec.fieldContext_OtterizeServiceIdentity_nameResolvedUsingAnnotation(ctx, field)
+			case "resolutionData":
+				return ec.fieldContext_OtterizeServiceIdentity_resolutionData(ctx, field)
+			case "podOwnerKind":
+				return ec.fieldContext_OtterizeServiceIdentity_podOwnerKind(ctx, field)
+			case "kubernetesService":
+				return ec.fieldContext_OtterizeServiceIdentity_kubernetesService(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type OtterizeServiceIdentity", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _IdentityResolutionData_tcpDestResolveFixData(ctx context.Context, field graphql.CollectedField, obj *model.IdentityResolutionData) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_IdentityResolutionData_tcpDestResolveFixData(ctx, field)
+func (ec *executionContext) _IncomingTrafficIntent_ip(ctx context.Context, field graphql.CollectedField, obj *model.IncomingTrafficIntent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_IncomingTrafficIntent_ip(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1822,34 +3053,31 @@ func (ec *executionContext) _IdentityResolutionData_tcpDestResolveFixData(ctx co
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.TCPDestResolveFixData, nil
+		return obj.IP, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.TCPDestResolveBugfixData)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOTCPDestResolveBugfixData2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐTCPDestResolveBugfixData(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_IdentityResolutionData_tcpDestResolveFixData(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_IncomingTrafficIntent_ip(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "IdentityResolutionData",
+		Object:     "IncomingTrafficIntent",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "isSrcControlPlane":
-				return ec.fieldContext_TCPDestResolveBugfixData_isSrcControlPlane(ctx, field)
-			case "resolvedUsingIp":
-				return ec.fieldContext_TCPDestResolveBugfixData_resolvedUsingIp(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type TCPDestResolveBugfixData", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
@@ -2097,6 +3325,8 @@ func (ec *executionContext) fieldContext_Intent_kafkaTopics(ctx context.Context,
 				return ec.fieldContext_KafkaConfig_name(ctx, field)
 			case "operations":
 				return ec.fieldContext_KafkaConfig_operations(ctx, field)
+			case "resourceType":
+				return ec.fieldContext_KafkaConfig_resourceType(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type KafkaConfig", field.Name)
 		},
@@ -2192,6 +3422,47 @@ func (ec *executionContext) fieldContext_Intent_awsActions(ctx context.Context,
 	return fc, nil
 }
 
+func (ec *executionContext) _Intent_protocol(ctx context.Context, field graphql.CollectedField, obj *model.Intent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Intent_protocol(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Protocol, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.TransportProtocol)
+	fc.Result = res
+	return ec.marshalOTransportProtocol2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐTransportProtocol(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Intent_protocol(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Intent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type TransportProtocol does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _KafkaConfig_name(ctx context.Context, field graphql.CollectedField, obj *model.KafkaConfig) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_KafkaConfig_name(ctx, field)
 	if err != nil {
@@ -2230,14 +3501,195 @@ func (ec *executionContext) fieldContext_KafkaConfig_name(ctx context.Context, f
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _KafkaConfig_operations(ctx context.Context, field graphql.CollectedField, obj *model.KafkaConfig) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_KafkaConfig_operations(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Operations, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]model.KafkaOperation)
+	fc.Result = res
+	return ec.marshalOKafkaOperation2ᚕgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐKafkaOperationᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_KafkaConfig_operations(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "KafkaConfig",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type KafkaOperation does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _KafkaConfig_resourceType(ctx context.Context, field graphql.CollectedField, obj *model.KafkaConfig) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_KafkaConfig_resourceType(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ResourceType, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.KafkaResourceType)
+	fc.Result = res
+	return ec.marshalOKafkaResourceType2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐKafkaResourceType(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_KafkaConfig_resourceType(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "KafkaConfig",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type KafkaResourceType does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_resetCapture(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_resetCapture(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Mutation().ResetCapture(rctx)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Mutation_resetCapture(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_reportCaptureResults(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_reportCaptureResults(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Mutation().ReportCaptureResults(rctx, fc.Args["results"].(model.CaptureResults))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Mutation_reportCaptureResults(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_reportCaptureResults_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _KafkaConfig_operations(ctx context.Context, field graphql.CollectedField, obj *model.KafkaConfig) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_KafkaConfig_operations(ctx, field)
+func (ec *executionContext) _Mutation_reportTCPCaptureResults(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_reportTCPCaptureResults(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -2250,35 +3702,49 @@ func (ec *executionContext) _KafkaConfig_operations(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Operations, nil
+		return ec.resolvers.Mutation().ReportTCPCaptureResults(rctx, fc.Args["results"].(model.CaptureTCPResults))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.([]model.KafkaOperation)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalOKafkaOperation2ᚕgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐKafkaOperationᚄ(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_KafkaConfig_operations(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_reportTCPCaptureResults(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "KafkaConfig",
+		Object:     "Mutation",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type KafkaOperation does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_reportTCPCaptureResults_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_resetCapture(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_resetCapture(ctx, field)
+func (ec *executionContext) _Mutation_reportTLSCaptureResults(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_reportTLSCaptureResults(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -2291,7 +3757,7 @@ func (ec *executionContext) _Mutation_resetCapture(ctx context.Context, field gr
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ResetCapture(rctx)
+		return ec.resolvers.Mutation().ReportTLSCaptureResults(rctx, fc.Args["results"].(model.CaptureTLSResults))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2308,7 +3774,7 @@ func (ec *executionContext) _Mutation_resetCapture(ctx context.Context, field gr
 	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_resetCapture(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_reportTLSCaptureResults(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Mutation",
 		Field:      field,
@@ -2318,11 +3784,22 @@ func (ec *executionContext) fieldContext_Mutation_resetCapture(ctx context.Conte
 			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_reportTLSCaptureResults_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_reportCaptureResults(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_reportCaptureResults(ctx, field)
+func (ec *executionContext) _Mutation_reportSocketScanResults(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_reportSocketScanResults(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -2335,7 +3812,7 @@ func (ec *executionContext) _Mutation_reportCaptureResults(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ReportCaptureResults(rctx, fc.Args["results"].(model.CaptureResults))
+		return ec.resolvers.Mutation().ReportSocketScanResults(rctx, fc.Args["results"].(model.SocketScanResults))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2352,7 +3829,7 @@ func (ec *executionContext) _Mutation_reportCaptureResults(ctx context.Context,
 	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_reportCaptureResults(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_reportSocketScanResults(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Mutation",
 		Field:      field,
@@ -2369,15 +3846,15 @@ func (ec *executionContext) fieldContext_Mutation_reportCaptureResults(ctx conte
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_reportCaptureResults_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Mutation_reportSocketScanResults_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return fc, err
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_reportTCPCaptureResults(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_reportTCPCaptureResults(ctx, field)
+func (ec *executionContext) _Mutation_reportKafkaMapperResults(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_reportKafkaMapperResults(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -2390,7 +3867,7 @@ func (ec *executionContext) _Mutation_reportTCPCaptureResults(ctx context.Contex
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ReportTCPCaptureResults(rctx, fc.Args["results"].(model.CaptureTCPResults))
+		return ec.resolvers.Mutation().ReportKafkaMapperResults(rctx, fc.Args["results"].(model.KafkaMapperResults))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2407,7 +3884,7 @@ func (ec *executionContext) _Mutation_reportTCPCaptureResults(ctx context.Contex
 	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_reportTCPCaptureResults(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_reportKafkaMapperResults(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Mutation",
 		Field:      field,
@@ -2424,15 +3901,15 @@ func (ec *executionContext) fieldContext_Mutation_reportTCPCaptureResults(ctx co
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_reportTCPCaptureResults_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Mutation_reportKafkaMapperResults_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return fc, err
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_reportSocketScanResults(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_reportSocketScanResults(ctx, field)
+func (ec *executionContext) _Mutation_reportIstioConnectionResults(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_reportIstioConnectionResults(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -2445,7 +3922,7 @@ func (ec *executionContext) _Mutation_reportSocketScanResults(ctx context.Contex
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ReportSocketScanResults(rctx, fc.Args["results"].(model.SocketScanResults))
+		return ec.resolvers.Mutation().ReportIstioConnectionResults(rctx, fc.Args["results"].(model.IstioConnectionResults))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2462,7 +3939,7 @@ func (ec *executionContext) _Mutation_reportSocketScanResults(ctx context.Contex
 	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_reportSocketScanResults(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_reportIstioConnectionResults(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Mutation",
 		Field:      field,
@@ -2479,15 +3956,15 @@ func (ec *executionContext) fieldContext_Mutation_reportSocketScanResults(ctx co
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_reportSocketScanResults_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Mutation_reportIstioConnectionResults_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return fc, err
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_reportKafkaMapperResults(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_reportKafkaMapperResults(ctx, field)
+func (ec *executionContext) _Mutation_reportLinkerdConnectionResults(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_reportLinkerdConnectionResults(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -2500,7 +3977,7 @@ func (ec *executionContext) _Mutation_reportKafkaMapperResults(ctx context.Conte
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ReportKafkaMapperResults(rctx, fc.Args["results"].(model.KafkaMapperResults))
+		return ec.resolvers.Mutation().ReportLinkerdConnectionResults(rctx, fc.Args["results"].(model.LinkerdConnectionResults))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2517,7 +3994,7 @@ func (ec *executionContext) _Mutation_reportKafkaMapperResults(ctx context.Conte
 	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_reportKafkaMapperResults(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_reportLinkerdConnectionResults(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Mutation",
 		Field:      field,
@@ -2534,15 +4011,15 @@ func (ec *executionContext) fieldContext_Mutation_reportKafkaMapperResults(ctx c
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_reportKafkaMapperResults_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Mutation_reportLinkerdConnectionResults_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return fc, err
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_reportIstioConnectionResults(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_reportIstioConnectionResults(ctx, field)
+func (ec *executionContext) _Mutation_reportHTTPRequestResults(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_reportHTTPRequestResults(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -2555,7 +4032,7 @@ func (ec *executionContext) _Mutation_reportIstioConnectionResults(ctx context.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ReportIstioConnectionResults(rctx, fc.Args["results"].(model.IstioConnectionResults))
+		return ec.resolvers.Mutation().ReportHTTPRequestResults(rctx, fc.Args["results"].(model.HTTPRequestResults))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2572,7 +4049,7 @@ func (ec *executionContext) _Mutation_reportIstioConnectionResults(ctx context.C
 	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_reportIstioConnectionResults(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_reportHTTPRequestResults(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Mutation",
 		Field:      field,
@@ -2589,7 +4066,7 @@ func (ec *executionContext) fieldContext_Mutation_reportIstioConnectionResults(c
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_reportIstioConnectionResults_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Mutation_reportHTTPRequestResults_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return fc, err
 	}
@@ -3247,7 +4724,7 @@ func (ec *executionContext) _Query_serviceIntents(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ServiceIntents(rctx, fc.Args["namespaces"].([]string), fc.Args["includeLabels"].([]string), fc.Args["includeAllLabels"].(*bool))
+		return ec.resolvers.Query().ServiceIntents(rctx, fc.Args["namespaces"].([]string), fc.Args["includeLabels"].([]string), fc.Args["includeAllLabels"].(*bool), fc.Args["since"].(*time.Time), fc.Args["until"].(*time.Time))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3308,7 +4785,7 @@ func (ec *executionContext) _Query_intents(ctx context.Context, field graphql.Co
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Intents(rctx, fc.Args["namespaces"].([]string), fc.Args["includeLabels"].([]string), fc.Args["excludeServiceWithLabels"].([]string), fc.Args["includeAllLabels"].(*bool), fc.Args["server"].(*model.ServerFilter))
+		return ec.resolvers.Query().Intents(rctx, fc.Args["namespaces"].([]string), fc.Args["includeLabels"].([]string), fc.Args["excludeServiceWithLabels"].([]string), fc.Args["includeAllLabels"].(*bool), fc.Args["server"].(*model.ServerFilter), fc.Args["since"].(*time.Time), fc.Args["until"].(*time.Time))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3347,6 +4824,8 @@ func (ec *executionContext) fieldContext_Query_intents(ctx context.Context, fiel
 				return ec.fieldContext_Intent_httpResources(ctx, field)
 			case "awsActions":
 				return ec.fieldContext_Intent_awsActions(ctx, field)
+			case "protocol":
+				return ec.fieldContext_Intent_protocol(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type Intent", field.Name)
 		},
@@ -3365,6 +4844,126 @@ func (ec *executionContext) fieldContext_Query_intents(ctx context.Context, fiel
 	return fc, nil
 }
 
+func (ec *executionContext) _Query_clientIntentsYAML(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_clientIntentsYAML(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().ClientIntentsYaml(rctx, fc.Args["namespaces"].([]string))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Query_clientIntentsYAML(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_clientIntentsYAML_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_intentsDiff(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_intentsDiff(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().IntentsDiff(rctx, fc.Args["namespaces"].([]string))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]model.ClientIntentsDiff)
+	fc.Result = res
+	return ec.marshalNClientIntentsDiff2ᚕgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐClientIntentsDiffᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Query_intentsDiff(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "client":
+				return ec.fieldContext_ClientIntentsDiff_client(ctx, field)
+			case "missing":
+				return ec.fieldContext_ClientIntentsDiff_missing(ctx, field)
+			case "unused":
+				return ec.fieldContext_ClientIntentsDiff_unused(ctx, field)
+			case "matching":
+				return ec.fieldContext_ClientIntentsDiff_matching(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ClientIntentsDiff", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_intentsDiff_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Query_health(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Query_health(ctx, field)
 	if err != nil {
@@ -3612,49 +5211,128 @@ func (ec *executionContext) _ServiceIntents_intents(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Intents, nil
+		return obj.Intents, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]model.OtterizeServiceIdentity)
+	fc.Result = res
+	return ec.marshalNOtterizeServiceIdentity2ᚕgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐOtterizeServiceIdentityᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_ServiceIntents_intents(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ServiceIntents",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "name":
+				return ec.fieldContext_OtterizeServiceIdentity_name(ctx, field)
+			case "namespace":
+				return ec.fieldContext_OtterizeServiceIdentity_namespace(ctx, field)
+			case "labels":
+				return ec.fieldContext_OtterizeServiceIdentity_labels(ctx, field)
+			case "nameResolvedUsingAnnotation":
+				return ec.fieldContext_OtterizeServiceIdentity_nameResolvedUsingAnnotation(ctx, field)
+			case "resolutionData":
+				return ec.fieldContext_OtterizeServiceIdentity_resolutionData(ctx, field)
+			case "podOwnerKind":
+				return ec.fieldContext_OtterizeServiceIdentity_podOwnerKind(ctx, field)
+			case "kubernetesService":
+				return ec.fieldContext_OtterizeServiceIdentity_kubernetesService(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type OtterizeServiceIdentity", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Subscription_intentDiscovered(ctx context.Context, field graphql.CollectedField) (ret func(ctx context.Context) graphql.Marshaler) {
+	fc, err := ec.fieldContext_Subscription_intentDiscovered(ctx, field)
+	if err != nil {
+		return nil
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = nil
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Subscription().IntentDiscovered(rctx, fc.Args["namespaces"].([]string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
-		return graphql.Null
+		return nil
 	}
 	if resTmp == nil {
 		if !graphql.HasFieldError(ctx, fc) {
 			ec.Errorf(ctx, "must not be null")
 		}
-		return graphql.Null
+		return nil
+	}
+	return func(ctx context.Context) graphql.Marshaler {
+		select {
+		case res, ok := <-resTmp.(<-chan *model.DiscoveredIntent):
+			if !ok {
+				return nil
+			}
+			return graphql.WriterFunc(func(w io.Writer) {
+				w.Write([]byte{'{'})
+				graphql.MarshalString(field.Alias).MarshalGQL(w)
+				w.Write([]byte{':'})
+				ec.marshalNDiscoveredIntent2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐDiscoveredIntent(ctx, field.Selections, res).MarshalGQL(w)
+				w.Write([]byte{'}'})
+			})
+		case <-ctx.Done():
+			return nil
+		}
 	}
-	res := resTmp.([]model.OtterizeServiceIdentity)
-	fc.Result = res
-	return ec.marshalNOtterizeServiceIdentity2ᚕgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐOtterizeServiceIdentityᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ServiceIntents_intents(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Subscription_intentDiscovered(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ServiceIntents",
+		Object:     "Subscription",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "name":
-				return ec.fieldContext_OtterizeServiceIdentity_name(ctx, field)
-			case "namespace":
-				return ec.fieldContext_OtterizeServiceIdentity_namespace(ctx, field)
-			case "labels":
-				return ec.fieldContext_OtterizeServiceIdentity_labels(ctx, field)
-			case "nameResolvedUsingAnnotation":
-				return ec.fieldContext_OtterizeServiceIdentity_nameResolvedUsingAnnotation(ctx, field)
-			case "resolutionData":
-				return ec.fieldContext_OtterizeServiceIdentity_resolutionData(ctx, field)
-			case "podOwnerKind":
-				return ec.fieldContext_OtterizeServiceIdentity_podOwnerKind(ctx, field)
-			case "kubernetesService":
-				return ec.fieldContext_OtterizeServiceIdentity_kubernetesService(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type OtterizeServiceIdentity", field.Name)
+			case "discoveredAt":
+				return ec.fieldContext_DiscoveredIntent_discoveredAt(ctx, field)
+			case "intent":
+				return ec.fieldContext_DiscoveredIntent_intent(ctx, field)
+			case "externalTrafficIntent":
+				return ec.fieldContext_DiscoveredIntent_externalTrafficIntent(ctx, field)
+			case "incomingTrafficIntent":
+				return ec.fieldContext_DiscoveredIntent_incomingTrafficIntent(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type DiscoveredIntent", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Subscription_intentDiscovered_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
@@ -5683,6 +7361,33 @@ func (ec *executionContext) unmarshalInputCaptureTCPResults(ctx context.Context,
 	return it, nil
 }
 
+func (ec *executionContext) unmarshalInputCaptureTLSResults(ctx context.Context, obj interface{}) (model.CaptureTLSResults, error) {
+	var it model.CaptureTLSResults
+	asMap := map[string]interface{}{}
+	for k, v := range obj.(map[string]interface{}) {
+		asMap[k] = v
+	}
+
+	fieldsInOrder := [...]string{"results"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "results":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("results"))
+			data, err := ec.unmarshalNRecordedDestinationsForSrc2ᚕgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐRecordedDestinationsForSrcᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Results = data
+		}
+	}
+
+	return it, nil
+}
+
 func (ec *executionContext) unmarshalInputDestination(ctx context.Context, obj interface{}) (model.Destination, error) {
 	var it model.Destination
 	asMap := map[string]interface{}{}
@@ -5690,7 +7395,7 @@ func (ec *executionContext) unmarshalInputDestination(ctx context.Context, obj i
 		asMap[k] = v
 	}
 
-	fieldsInOrder := [...]string{"destination", "destinationIP", "destinationPort", "TTL", "lastSeen", "srcPorts"}
+	fieldsInOrder := [...]string{"destination", "destinationIP", "destinationPort", "TTL", "lastSeen", "srcPorts", "protocol"}
 	for _, k := range fieldsInOrder {
 		v, ok := asMap[k]
 		if !ok {
@@ -5739,6 +7444,13 @@ func (ec *executionContext) unmarshalInputDestination(ctx context.Context, obj i
 				return it, err
 			}
 			it.SrcPorts = data
+		case "protocol":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("protocol"))
+			data, err := ec.unmarshalOTransportProtocol2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐTransportProtocol(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Protocol = data
 		}
 	}
 
@@ -5793,6 +7505,95 @@ func (ec *executionContext) unmarshalInputGCPOperation(ctx context.Context, obj
 	return it, nil
 }
 
+func (ec *executionContext) unmarshalInputHTTPRequestResult(ctx context.Context, obj interface{}) (model.HTTPRequestResult, error) {
+	var it model.HTTPRequestResult
+	asMap := map[string]interface{}{}
+	for k, v := range obj.(map[string]interface{}) {
+		asMap[k] = v
+	}
+
+	fieldsInOrder := [...]string{"srcIp", "dstIp", "dstPort", "method", "path", "lastSeen"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "srcIp":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("srcIp"))
+			data, err := ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.SrcIP = data
+		case "dstIp":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("dstIp"))
+			data, err := ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.DstIP = data
+		case "dstPort":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("dstPort"))
+			data, err := ec.unmarshalNInt2int64(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.DstPort = data
+		case "method":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("method"))
+			data, err := ec.unmarshalNHttpMethod2githubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐHTTPMethod(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Method = data
+		case "path":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("path"))
+			data, err := ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Path = data
+		case "lastSeen":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("lastSeen"))
+			data, err := ec.unmarshalNTime2timeᚐTime(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.LastSeen = data
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputHTTPRequestResults(ctx context.Context, obj interface{}) (model.HTTPRequestResults, error) {
+	var it model.HTTPRequestResults
+	asMap := map[string]interface{}{}
+	for k, v := range obj.(map[string]interface{}) {
+		asMap[k] = v
+	}
+
+	fieldsInOrder := [...]string{"results"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "results":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("results"))
+			data, err := ec.unmarshalNHTTPRequestResult2ᚕgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐHTTPRequestResultᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Results = data
+		}
+	}
+
+	return it, nil
+}
+
 func (ec *executionContext) unmarshalInputIstioConnection(ctx context.Context, obj interface{}) (model.IstioConnection, error) {
 	var it model.IstioConnection
 	asMap := map[string]interface{}{}
@@ -5813,64 +7614,188 @@ func (ec *executionContext) unmarshalInputIstioConnection(ctx context.Context, o
 			if err != nil {
 				return it, err
 			}
-			it.SrcWorkload = data
-		case "srcWorkloadNamespace":
-			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("srcWorkloadNamespace"))
-			data, err := ec.unmarshalNString2string(ctx, v)
+			it.SrcWorkload = data
+		case "srcWorkloadNamespace":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("srcWorkloadNamespace"))
+			data, err := ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.SrcWorkloadNamespace = data
+		case "dstWorkload":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("dstWorkload"))
+			data, err := ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.DstWorkload = data
+		case "dstServiceName":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("dstServiceName"))
+			data, err := ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.DstServiceName = data
+		case "dstWorkloadNamespace":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("dstWorkloadNamespace"))
+			data, err := ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.DstWorkloadNamespace = data
+		case "path":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("path"))
+			data, err := ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Path = data
+		case "methods":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("methods"))
+			data, err := ec.unmarshalNHttpMethod2ᚕgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐHTTPMethodᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Methods = data
+		case "lastSeen":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("lastSeen"))
+			data, err := ec.unmarshalNTime2timeᚐTime(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.LastSeen = data
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputIstioConnectionResults(ctx context.Context, obj interface{}) (model.IstioConnectionResults, error) {
+	var it model.IstioConnectionResults
+	asMap := map[string]interface{}{}
+	for k, v := range obj.(map[string]interface{}) {
+		asMap[k] = v
+	}
+
+	fieldsInOrder := [...]string{"results"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "results":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("results"))
+			data, err := ec.unmarshalNIstioConnection2ᚕgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐIstioConnectionᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Results = data
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputKafkaMapperResult(ctx context.Context, obj interface{}) (model.KafkaMapperResult, error) {
+	var it model.KafkaMapperResult
+	asMap := map[string]interface{}{}
+	for k, v := range obj.(map[string]interface{}) {
+		asMap[k] = v
+	}
+
+	fieldsInOrder := [...]string{"srcIp", "serverPodName", "serverNamespace", "serverIp", "topic", "operation", "lastSeen", "principal", "resourceType", "patternType", "srcServiceAccount"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "srcIp":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("srcIp"))
+			data, err := ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.SrcIP = data
+		case "serverPodName":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("serverPodName"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ServerPodName = data
+		case "serverNamespace":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("serverNamespace"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ServerNamespace = data
+		case "serverIp":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("serverIp"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
 			if err != nil {
 				return it, err
 			}
-			it.SrcWorkloadNamespace = data
-		case "dstWorkload":
-			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("dstWorkload"))
+			it.ServerIP = data
+		case "topic":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("topic"))
 			data, err := ec.unmarshalNString2string(ctx, v)
 			if err != nil {
 				return it, err
 			}
-			it.DstWorkload = data
-		case "dstServiceName":
-			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("dstServiceName"))
+			it.Topic = data
+		case "operation":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("operation"))
 			data, err := ec.unmarshalNString2string(ctx, v)
 			if err != nil {
 				return it, err
 			}
-			it.DstServiceName = data
-		case "dstWorkloadNamespace":
-			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("dstWorkloadNamespace"))
-			data, err := ec.unmarshalNString2string(ctx, v)
+			it.Operation = data
+		case "lastSeen":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("lastSeen"))
+			data, err := ec.unmarshalNTime2timeᚐTime(ctx, v)
 			if err != nil {
 				return it, err
 			}
-			it.DstWorkloadNamespace = data
-		case "path":
-			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("path"))
-			data, err := ec.unmarshalNString2string(ctx, v)
+			it.LastSeen = data
+		case "principal":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("principal"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
 			if err != nil {
 				return it, err
 			}
-			it.Path = data
-		case "methods":
-			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("methods"))
-			data, err := ec.unmarshalNHttpMethod2ᚕgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐHTTPMethodᚄ(ctx, v)
+			it.Principal = data
+		case "resourceType":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("resourceType"))
+			data, err := ec.unmarshalOKafkaResourceType2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐKafkaResourceType(ctx, v)
 			if err != nil {
 				return it, err
 			}
-			it.Methods = data
-		case "lastSeen":
-			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("lastSeen"))
-			data, err := ec.unmarshalNTime2timeᚐTime(ctx, v)
+			it.ResourceType = data
+		case "patternType":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("patternType"))
+			data, err := ec.unmarshalOKafkaPatternType2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐKafkaPatternType(ctx, v)
 			if err != nil {
 				return it, err
 			}
-			it.LastSeen = data
+			it.PatternType = data
+		case "srcServiceAccount":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("srcServiceAccount"))
+			data, err := ec.unmarshalONamespacedName2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐNamespacedName(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.SrcServiceAccount = data
 		}
 	}
 
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputIstioConnectionResults(ctx context.Context, obj interface{}) (model.IstioConnectionResults, error) {
-	var it model.IstioConnectionResults
+func (ec *executionContext) unmarshalInputKafkaMapperResults(ctx context.Context, obj interface{}) (model.KafkaMapperResults, error) {
+	var it model.KafkaMapperResults
 	asMap := map[string]interface{}{}
 	for k, v := range obj.(map[string]interface{}) {
 		asMap[k] = v
@@ -5885,7 +7810,7 @@ func (ec *executionContext) unmarshalInputIstioConnectionResults(ctx context.Con
 		switch k {
 		case "results":
 			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("results"))
-			data, err := ec.unmarshalNIstioConnection2ᚕgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐIstioConnectionᚄ(ctx, v)
+			data, err := ec.unmarshalNKafkaMapperResult2ᚕgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐKafkaMapperResultᚄ(ctx, v)
 			if err != nil {
 				return it, err
 			}
@@ -5896,55 +7821,55 @@ func (ec *executionContext) unmarshalInputIstioConnectionResults(ctx context.Con
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputKafkaMapperResult(ctx context.Context, obj interface{}) (model.KafkaMapperResult, error) {
-	var it model.KafkaMapperResult
+func (ec *executionContext) unmarshalInputLinkerdConnection(ctx context.Context, obj interface{}) (model.LinkerdConnection, error) {
+	var it model.LinkerdConnection
 	asMap := map[string]interface{}{}
 	for k, v := range obj.(map[string]interface{}) {
 		asMap[k] = v
 	}
 
-	fieldsInOrder := [...]string{"srcIp", "serverPodName", "serverNamespace", "topic", "operation", "lastSeen"}
+	fieldsInOrder := [...]string{"srcPodName", "srcNamespace", "authority", "path", "methods", "lastSeen"}
 	for _, k := range fieldsInOrder {
 		v, ok := asMap[k]
 		if !ok {
 			continue
 		}
 		switch k {
-		case "srcIp":
-			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("srcIp"))
+		case "srcPodName":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("srcPodName"))
 			data, err := ec.unmarshalNString2string(ctx, v)
 			if err != nil {
 				return it, err
 			}
-			it.SrcIP = data
-		case "serverPodName":
-			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("serverPodName"))
+			it.SrcPodName = data
+		case "srcNamespace":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("srcNamespace"))
 			data, err := ec.unmarshalNString2string(ctx, v)
 			if err != nil {
 				return it, err
 			}
-			it.ServerPodName = data
-		case "serverNamespace":
-			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("serverNamespace"))
+			it.SrcNamespace = data
+		case "authority":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("authority"))
 			data, err := ec.unmarshalNString2string(ctx, v)
 			if err != nil {
 				return it, err
 			}
-			it.ServerNamespace = data
-		case "topic":
-			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("topic"))
-			data, err := ec.unmarshalNString2string(ctx, v)
+			it.Authority = data
+		case "path":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("path"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
 			if err != nil {
 				return it, err
 			}
-			it.Topic = data
-		case "operation":
-			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("operation"))
-			data, err := ec.unmarshalNString2string(ctx, v)
+			it.Path = data
+		case "methods":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("methods"))
+			data, err := ec.unmarshalNHttpMethod2ᚕgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐHTTPMethodᚄ(ctx, v)
 			if err != nil {
 				return it, err
 			}
-			it.Operation = data
+			it.Methods = data
 		case "lastSeen":
 			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("lastSeen"))
 			data, err := ec.unmarshalNTime2timeᚐTime(ctx, v)
@@ -5958,8 +7883,8 @@ func (ec *executionContext) unmarshalInputKafkaMapperResult(ctx context.Context,
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputKafkaMapperResults(ctx context.Context, obj interface{}) (model.KafkaMapperResults, error) {
-	var it model.KafkaMapperResults
+func (ec *executionContext) unmarshalInputLinkerdConnectionResults(ctx context.Context, obj interface{}) (model.LinkerdConnectionResults, error) {
+	var it model.LinkerdConnectionResults
 	asMap := map[string]interface{}{}
 	for k, v := range obj.(map[string]interface{}) {
 		asMap[k] = v
@@ -5974,7 +7899,7 @@ func (ec *executionContext) unmarshalInputKafkaMapperResults(ctx context.Context
 		switch k {
 		case "results":
 			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("results"))
-			data, err := ec.unmarshalNKafkaMapperResult2ᚕgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐKafkaMapperResultᚄ(ctx, v)
+			data, err := ec.unmarshalNLinkerdConnection2ᚕgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐLinkerdConnectionᚄ(ctx, v)
 			if err != nil {
 				return it, err
 			}
@@ -6026,7 +7951,7 @@ func (ec *executionContext) unmarshalInputRecordedDestinationsForSrc(ctx context
 		asMap[k] = v
 	}
 
-	fieldsInOrder := [...]string{"srcIp", "srcHostname", "destinations"}
+	fieldsInOrder := [...]string{"srcIp", "srcHostname", "srcContainerId", "destinations"}
 	for _, k := range fieldsInOrder {
 		v, ok := asMap[k]
 		if !ok {
@@ -6047,6 +7972,13 @@ func (ec *executionContext) unmarshalInputRecordedDestinationsForSrc(ctx context
 				return it, err
 			}
 			it.SrcHostname = data
+		case "srcContainerId":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("srcContainerId"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.SrcContainerID = data
 		case "destinations":
 			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("destinations"))
 			data, err := ec.unmarshalNDestination2ᚕgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐDestinationᚄ(ctx, v)
@@ -6204,6 +8136,154 @@ func (ec *executionContext) unmarshalInputTrafficLevelResults(ctx context.Contex
 
 // region    **************************** object.gotpl ****************************
 
+var clientIntentsDiffImplementors = []string{"ClientIntentsDiff"}
+
+func (ec *executionContext) _ClientIntentsDiff(ctx context.Context, sel ast.SelectionSet, obj *model.ClientIntentsDiff) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, clientIntentsDiffImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("ClientIntentsDiff")
+		case "client":
+			out.Values[i] = ec._ClientIntentsDiff_client(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "missing":
+			out.Values[i] = ec._ClientIntentsDiff_missing(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "unused":
+			out.Values[i] = ec._ClientIntentsDiff_unused(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "matching":
+			out.Values[i] = ec._ClientIntentsDiff_matching(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var discoveredIntentImplementors = []string{"DiscoveredIntent"}
+
+func (ec *executionContext) _DiscoveredIntent(ctx context.Context, sel ast.SelectionSet, obj *model.DiscoveredIntent) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, discoveredIntentImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("DiscoveredIntent")
+		case "discoveredAt":
+			out.Values[i] = ec._DiscoveredIntent_discoveredAt(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "intent":
+			out.Values[i] = ec._DiscoveredIntent_intent(ctx, field, obj)
+		case "externalTrafficIntent":
+			out.Values[i] = ec._DiscoveredIntent_externalTrafficIntent(ctx, field, obj)
+		case "incomingTrafficIntent":
+			out.Values[i] = ec._DiscoveredIntent_incomingTrafficIntent(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var externalTrafficIntentImplementors = []string{"ExternalTrafficIntent"}
+
+func (ec *executionContext) _ExternalTrafficIntent(ctx context.Context, sel ast.SelectionSet, obj *model.ExternalTrafficIntent) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, externalTrafficIntentImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("ExternalTrafficIntent")
+		case "client":
+			out.Values[i] = ec._ExternalTrafficIntent_client(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "dnsName":
+			out.Values[i] = ec._ExternalTrafficIntent_dnsName(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "ips":
+			out.Values[i] = ec._ExternalTrafficIntent_ips(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
 var groupVersionKindImplementors = []string{"GroupVersionKind"}
 
 func (ec *executionContext) _GroupVersionKind(ctx context.Context, sel ast.SelectionSet, obj *model.GroupVersionKind) graphql.Marshaler {
@@ -6301,27 +8381,71 @@ func (ec *executionContext) _IdentityResolutionData(ctx context.Context, sel ast
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("IdentityResolutionData")
-		case "host":
-			out.Values[i] = ec._IdentityResolutionData_host(ctx, field, obj)
-		case "podHostname":
-			out.Values[i] = ec._IdentityResolutionData_podHostname(ctx, field, obj)
-		case "procfsHostname":
-			out.Values[i] = ec._IdentityResolutionData_procfsHostname(ctx, field, obj)
-		case "port":
-			out.Values[i] = ec._IdentityResolutionData_port(ctx, field, obj)
-		case "isService":
-			out.Values[i] = ec._IdentityResolutionData_isService(ctx, field, obj)
-		case "uptime":
-			out.Values[i] = ec._IdentityResolutionData_uptime(ctx, field, obj)
-		case "lastSeen":
-			out.Values[i] = ec._IdentityResolutionData_lastSeen(ctx, field, obj)
-		case "extraInfo":
-			out.Values[i] = ec._IdentityResolutionData_extraInfo(ctx, field, obj)
-		case "hasLinkerdSidecar":
-			out.Values[i] = ec._IdentityResolutionData_hasLinkerdSidecar(ctx, field, obj)
-		case "tcpDestResolveFixData":
-			out.Values[i] = ec._IdentityResolutionData_tcpDestResolveFixData(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("IdentityResolutionData")
+		case "host":
+			out.Values[i] = ec._IdentityResolutionData_host(ctx, field, obj)
+		case "podHostname":
+			out.Values[i] = ec._IdentityResolutionData_podHostname(ctx, field, obj)
+		case "procfsHostname":
+			out.Values[i] = ec._IdentityResolutionData_procfsHostname(ctx, field, obj)
+		case "port":
+			out.Values[i] = ec._IdentityResolutionData_port(ctx, field, obj)
+		case "isService":
+			out.Values[i] = ec._IdentityResolutionData_isService(ctx, field, obj)
+		case "uptime":
+			out.Values[i] = ec._IdentityResolutionData_uptime(ctx, field, obj)
+		case "lastSeen":
+			out.Values[i] = ec._IdentityResolutionData_lastSeen(ctx, field, obj)
+		case "extraInfo":
+			out.Values[i] = ec._IdentityResolutionData_extraInfo(ctx, field, obj)
+		case "hasLinkerdSidecar":
+			out.Values[i] = ec._IdentityResolutionData_hasLinkerdSidecar(ctx, field, obj)
+		case "tcpDestResolveFixData":
+			out.Values[i] = ec._IdentityResolutionData_tcpDestResolveFixData(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var incomingTrafficIntentImplementors = []string{"IncomingTrafficIntent"}
+
+func (ec *executionContext) _IncomingTrafficIntent(ctx context.Context, sel ast.SelectionSet, obj *model.IncomingTrafficIntent) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, incomingTrafficIntentImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("IncomingTrafficIntent")
+		case "server":
+			out.Values[i] = ec._IncomingTrafficIntent_server(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "ip":
+			out.Values[i] = ec._IncomingTrafficIntent_ip(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -6376,6 +8500,8 @@ func (ec *executionContext) _Intent(ctx context.Context, sel ast.SelectionSet, o
 			out.Values[i] = ec._Intent_httpResources(ctx, field, obj)
 		case "awsActions":
 			out.Values[i] = ec._Intent_awsActions(ctx, field, obj)
+		case "protocol":
+			out.Values[i] = ec._Intent_protocol(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -6417,6 +8543,8 @@ func (ec *executionContext) _KafkaConfig(ctx context.Context, sel ast.SelectionS
 			}
 		case "operations":
 			out.Values[i] = ec._KafkaConfig_operations(ctx, field, obj)
+		case "resourceType":
+			out.Values[i] = ec._KafkaConfig_resourceType(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -6480,6 +8608,13 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "reportTLSCaptureResults":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_reportTLSCaptureResults(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		case "reportSocketScanResults":
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
 				return ec._Mutation_reportSocketScanResults(ctx, field)
@@ -6501,6 +8636,20 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "reportLinkerdConnectionResults":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_reportLinkerdConnectionResults(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "reportHTTPRequestResults":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_reportHTTPRequestResults(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		case "reportAWSOperation":
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
 				return ec._Mutation_reportAWSOperation(ctx, field)
@@ -6712,6 +8861,50 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
 			}
 
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "clientIntentsYAML":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_clientIntentsYAML(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "intentsDiff":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_intentsDiff(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
 			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
 		case "health":
 			field := field
@@ -6810,6 +9003,26 @@ func (ec *executionContext) _ServiceIntents(ctx context.Context, sel ast.Selecti
 	return out
 }
 
+var subscriptionImplementors = []string{"Subscription"}
+
+func (ec *executionContext) _Subscription(ctx context.Context, sel ast.SelectionSet) func(ctx context.Context) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, subscriptionImplementors)
+	ctx = graphql.WithFieldContext(ctx, &graphql.FieldContext{
+		Object: "Subscription",
+	})
+	if len(fields) != 1 {
+		ec.Errorf(ctx, "must subscribe to exactly one stream")
+		return nil
+	}
+
+	switch fields[0].Name {
+	case "intentDiscovered":
+		return ec._Subscription_intentDiscovered(ctx, fields[0])
+	default:
+		panic("unknown field " + strconv.Quote(fields[0].Name))
+	}
+}
+
 var tCPDestResolveBugfixDataImplementors = []string{"TCPDestResolveBugfixData"}
 
 func (ec *executionContext) _TCPDestResolveBugfixData(ctx context.Context, sel ast.SelectionSet, obj *model.TCPDestResolveBugfixData) graphql.Marshaler {
@@ -7249,6 +9462,59 @@ func (ec *executionContext) unmarshalNCaptureTCPResults2githubᚗcomᚋotterize
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
+func (ec *executionContext) unmarshalNCaptureTLSResults2githubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐCaptureTLSResults(ctx context.Context, v interface{}) (model.CaptureTLSResults, error) {
+	res, err := ec.unmarshalInputCaptureTLSResults(ctx, v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNClientIntentsDiff2githubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐClientIntentsDiff(ctx context.Context, sel ast.SelectionSet, v model.ClientIntentsDiff) graphql.Marshaler {
+	return ec._ClientIntentsDiff(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNClientIntentsDiff2ᚕgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐClientIntentsDiffᚄ(ctx context.Context, sel ast.SelectionSet, v []model.ClientIntentsDiff) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNClientIntentsDiff2githubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐClientIntentsDiff(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
 func (ec *executionContext) unmarshalNDestination2githubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐDestination(ctx context.Context, v interface{}) (model.Destination, error) {
 	res, err := ec.unmarshalInputDestination(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -7271,6 +9537,20 @@ func (ec *executionContext) unmarshalNDestination2ᚕgithubᚗcomᚋotterizeᚋn
 	return res, nil
 }
 
+func (ec *executionContext) marshalNDiscoveredIntent2githubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐDiscoveredIntent(ctx context.Context, sel ast.SelectionSet, v model.DiscoveredIntent) graphql.Marshaler {
+	return ec._DiscoveredIntent(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNDiscoveredIntent2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐDiscoveredIntent(ctx context.Context, sel ast.SelectionSet, v *model.DiscoveredIntent) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._DiscoveredIntent(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalNGCPOperation2githubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐGCPOperation(ctx context.Context, v interface{}) (model.GCPOperation, error) {
 	res, err := ec.unmarshalInputGCPOperation(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -7293,6 +9573,33 @@ func (ec *executionContext) unmarshalNGCPOperation2ᚕgithubᚗcomᚋotterizeᚋ
 	return res, nil
 }
 
+func (ec *executionContext) unmarshalNHTTPRequestResult2githubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐHTTPRequestResult(ctx context.Context, v interface{}) (model.HTTPRequestResult, error) {
+	res, err := ec.unmarshalInputHTTPRequestResult(ctx, v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) unmarshalNHTTPRequestResult2ᚕgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐHTTPRequestResultᚄ(ctx context.Context, v interface{}) ([]model.HTTPRequestResult, error) {
+	var vSlice []interface{}
+	if v != nil {
+		vSlice = graphql.CoerceList(v)
+	}
+	var err error
+	res := make([]model.HTTPRequestResult, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNHTTPRequestResult2githubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐHTTPRequestResult(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) unmarshalNHTTPRequestResults2githubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐHTTPRequestResults(ctx context.Context, v interface{}) (model.HTTPRequestResults, error) {
+	res, err := ec.unmarshalInputHTTPRequestResults(ctx, v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
 func (ec *executionContext) unmarshalNHttpMethod2githubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐHTTPMethod(ctx context.Context, v interface{}) (model.HTTPMethod, error) {
 	var res model.HTTPMethod
 	err := res.UnmarshalGQL(v)
@@ -7499,6 +9806,33 @@ func (ec *executionContext) marshalNKafkaOperation2githubᚗcomᚋotterizeᚋnet
 	return v
 }
 
+func (ec *executionContext) unmarshalNLinkerdConnection2githubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐLinkerdConnection(ctx context.Context, v interface{}) (model.LinkerdConnection, error) {
+	res, err := ec.unmarshalInputLinkerdConnection(ctx, v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) unmarshalNLinkerdConnection2ᚕgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐLinkerdConnectionᚄ(ctx context.Context, v interface{}) ([]model.LinkerdConnection, error) {
+	var vSlice []interface{}
+	if v != nil {
+		vSlice = graphql.CoerceList(v)
+	}
+	var err error
+	res := make([]model.LinkerdConnection, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNLinkerdConnection2githubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐLinkerdConnection(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) unmarshalNLinkerdConnectionResults2githubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐLinkerdConnectionResults(ctx context.Context, v interface{}) (model.LinkerdConnectionResults, error) {
+	res, err := ec.unmarshalInputLinkerdConnectionResults(ctx, v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
 func (ec *executionContext) marshalNOtterizeServiceIdentity2githubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐOtterizeServiceIdentity(ctx context.Context, sel ast.SelectionSet, v model.OtterizeServiceIdentity) graphql.Marshaler {
 	return ec._OtterizeServiceIdentity(ctx, sel, &v)
 }
@@ -8004,6 +10338,13 @@ func (ec *executionContext) marshalOBoolean2ᚖbool(ctx context.Context, sel ast
 	return res
 }
 
+func (ec *executionContext) marshalOExternalTrafficIntent2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐExternalTrafficIntent(ctx context.Context, sel ast.SelectionSet, v *model.ExternalTrafficIntent) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._ExternalTrafficIntent(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalOGroupVersionKind2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐGroupVersionKind(ctx context.Context, sel ast.SelectionSet, v *model.GroupVersionKind) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -8132,6 +10473,13 @@ func (ec *executionContext) marshalOIdentityResolutionData2ᚖgithubᚗcomᚋott
 	return ec._IdentityResolutionData(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalOIncomingTrafficIntent2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐIncomingTrafficIntent(ctx context.Context, sel ast.SelectionSet, v *model.IncomingTrafficIntent) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._IncomingTrafficIntent(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalOInt2ᚕint64ᚄ(ctx context.Context, v interface{}) ([]int64, error) {
 	if v == nil {
 		return nil, nil
@@ -8186,6 +10534,13 @@ func (ec *executionContext) marshalOInt2ᚖint64(ctx context.Context, sel ast.Se
 	return res
 }
 
+func (ec *executionContext) marshalOIntent2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐIntent(ctx context.Context, sel ast.SelectionSet, v *model.Intent) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._Intent(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalOIntentType2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐIntentType(ctx context.Context, v interface{}) (*model.IntentType, error) {
 	if v == nil {
 		return nil, nil
@@ -8316,6 +10671,38 @@ func (ec *executionContext) marshalOKafkaOperation2ᚕgithubᚗcomᚋotterizeᚋ
 	return ret
 }
 
+func (ec *executionContext) unmarshalOKafkaPatternType2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐKafkaPatternType(ctx context.Context, v interface{}) (*model.KafkaPatternType, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var res = new(model.KafkaPatternType)
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOKafkaPatternType2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐKafkaPatternType(ctx context.Context, sel ast.SelectionSet, v *model.KafkaPatternType) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return v
+}
+
+func (ec *executionContext) unmarshalOKafkaResourceType2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐKafkaResourceType(ctx context.Context, v interface{}) (*model.KafkaResourceType, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var res = new(model.KafkaResourceType)
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOKafkaResourceType2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐKafkaResourceType(ctx context.Context, sel ast.SelectionSet, v *model.KafkaResourceType) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return v
+}
+
 func (ec *executionContext) unmarshalONamespacedName2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐNamespacedName(ctx context.Context, v interface{}) (*model.NamespacedName, error) {
 	if v == nil {
 		return nil, nil
@@ -8440,6 +10827,38 @@ func (ec *executionContext) marshalOTCPDestResolveBugfixData2ᚖgithubᚗcomᚋo
 	return ec._TCPDestResolveBugfixData(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalOTime2ᚖtimeᚐTime(ctx context.Context, v interface{}) (*time.Time, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := graphql.UnmarshalTime(v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOTime2ᚖtimeᚐTime(ctx context.Context, sel ast.SelectionSet, v *time.Time) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	res := graphql.MarshalTime(*v)
+	return res
+}
+
+func (ec *executionContext) unmarshalOTransportProtocol2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐTransportProtocol(ctx context.Context, v interface{}) (*model.TransportProtocol, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var res = new(model.TransportProtocol)
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOTransportProtocol2ᚖgithubᚗcomᚋotterizeᚋnetworkᚑmapperᚋsrcᚋmapperᚋpkgᚋgraphᚋmodelᚐTransportProtocol(ctx context.Context, sel ast.SelectionSet, v *model.TransportProtocol) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return v
+}
+
 func (ec *executionContext) marshalO__EnumValue2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐEnumValueᚄ(ctx context.Context, sel ast.SelectionSet, v []introspection.EnumValue) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
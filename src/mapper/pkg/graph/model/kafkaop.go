@@ -21,7 +21,9 @@ var (
 )
 
 func KafkaOpFromText(text string) (KafkaOperation, error) {
-	normalized := strings.ToLower(text)
+	// The ZooKeeper-based AclAuthorizer logs operations as e.g. "IdempotentWrite", and KRaft's StandardAuthorizer as
+	// "IDEMPOTENT_WRITE".
+	normalized := strings.ReplaceAll(strings.ToLower(text), "_", "")
 
 	apiOp, ok := kafkaOperationToAclOperation[normalized]
 	if !ok {
@@ -29,3 +31,8 @@ func KafkaOpFromText(text string) (KafkaOperation, error) {
 	}
 	return apiOp, nil
 }
+
+// IsTopic returns whether the config refers to a topic, rather than to another type of Kafka resource.
+func (c KafkaConfig) IsTopic() bool {
+	return c.ResourceType == nil || *c.ResourceType == KafkaResourceTypeTopic
+}
@@ -33,13 +33,42 @@ type CaptureTCPResults struct {
 	Results []RecordedDestinationsForSrc `json:"results"`
 }
 
+type CaptureTLSResults struct {
+	Results []RecordedDestinationsForSrc `json:"results"`
+}
+
+type ClientIntentsDiff struct {
+	Client *OtterizeServiceIdentity `json:"client"`
+	// Servers the client was seen calling, with no matching target in its applied ClientIntents.
+	Missing []OtterizeServiceIdentity `json:"missing"`
+	// Targets in the client's applied ClientIntents that the client was not seen calling.
+	Unused []OtterizeServiceIdentity `json:"unused"`
+	// Servers the client was seen calling, that have a matching target in its applied ClientIntents.
+	Matching []OtterizeServiceIdentity `json:"matching"`
+}
+
 type Destination struct {
-	Destination     string    `json:"destination"`
-	DestinationIP   *string   `json:"destinationIP,omitempty"`
-	DestinationPort *int64    `json:"destinationPort,omitempty"`
-	TTL             *int64    `json:"TTL,omitempty"`
-	LastSeen        time.Time `json:"lastSeen"`
-	SrcPorts        []int64   `json:"srcPorts,omitempty"`
+	Destination     string             `json:"destination"`
+	DestinationIP   *string            `json:"destinationIP,omitempty"`
+	DestinationPort *int64             `json:"destinationPort,omitempty"`
+	TTL             *int64             `json:"TTL,omitempty"`
+	LastSeen        time.Time          `json:"lastSeen"`
+	SrcPorts        []int64            `json:"srcPorts,omitempty"`
+	Protocol        *TransportProtocol `json:"protocol,omitempty"`
+}
+
+// A newly discovered intent. Exactly one of intent, externalTrafficIntent and incomingTrafficIntent is set.
+type DiscoveredIntent struct {
+	DiscoveredAt          time.Time              `json:"discoveredAt"`
+	Intent                *Intent                `json:"intent,omitempty"`
+	ExternalTrafficIntent *ExternalTrafficIntent `json:"externalTrafficIntent,omitempty"`
+	IncomingTrafficIntent *IncomingTrafficIntent `json:"incomingTrafficIntent,omitempty"`
+}
+
+type ExternalTrafficIntent struct {
+	Client  *OtterizeServiceIdentity `json:"client"`
+	DNSName string                   `json:"dnsName"`
+	Ips     []string                 `json:"ips"`
 }
 
 type GCPOperation struct {
@@ -55,6 +84,21 @@ type GroupVersionKind struct {
 	Kind    string  `json:"kind"`
 }
 
+// A plaintext HTTP request, sniffed from the traffic between a client and a server.
+type HTTPRequestResult struct {
+	SrcIP   string     `json:"srcIp"`
+	DstIP   string     `json:"dstIp"`
+	DstPort int64      `json:"dstPort"`
+	Method  HTTPMethod `json:"method"`
+	// The path of the request, without its query string.
+	Path     string    `json:"path"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+type HTTPRequestResults struct {
+	Results []HTTPRequestResult `json:"results"`
+}
+
 type HTTPResource struct {
 	Path    string       `json:"path"`
 	Methods []HTTPMethod `json:"methods,omitempty"`
@@ -73,6 +117,11 @@ type IdentityResolutionData struct {
 	TCPDestResolveFixData *TCPDestResolveBugfixData `json:"tcpDestResolveFixData,omitempty"`
 }
 
+type IncomingTrafficIntent struct {
+	Server *OtterizeServiceIdentity `json:"server"`
+	IP     string                   `json:"ip"`
+}
+
 type Intent struct {
 	Client         *OtterizeServiceIdentity `json:"client"`
 	Server         *OtterizeServiceIdentity `json:"server"`
@@ -81,6 +130,7 @@ type Intent struct {
 	KafkaTopics    []KafkaConfig            `json:"kafkaTopics,omitempty"`
 	HTTPResources  []HTTPResource           `json:"httpResources,omitempty"`
 	AwsActions     []string                 `json:"awsActions,omitempty"`
+	Protocol       *TransportProtocol       `json:"protocol,omitempty"`
 }
 
 type IstioConnection struct {
@@ -99,23 +149,53 @@ type IstioConnectionResults struct {
 }
 
 type KafkaConfig struct {
+	// The name of the resource. Prefixed resource patterns are named after their prefix, followed by '*'.
 	Name       string           `json:"name"`
 	Operations []KafkaOperation `json:"operations,omitempty"`
+	// The type of the resource, which is a topic when not set.
+	ResourceType *KafkaResourceType `json:"resourceType,omitempty"`
 }
 
 type KafkaMapperResult struct {
-	SrcIP           string    `json:"srcIp"`
-	ServerPodName   string    `json:"serverPodName"`
-	ServerNamespace string    `json:"serverNamespace"`
-	Topic           string    `json:"topic"`
-	Operation       string    `json:"operation"`
-	LastSeen        time.Time `json:"lastSeen"`
+	SrcIP string `json:"srcIp"`
+	// The server pod. When not set, the server is resolved from serverIp.
+	ServerPodName   *string `json:"serverPodName,omitempty"`
+	ServerNamespace *string `json:"serverNamespace,omitempty"`
+	// The IP of the server, for results that were not read from the server itself, such as results sniffed from the
+	// Kafka protocol.
+	ServerIP *string `json:"serverIp,omitempty"`
+	// The name of the resource, which is a topic unless resourceType is set.
+	Topic        string             `json:"topic"`
+	Operation    string             `json:"operation"`
+	LastSeen     time.Time          `json:"lastSeen"`
+	Principal    *string            `json:"principal,omitempty"`
+	ResourceType *KafkaResourceType `json:"resourceType,omitempty"`
+	PatternType  *KafkaPatternType  `json:"patternType,omitempty"`
+	// The ServiceAccount the principal was mapped to. When its pods belong to a single workload, that workload is used as
+	// the client instead of resolving srcIp.
+	SrcServiceAccount *NamespacedName `json:"srcServiceAccount,omitempty"`
 }
 
 type KafkaMapperResults struct {
 	Results []KafkaMapperResult `json:"results"`
 }
 
+// HTTP traffic from a pod meshed by Linkerd, read from the metrics of the pod's linkerd-proxy.
+type LinkerdConnection struct {
+	SrcPodName   string `json:"srcPodName"`
+	SrcNamespace string `json:"srcNamespace"`
+	// The authority the client addressed, such as server.namespace.svc.cluster.local:8080.
+	Authority string `json:"authority"`
+	// The path of the ServiceProfile route the requests matched, if the route is named after its method and path.
+	Path     *string      `json:"path,omitempty"`
+	Methods  []HTTPMethod `json:"methods"`
+	LastSeen time.Time    `json:"lastSeen"`
+}
+
+type LinkerdConnectionResults struct {
+	Results []LinkerdConnection `json:"results"`
+}
+
 type Mutation struct {
 }
 
@@ -145,9 +225,12 @@ type Query struct {
 }
 
 type RecordedDestinationsForSrc struct {
-	SrcIP        string        `json:"srcIp"`
-	SrcHostname  string        `json:"srcHostname"`
-	Destinations []Destination `json:"destinations"`
+	SrcIP       string `json:"srcIp"`
+	SrcHostname string `json:"srcHostname"`
+	// The ID of the container the requests were made from, when traced at the socket. It identifies the source pod even
+	// if srcIp was since reused by another pod.
+	SrcContainerID *string       `json:"srcContainerId,omitempty"`
+	Destinations   []Destination `json:"destinations"`
 }
 
 type ServerFilter struct {
@@ -164,6 +247,9 @@ type SocketScanResults struct {
 	Results []RecordedDestinationsForSrc `json:"results"`
 }
 
+type Subscription struct {
+}
+
 type TCPDestResolveBugfixData struct {
 	IsSrcControlPlane bool `json:"isSrcControlPlane"`
 	ResolvedUsingIP   bool `json:"resolvedUsingIp"`
@@ -340,3 +426,130 @@ func (e *KafkaOperation) UnmarshalGQL(v interface{}) error {
 func (e KafkaOperation) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+type KafkaPatternType string
+
+const (
+	KafkaPatternTypeLiteral  KafkaPatternType = "LITERAL"
+	KafkaPatternTypePrefixed KafkaPatternType = "PREFIXED"
+)
+
+var AllKafkaPatternType = []KafkaPatternType{
+	KafkaPatternTypeLiteral,
+	KafkaPatternTypePrefixed,
+}
+
+func (e KafkaPatternType) IsValid() bool {
+	switch e {
+	case KafkaPatternTypeLiteral, KafkaPatternTypePrefixed:
+		return true
+	}
+	return false
+}
+
+func (e KafkaPatternType) String() string {
+	return string(e)
+}
+
+func (e *KafkaPatternType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = KafkaPatternType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid KafkaPatternType", str)
+	}
+	return nil
+}
+
+func (e KafkaPatternType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type KafkaResourceType string
+
+const (
+	KafkaResourceTypeTopic           KafkaResourceType = "TOPIC"
+	KafkaResourceTypeGroup           KafkaResourceType = "GROUP"
+	KafkaResourceTypeTransactionalID KafkaResourceType = "TRANSACTIONAL_ID"
+	KafkaResourceTypeCluster         KafkaResourceType = "CLUSTER"
+)
+
+var AllKafkaResourceType = []KafkaResourceType{
+	KafkaResourceTypeTopic,
+	KafkaResourceTypeGroup,
+	KafkaResourceTypeTransactionalID,
+	KafkaResourceTypeCluster,
+}
+
+func (e KafkaResourceType) IsValid() bool {
+	switch e {
+	case KafkaResourceTypeTopic, KafkaResourceTypeGroup, KafkaResourceTypeTransactionalID, KafkaResourceTypeCluster:
+		return true
+	}
+	return false
+}
+
+func (e KafkaResourceType) String() string {
+	return string(e)
+}
+
+func (e *KafkaResourceType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = KafkaResourceType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid KafkaResourceType", str)
+	}
+	return nil
+}
+
+func (e KafkaResourceType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type TransportProtocol string
+
+const (
+	TransportProtocolTCP TransportProtocol = "TCP"
+	TransportProtocolUDP TransportProtocol = "UDP"
+)
+
+var AllTransportProtocol = []TransportProtocol{
+	TransportProtocolTCP,
+	TransportProtocolUDP,
+}
+
+func (e TransportProtocol) IsValid() bool {
+	switch e {
+	case TransportProtocolTCP, TransportProtocolUDP:
+		return true
+	}
+	return false
+}
+
+func (e TransportProtocol) String() string {
+	return string(e)
+}
+
+func (e *TransportProtocol) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TransportProtocol(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TransportProtocol", str)
+	}
+	return nil
+}
+
+func (e TransportProtocol) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
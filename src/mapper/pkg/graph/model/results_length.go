@@ -12,10 +12,18 @@ func (c SocketScanResults) Length() int {
 	return len(c.Results)
 }
 
+func (c HTTPRequestResults) Length() int {
+	return len(c.Results)
+}
+
 func (c IstioConnectionResults) Length() int {
 	return len(c.Results)
 }
 
+func (c LinkerdConnectionResults) Length() int {
+	return len(c.Results)
+}
+
 type AWSOperationResults []AWSOperation
 
 func (c AWSOperationResults) Length() int {
@@ -38,6 +46,10 @@ func (c CaptureTCPResults) Length() int {
 	return len(c.Results)
 }
 
+func (c CaptureTLSResults) Length() int {
+	return len(c.Results)
+}
+
 func (c TrafficLevelResults) Length() int {
 	return len(c.Results)
 }
@@ -2,6 +2,7 @@ package incomingtrafficholder
 
 import (
 	"context"
+	"github.com/hashicorp/golang-lru/v2/expirable"
 	"github.com/otterize/network-mapper/src/mapper/pkg/cloudclient"
 	"github.com/otterize/network-mapper/src/mapper/pkg/concurrentconnectioncounter"
 	"github.com/otterize/network-mapper/src/mapper/pkg/config"
@@ -14,6 +15,10 @@ import (
 
 type IP string
 
+// seenIntentsCacheSize bounds the number of internet clients remembered for new intent notifications, as they are keyed
+// by source IP and would otherwise grow without bound when the intents TTL is disabled.
+const seenIntentsCacheSize = 10000
+
 type IncomingTrafficIntent struct {
 	Server   model.OtterizeServiceIdentity `json:"client"`
 	LastSeen time.Time
@@ -34,9 +39,12 @@ type IncomingTrafficKey struct {
 }
 
 type IncomingTrafficIntentsHolder struct {
-	intents               map[IncomingTrafficKey]TimestampedIncomingTrafficIntent
+	intents map[IncomingTrafficKey]TimestampedIncomingTrafficIntent
+	// seenIntents holds the last time each recently seen intent was seen, regardless of whether it was already uploaded.
+	seenIntents           *expirable.LRU[IncomingTrafficKey, time.Time]
 	lock                  sync.Mutex
 	callbacks             []IncomingTrafficCallbackFunc
+	newIntentCallbacks    []func(TimestampedIncomingTrafficIntent)
 	connectionCountDiffer *concurrentconnectioncounter.ConnectionCountDiffer[IncomingTrafficKey, *concurrentconnectioncounter.CountableIncomingInternetTrafficIntent]
 }
 
@@ -46,6 +54,7 @@ type IntentsConnectionCounter map[IncomingTrafficKey]*concurrentconnectioncounte
 func NewIncomingTrafficIntentsHolder() *IncomingTrafficIntentsHolder {
 	return &IncomingTrafficIntentsHolder{
 		intents:               make(map[IncomingTrafficKey]TimestampedIncomingTrafficIntent),
+		seenIntents:           expirable.NewLRU[IncomingTrafficKey, time.Time](seenIntentsCacheSize, nil, 0),
 		connectionCountDiffer: concurrentconnectioncounter.NewConnectionCountDiffer[IncomingTrafficKey, *concurrentconnectioncounter.CountableIncomingInternetTrafficIntent](),
 	}
 }
@@ -54,6 +63,12 @@ func (h *IncomingTrafficIntentsHolder) RegisterNotifyIntents(callback IncomingTr
 	h.callbacks = append(h.callbacks, callback)
 }
 
+// RegisterNotifyNewIntent registers a callback that is called whenever an intent is added for the first time. Callbacks
+// are called while the holder is locked, and must not block.
+func (h *IncomingTrafficIntentsHolder) RegisterNotifyNewIntent(callback func(TimestampedIncomingTrafficIntent)) {
+	h.newIntentCallbacks = append(h.newIntentCallbacks, callback)
+}
+
 func (h *IncomingTrafficIntentsHolder) PeriodicIntentsUpload(ctx context.Context, interval time.Duration) {
 	logrus.Info("Starting periodic external traffic intents upload")
 
@@ -117,18 +132,50 @@ func (h *IncomingTrafficIntentsHolder) AddIntent(intent IncomingTrafficIntent) {
 		SourcePorts: intent.SrcPorts,
 	})
 
+	lastSeen, seen := h.seenIntents.Get(key)
+	if !seen || intent.LastSeen.After(lastSeen) {
+		h.seenIntents.Add(key, intent.LastSeen)
+	}
+
 	mergedIntent, ok := h.intents[key]
 	if !ok {
-		h.intents[key] = TimestampedIncomingTrafficIntent{
+		mergedIntent = TimestampedIncomingTrafficIntent{
 			Timestamp: intent.LastSeen,
 			Intent:    intent,
 		}
-		return
+	} else if intent.LastSeen.After(mergedIntent.Timestamp) {
+		mergedIntent.Timestamp = intent.LastSeen
 	}
+	h.intents[key] = mergedIntent
 
-	if intent.LastSeen.After(mergedIntent.Timestamp) {
-		mergedIntent.Timestamp = intent.LastSeen
+	if !seen {
+		for _, callback := range h.newIntentCallbacks {
+			callback(mergedIntent)
+		}
 	}
+}
 
-	h.intents[key] = mergedIntent
+// EvictIntentsNotSeenSince forgets intents last seen before cutoff, so that they are notified as new when seen again,
+// returning the number of intents forgotten.
+func (h *IncomingTrafficIntentsHolder) EvictIntentsNotSeenSince(cutoff time.Time) int {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	evicted := 0
+	for _, key := range h.seenIntents.Keys() {
+		if lastSeen, ok := h.seenIntents.Peek(key); ok && lastSeen.Before(cutoff) {
+			h.seenIntents.Remove(key)
+			evicted++
+		}
+	}
+	return evicted
+}
+
+func (h *IncomingTrafficIntentsHolder) Reset() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.intents = make(map[IncomingTrafficKey]TimestampedIncomingTrafficIntent)
+	h.seenIntents.Purge()
+	h.connectionCountDiffer.Reset()
 }
@@ -2,6 +2,7 @@ package incomingtrafficholder
 
 import (
 	"context"
+	"fmt"
 	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/suite"
@@ -159,6 +160,51 @@ func (s *IncomingTrafficHolderSuite) TestReportOnlyLatest() {
 	s.Require().Equal(timestamp3, uploaded[0].Timestamp)
 }
 
+func (s *IncomingTrafficHolderSuite) TestNotifyNewIntentOnlyOnce() {
+	timestamp := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	incoming := IncomingTrafficIntent{
+		Server:   model.OtterizeServiceIdentity{Name: testServerName, Namespace: testServerNamespace},
+		LastSeen: timestamp,
+		IP:       ipAddressA,
+	}
+
+	notified := make([]TimestampedIncomingTrafficIntent, 0)
+	s.holder.RegisterNotifyNewIntent(func(intent TimestampedIncomingTrafficIntent) {
+		notified = append(notified, intent)
+	})
+
+	s.holder.AddIntent(incoming)
+	s.holder.GetNewIntentsSinceLastGet()
+	// An intent seen again after it was uploaded is not new
+	incoming.LastSeen = timestamp.Add(time.Hour)
+	s.holder.AddIntent(incoming)
+	s.Require().Len(notified, 1)
+
+	// An intent evicted once it was not seen within the TTL is new when seen again
+	s.Require().Equal(0, s.holder.EvictIntentsNotSeenSince(timestamp.Add(time.Minute)))
+	s.Require().Equal(1, s.holder.EvictIntentsNotSeenSince(timestamp.Add(2*time.Hour)))
+	incoming.LastSeen = timestamp.Add(3 * time.Hour)
+	s.holder.AddIntent(incoming)
+	s.Require().Len(notified, 2)
+
+	// Resetting the holder forgets which intents were seen
+	s.holder.Reset()
+	s.holder.AddIntent(incoming)
+	s.Require().Len(notified, 3)
+}
+
+func (s *IncomingTrafficHolderSuite) TestSeenIntentsBounded() {
+	timestamp := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < seenIntentsCacheSize+10; i++ {
+		s.holder.AddIntent(IncomingTrafficIntent{
+			Server:   model.OtterizeServiceIdentity{Name: testServerName, Namespace: testServerNamespace},
+			LastSeen: timestamp,
+			IP:       fmt.Sprintf("1.1.%d.%d", i/256, i%256),
+		})
+	}
+	s.Require().Equal(seenIntentsCacheSize, s.holder.seenIntents.Len())
+}
+
 func TestIncomingTrafficHolderSuite(t *testing.T) {
 	suite.Run(t, new(IncomingTrafficHolderSuite))
 }
@@ -0,0 +1,60 @@
+package incomingtrafficholder
+
+import (
+	"encoding/json"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"time"
+)
+
+// Snapshot returns the intents not uploaded yet that were last seen since cutoff, serialized. Older intents are left
+// for the TTL eviction.
+func (h *IncomingTrafficIntentsHolder) Snapshot(cutoff time.Time) ([]byte, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	intents := make([]TimestampedIncomingTrafficIntent, 0, len(h.intents))
+	for _, intent := range h.intents {
+		if intent.Timestamp.Before(cutoff) {
+			continue
+		}
+		intent.ConnectionsCount = nil
+		intents = append(intents, intent)
+	}
+
+	data, err := json.Marshal(intents)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	return data, nil
+}
+
+// Restore adds intents from a snapshot created by Snapshot to the holder, skipping those last seen before cutoff.
+// Intents already in the holder take precedence over restored ones.
+func (h *IncomingTrafficIntentsHolder) Restore(data []byte, cutoff time.Time) error {
+	var intents []TimestampedIncomingTrafficIntent
+	if err := json.Unmarshal(data, &intents); err != nil {
+		return errors.Wrap(err)
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for _, intent := range intents {
+		if intent.Timestamp.Before(cutoff) {
+			continue
+		}
+		key := IncomingTrafficKey{
+			ServerName:      intent.Intent.Server.Name,
+			ServerNamespace: intent.Intent.Server.Namespace,
+			IP:              intent.Intent.IP,
+		}
+		if _, found := h.intents[key]; found {
+			continue
+		}
+		h.intents[key] = intent
+		if !h.seenIntents.Contains(key) {
+			h.seenIntents.Add(key, intent.Timestamp)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package intentsbroadcaster
+
+import (
+	"context"
+	"github.com/amit7itz/goset"
+	"github.com/otterize/network-mapper/src/mapper/pkg/externaltrafficholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
+	"github.com/otterize/network-mapper/src/mapper/pkg/incomingtrafficholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/intentsstore"
+	"github.com/otterize/network-mapper/src/mapper/pkg/prometheus"
+	"github.com/samber/lo"
+	"sync"
+)
+
+const subscriberBufferSize = 100
+
+type subscriber struct {
+	namespaces *goset.Set[string]
+	ch         chan *model.DiscoveredIntent
+}
+
+// Broadcaster fans out newly discovered intents to subscribers. Publishing never blocks, so that a slow subscriber
+// cannot stall intent collection: intents that do not fit in a subscriber's buffer are dropped for that subscriber.
+type Broadcaster struct {
+	lock        sync.Mutex
+	subscribers map[*subscriber]struct{}
+}
+
+func NewBroadcaster() *Broadcaster {
+	return &Broadcaster{
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// Subscribe returns a channel of discovered intents, filtered by namespaces if it is not empty. The channel is closed
+// once ctx is done.
+func (b *Broadcaster) Subscribe(ctx context.Context, namespaces []string) <-chan *model.DiscoveredIntent {
+	sub := &subscriber{
+		namespaces: goset.FromSlice(namespaces),
+		ch:         make(chan *model.DiscoveredIntent, subscriberBufferSize),
+	}
+
+	b.lock.Lock()
+	b.subscribers[sub] = struct{}{}
+	b.lock.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.lock.Lock()
+		defer b.lock.Unlock()
+		delete(b.subscribers, sub)
+		close(sub.ch)
+	}()
+
+	return sub.ch
+}
+
+func (b *Broadcaster) publish(namespace string, discoveredIntent *model.DiscoveredIntent) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for sub := range b.subscribers {
+		if !sub.namespaces.IsEmpty() && !sub.namespaces.Contains(namespace) {
+			continue
+		}
+		select {
+		case sub.ch <- discoveredIntent:
+		default:
+			prometheus.IncrementSubscriptionDrops(1)
+		}
+	}
+}
+
+func (b *Broadcaster) NotifyNewIntent(intent intentsstore.TimestampedIntent) {
+	b.publish(intent.Intent.Client.Namespace, &model.DiscoveredIntent{
+		DiscoveredAt: intent.Timestamp,
+		Intent:       lo.ToPtr(intent.Intent),
+	})
+}
+
+func (b *Broadcaster) NotifyNewExternalTrafficIntent(intent externaltrafficholder.TimestampedExternalTrafficIntent) {
+	b.publish(intent.Intent.Client.Namespace, &model.DiscoveredIntent{
+		DiscoveredAt: intent.Timestamp,
+		ExternalTrafficIntent: &model.ExternalTrafficIntent{
+			Client:  lo.ToPtr(intent.Intent.Client),
+			DNSName: intent.Intent.DNSName,
+			Ips: lo.MapToSlice(intent.Intent.IPs, func(ip externaltrafficholder.IP, _ struct{}) string {
+				return string(ip)
+			}),
+		},
+	})
+}
+
+func (b *Broadcaster) NotifyNewIncomingTrafficIntent(intent incomingtrafficholder.TimestampedIncomingTrafficIntent) {
+	b.publish(intent.Intent.Server.Namespace, &model.DiscoveredIntent{
+		DiscoveredAt: intent.Timestamp,
+		IncomingTrafficIntent: &model.IncomingTrafficIntent{
+			Server: lo.ToPtr(intent.Intent.Server),
+			IP:     intent.Intent.IP,
+		},
+	})
+}
@@ -0,0 +1,133 @@
+package intentsbroadcaster
+
+import (
+	"context"
+	"github.com/otterize/network-mapper/src/mapper/pkg/externaltrafficholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
+	"github.com/otterize/network-mapper/src/mapper/pkg/incomingtrafficholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/intentsstore"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+const (
+	testNamespace      = "test-namespace"
+	otherTestNamespace = "other-test-namespace"
+	testTimeout        = time.Second
+)
+
+type BroadcasterTestSuite struct {
+	suite.Suite
+	broadcaster           *Broadcaster
+	intentsHolder         *intentsstore.IntentsHolder
+	externalTrafficHolder *externaltrafficholder.ExternalTrafficIntentsHolder
+	incomingTrafficHolder *incomingtrafficholder.IncomingTrafficIntentsHolder
+}
+
+func (s *BroadcasterTestSuite) SetupTest() {
+	s.broadcaster = NewBroadcaster()
+	s.intentsHolder = intentsstore.NewIntentsHolder()
+	s.intentsHolder.RegisterNotifyNewIntent(s.broadcaster.NotifyNewIntent)
+	s.externalTrafficHolder = externaltrafficholder.NewExternalTrafficIntentsHolder()
+	s.externalTrafficHolder.RegisterNotifyNewIntent(s.broadcaster.NotifyNewExternalTrafficIntent)
+	s.incomingTrafficHolder = incomingtrafficholder.NewIncomingTrafficIntentsHolder()
+	s.incomingTrafficHolder.RegisterNotifyNewIntent(s.broadcaster.NotifyNewIncomingTrafficIntent)
+}
+
+func (s *BroadcasterTestSuite) addIntent(namespace string) {
+	s.intentsHolder.AddIntent(time.Now(), model.Intent{
+		Client: &model.OtterizeServiceIdentity{Name: "client", Namespace: namespace},
+		Server: &model.OtterizeServiceIdentity{Name: "server", Namespace: namespace},
+	}, nil)
+}
+
+func (s *BroadcasterTestSuite) receive(ch <-chan *model.DiscoveredIntent) *model.DiscoveredIntent {
+	select {
+	case discovered := <-ch:
+		return discovered
+	case <-time.After(testTimeout):
+		s.Require().Fail("timed out waiting for discovered intent")
+		return nil
+	}
+}
+
+func (s *BroadcasterTestSuite) requireNothingReceived(ch <-chan *model.DiscoveredIntent) {
+	select {
+	case discovered := <-ch:
+		s.Require().Fail("unexpected discovered intent", "%+v", discovered)
+	default:
+	}
+}
+
+func (s *BroadcasterTestSuite) TestStreamsNewIntentsOnce() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := s.broadcaster.Subscribe(ctx, nil)
+
+	s.addIntent(testNamespace)
+	s.addIntent(testNamespace)
+
+	discovered := s.receive(ch)
+	s.Require().NotNil(discovered.Intent)
+	s.Require().Equal("client", discovered.Intent.Client.Name)
+	s.Require().Equal("server", discovered.Intent.Server.Name)
+	s.requireNothingReceived(ch)
+}
+
+func (s *BroadcasterTestSuite) TestStreamsExternalAndIncomingTraffic() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := s.broadcaster.Subscribe(ctx, nil)
+
+	s.externalTrafficHolder.AddIntent(externaltrafficholder.ExternalTrafficIntent{
+		Client:   model.OtterizeServiceIdentity{Name: "client", Namespace: testNamespace},
+		LastSeen: time.Now(),
+		DNSName:  "example.com",
+		IPs:      map[externaltrafficholder.IP]struct{}{"1.1.1.1": {}},
+	})
+	discovered := s.receive(ch)
+	s.Require().NotNil(discovered.ExternalTrafficIntent)
+	s.Require().Equal("example.com", discovered.ExternalTrafficIntent.DNSName)
+	s.Require().Equal([]string{"1.1.1.1"}, discovered.ExternalTrafficIntent.Ips)
+
+	s.incomingTrafficHolder.AddIntent(incomingtrafficholder.IncomingTrafficIntent{
+		Server:   model.OtterizeServiceIdentity{Name: "server", Namespace: testNamespace},
+		LastSeen: time.Now(),
+		IP:       "2.2.2.2",
+	})
+	discovered = s.receive(ch)
+	s.Require().NotNil(discovered.IncomingTrafficIntent)
+	s.Require().Equal("server", discovered.IncomingTrafficIntent.Server.Name)
+	s.Require().Equal("2.2.2.2", discovered.IncomingTrafficIntent.IP)
+}
+
+func (s *BroadcasterTestSuite) TestFiltersByNamespace() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := s.broadcaster.Subscribe(ctx, []string{testNamespace})
+
+	s.addIntent(otherTestNamespace)
+	s.addIntent(testNamespace)
+
+	discovered := s.receive(ch)
+	s.Require().Equal(testNamespace, discovered.Intent.Client.Namespace)
+	s.requireNothingReceived(ch)
+}
+
+func (s *BroadcasterTestSuite) TestChannelClosedWhenContextDone() {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := s.broadcaster.Subscribe(ctx, nil)
+	cancel()
+
+	select {
+	case _, ok := <-ch:
+		s.Require().False(ok)
+	case <-time.After(testTimeout):
+		s.Require().Fail("timed out waiting for channel to close")
+	}
+}
+
+func TestBroadcasterTestSuite(t *testing.T) {
+	suite.Run(t, new(BroadcasterTestSuite))
+}
@@ -0,0 +1,186 @@
+package intentsdiff
+
+import (
+	"context"
+	otterizev2alpha1 "github.com/otterize/intents-operator/src/operator/api/v2alpha1"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
+	"github.com/otterize/network-mapper/src/mapper/pkg/intentsstore"
+	"github.com/otterize/network-mapper/src/mapper/pkg/prometheus"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"slices"
+	"strings"
+	"time"
+)
+
+// declaredTarget is an in-cluster target of a ClientIntents, identified the same way discovered servers are.
+type declaredTarget struct {
+	server    types.NamespacedName
+	isService bool
+}
+
+func (t declaredTarget) matches(server model.OtterizeServiceIdentity) bool {
+	if server.Namespace != t.server.Namespace {
+		return false
+	}
+	if t.isService {
+		return server.KubernetesService != nil && *server.KubernetesService == t.server.Name
+	}
+	return server.Name == t.server.Name
+}
+
+func (t declaredTarget) asServiceIdentity() model.OtterizeServiceIdentity {
+	identity := model.OtterizeServiceIdentity{Name: t.server.Name, Namespace: t.server.Namespace}
+	if t.isService {
+		identity.KubernetesService = lo.ToPtr(t.server.Name)
+	}
+	return identity
+}
+
+type Differ struct {
+	client        client.Client
+	intentsHolder *intentsstore.IntentsHolder
+}
+
+func NewDiffer(k8sClient client.Client, intentsHolder *intentsstore.IntentsHolder) *Differ {
+	return &Differ{
+		client:        k8sClient,
+		intentsHolder: intentsHolder,
+	}
+}
+
+func (d *Differ) listClientIntents(ctx context.Context, namespaces []string) ([]otterizev2alpha1.ClientIntents, error) {
+	if len(namespaces) == 0 {
+		var clientIntentsList otterizev2alpha1.ClientIntentsList
+		if err := d.client.List(ctx, &clientIntentsList); err != nil {
+			return nil, errors.Wrap(err)
+		}
+		return clientIntentsList.Items, nil
+	}
+
+	result := make([]otterizev2alpha1.ClientIntents, 0)
+	for _, namespace := range lo.Uniq(namespaces) {
+		var clientIntentsList otterizev2alpha1.ClientIntentsList
+		if err := d.client.List(ctx, &clientIntentsList, client.InNamespace(namespace)); err != nil {
+			return nil, errors.Wrap(err)
+		}
+		result = append(result, clientIntentsList.Items...)
+	}
+	return result, nil
+}
+
+// Diff compares the discovered in-cluster intents of each client against the ClientIntents applied for it. If
+// namespaces is not empty, only clients in these namespaces are compared.
+func (d *Differ) Diff(ctx context.Context, namespaces []string) ([]model.ClientIntentsDiff, error) {
+	clientIntents, err := d.listClientIntents(ctx, namespaces)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	discoveredIntents, err := d.intentsHolder.GetIntents(namespaces, nil, nil, false, nil, nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+
+	clients := make(map[types.NamespacedName]model.OtterizeServiceIdentity)
+	declared := make(map[types.NamespacedName][]declaredTarget)
+	for _, ci := range clientIntents {
+		if ci.Spec == nil || ci.DeletionTimestamp != nil {
+			continue
+		}
+		clientName := types.NamespacedName{Namespace: ci.Namespace, Name: ci.GetWorkloadName()}
+		clients[clientName] = model.OtterizeServiceIdentity{Name: clientName.Name, Namespace: clientName.Namespace}
+		for _, target := range ci.GetTargetList() {
+			if !target.IsTargetInCluster() {
+				continue
+			}
+			declared[clientName] = append(declared[clientName], declaredTarget{
+				server: types.NamespacedName{
+					Namespace: target.GetTargetServerNamespace(ci.Namespace),
+					Name:      target.GetTargetServerName(),
+				},
+				isService: target.IsTargetServerKubernetesService(),
+			})
+		}
+	}
+
+	discovered := make(map[types.NamespacedName][]model.OtterizeServiceIdentity)
+	for _, intent := range discoveredIntents {
+		clientName := intent.Intent.Client.AsNamespacedName()
+		if _, ok := clients[clientName]; !ok {
+			clients[clientName] = *intent.Intent.Client
+		}
+		discovered[clientName] = append(discovered[clientName], *intent.Intent.Server)
+	}
+
+	result := make([]model.ClientIntentsDiff, 0, len(clients))
+	for clientName, clientIdentity := range clients {
+		diff := model.ClientIntentsDiff{
+			Client:   lo.ToPtr(clientIdentity),
+			Missing:  make([]model.OtterizeServiceIdentity, 0),
+			Unused:   make([]model.OtterizeServiceIdentity, 0),
+			Matching: make([]model.OtterizeServiceIdentity, 0),
+		}
+		usedTargets := make(map[declaredTarget]bool)
+		for _, server := range lo.UniqBy(discovered[clientName], model.OtterizeServiceIdentity.AsNamespacedName) {
+			target, found := lo.Find(declared[clientName], func(target declaredTarget) bool {
+				return target.matches(server)
+			})
+			if !found {
+				diff.Missing = append(diff.Missing, server)
+				continue
+			}
+			usedTargets[target] = true
+			diff.Matching = append(diff.Matching, server)
+		}
+		for _, target := range lo.Uniq(declared[clientName]) {
+			if !usedTargets[target] {
+				diff.Unused = append(diff.Unused, target.asServiceIdentity())
+			}
+		}
+
+		sortIdentities(diff.Missing)
+		sortIdentities(diff.Unused)
+		sortIdentities(diff.Matching)
+		result = append(result, diff)
+	}
+
+	slices.SortFunc(result, func(a, b model.ClientIntentsDiff) int {
+		return strings.Compare(a.Client.AsNamespacedName().String(), b.Client.AsNamespacedName().String())
+	})
+	return result, nil
+}
+
+func sortIdentities(identities []model.OtterizeServiceIdentity) {
+	slices.SortFunc(identities, func(a, b model.OtterizeServiceIdentity) int {
+		return strings.Compare(a.AsNamespacedName().String(), b.AsNamespacedName().String())
+	})
+}
+
+func (d *Differ) updateMetrics(ctx context.Context) error {
+	diffs, err := d.Diff(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err)
+	}
+
+	prometheus.ResetIntentsDiff()
+	for _, diff := range diffs {
+		prometheus.SetIntentsDiff(diff.Client.Namespace, diff.Client.Name, len(diff.Missing), len(diff.Unused), len(diff.Matching))
+	}
+	return nil
+}
+
+func (d *Differ) PeriodicMetricsUpdate(ctx context.Context, interval time.Duration) {
+	for {
+		select {
+		case <-time.After(interval):
+			if err := d.updateMetrics(ctx); err != nil {
+				logrus.WithError(err).Error("Failed updating intents diff metrics")
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package intentsdiff
+
+import (
+	"context"
+	otterizev2alpha1 "github.com/otterize/intents-operator/src/operator/api/v2alpha1"
+	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
+	"github.com/otterize/network-mapper/src/mapper/pkg/intentsstore"
+	"github.com/otterize/network-mapper/src/mapper/pkg/mocks"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"testing"
+	"time"
+)
+
+const testNamespace = "test-namespace"
+
+type DifferTestSuite struct {
+	suite.Suite
+	k8sMockClient *mocks.K8sClient
+	intentsHolder *intentsstore.IntentsHolder
+	differ        *Differ
+}
+
+func (s *DifferTestSuite) SetupTest() {
+	controller := gomock.NewController(s.T())
+	s.k8sMockClient = mocks.NewK8sClient(controller)
+	s.intentsHolder = intentsstore.NewIntentsHolder()
+	s.differ = NewDiffer(s.k8sMockClient, s.intentsHolder)
+}
+
+func (s *DifferTestSuite) addDiscoveredIntent(clientName string, server model.OtterizeServiceIdentity) {
+	s.intentsHolder.AddIntent(time.Now(), model.Intent{
+		Client: &model.OtterizeServiceIdentity{Name: clientName, Namespace: testNamespace},
+		Server: &server,
+	}, nil)
+}
+
+func (s *DifferTestSuite) TestDiff() {
+	s.addDiscoveredIntent("client", model.OtterizeServiceIdentity{Name: "declared-server", Namespace: testNamespace})
+	s.addDiscoveredIntent("client", model.OtterizeServiceIdentity{Name: "undeclared-server", Namespace: "other-namespace"})
+	s.addDiscoveredIntent("client", model.OtterizeServiceIdentity{Name: "api", Namespace: testNamespace, KubernetesService: lo.ToPtr("api-svc")})
+	s.addDiscoveredIntent("client-without-intents", model.OtterizeServiceIdentity{Name: "declared-server", Namespace: testNamespace})
+
+	clientIntents := otterizev2alpha1.ClientIntents{
+		ObjectMeta: metav1.ObjectMeta{Name: "client-intents", Namespace: testNamespace},
+		Spec: &otterizev2alpha1.IntentsSpec{
+			Workload: otterizev2alpha1.Workload{Name: "client"},
+			Targets: []otterizev2alpha1.Target{
+				{Kubernetes: &otterizev2alpha1.KubernetesTarget{Name: "declared-server"}},
+				{Service: &otterizev2alpha1.ServiceTarget{Name: "api-svc"}},
+				{Kubernetes: &otterizev2alpha1.KubernetesTarget{Name: "unused-server.other-namespace"}},
+				{Internet: &otterizev2alpha1.Internet{Domains: []string{"example.com"}}},
+			},
+		},
+	}
+
+	var intentsList otterizev2alpha1.ClientIntentsList
+	s.k8sMockClient.EXPECT().List(gomock.Any(), &intentsList, client.InNamespace(testNamespace)).DoAndReturn(
+		func(ctx context.Context, list *otterizev2alpha1.ClientIntentsList, opts ...client.ListOption) error {
+			list.Items = []otterizev2alpha1.ClientIntents{clientIntents}
+			return nil
+		})
+
+	diffs, err := s.differ.Diff(context.Background(), []string{testNamespace})
+	s.Require().NoError(err)
+	s.Require().Len(diffs, 2)
+
+	s.Require().Equal("client", diffs[0].Client.Name)
+	s.Require().Equal([]model.OtterizeServiceIdentity{
+		{Name: "undeclared-server", Namespace: "other-namespace"},
+	}, diffs[0].Missing)
+	s.Require().Equal([]model.OtterizeServiceIdentity{
+		{Name: "unused-server", Namespace: "other-namespace"},
+	}, diffs[0].Unused)
+	s.Require().Equal([]model.OtterizeServiceIdentity{
+		{Name: "api", Namespace: testNamespace, KubernetesService: lo.ToPtr("api-svc")},
+		{Name: "declared-server", Namespace: testNamespace},
+	}, diffs[0].Matching)
+
+	s.Require().Equal("client-without-intents", diffs[1].Client.Name)
+	s.Require().Equal([]model.OtterizeServiceIdentity{
+		{Name: "declared-server", Namespace: testNamespace},
+	}, diffs[1].Missing)
+	s.Require().Empty(diffs[1].Unused)
+	s.Require().Empty(diffs[1].Matching)
+}
+
+func TestDifferTestSuite(t *testing.T) {
+	suite.Run(t, new(DifferTestSuite))
+}
@@ -0,0 +1,50 @@
+package intentsstore
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// Evictable is implemented by the holders whose accumulated intents are evicted once they were not seen within the TTL.
+type Evictable interface {
+	EvictIntentsNotSeenSince(cutoff time.Time) int
+}
+
+// DropStale removes the entries of store last seen before cutoff, returning the number of entries removed. It is shared
+// by the holders, which each keep their intents in a map keyed and typed by the kind of intent.
+func DropStale[K comparable, V any](store map[K]V, cutoff time.Time, lastSeen func(V) time.Time) int {
+	dropped := 0
+	for key, entry := range store {
+		if lastSeen(entry).Before(cutoff) {
+			delete(store, key)
+			dropped++
+		}
+	}
+	return dropped
+}
+
+// PeriodicIntentsEviction removes intents that were not seen within the TTL from each of the holders, so that call paths
+// that no longer exist stop being reported. A TTL of zero disables eviction.
+func PeriodicIntentsEviction(ctx context.Context, ttl time.Duration, interval time.Duration, holders ...Evictable) {
+	if ttl == 0 {
+		return
+	}
+	logrus.WithField("ttl", ttl).Info("Starting periodic intents eviction")
+
+	for {
+		select {
+		case <-time.After(interval):
+			cutoff := time.Now().Add(-ttl)
+			evicted := 0
+			for _, holder := range holders {
+				evicted += holder.EvictIntentsNotSeenSince(cutoff)
+			}
+			if evicted > 0 {
+				logrus.WithField("count", evicted).Debug("Evicted expired intents")
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
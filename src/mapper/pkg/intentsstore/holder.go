@@ -23,6 +23,18 @@ type IntentsStoreKey struct {
 	Source      types.NamespacedName
 	Destination types.NamespacedName
 	Type        model.IntentType
+	Protocol    model.TransportProtocol
+}
+
+// newIntentsStoreKey returns the key of the intent, in which intents with no protocol are keyed as TCP, so they are
+// merged with TCP intents of the same client and server, but not with UDP ones.
+func newIntentsStoreKey(intent model.Intent) IntentsStoreKey {
+	return IntentsStoreKey{
+		Source:      intent.Client.AsNamespacedName(),
+		Destination: intent.Server.AsNamespacedName(),
+		Type:        lo.FromPtr(intent.Type),
+		Protocol:    lo.FromPtrOr(intent.Protocol, model.TransportProtocolTCP),
+	}
 }
 
 type TimestampedIntent struct {
@@ -41,6 +53,7 @@ type IntentsHolder struct {
 	connectionsCountDiffer *concurrentconnectioncounter.ConnectionCountDiffer[IntentsStoreKey, *concurrentconnectioncounter.CountableIntentIntent]
 	lock                   sync.Mutex
 	callbacks              []func(context.Context, []TimestampedIntent)
+	newIntentCallbacks     []func(TimestampedIntent)
 }
 
 func NewIntentsHolder() *IntentsHolder {
@@ -50,6 +63,7 @@ func NewIntentsHolder() *IntentsHolder {
 		connectionsCountDiffer: concurrentconnectioncounter.NewConnectionCountDiffer[IntentsStoreKey, *concurrentconnectioncounter.CountableIntentIntent](),
 		lock:                   sync.Mutex{},
 		callbacks:              make([]func(context.Context, []TimestampedIntent), 0),
+		newIntentCallbacks:     make([]func(TimestampedIntent), 0),
 	}
 }
 
@@ -82,17 +96,26 @@ func (i *IntentsHolder) Reset() {
 	i.accumulatingStore = make(IntentsStore)
 }
 
+type kafkaResourceKey struct {
+	resourceType model.KafkaResourceType
+	name         string
+}
+
+func kafkaResourceKeyOf(config model.KafkaConfig) kafkaResourceKey {
+	return kafkaResourceKey{resourceType: lo.FromPtrOr(config.ResourceType, model.KafkaResourceTypeTopic), name: config.Name}
+}
+
 func mergeKafkaTopics(existingTopics []model.KafkaConfig, newTopics []model.KafkaConfig) []model.KafkaConfig {
-	existingTopicsByName := lo.SliceToMap(existingTopics, func(topic model.KafkaConfig) (string, *model.KafkaConfig) {
-		return topic.Name, &topic
+	existingTopicsByName := lo.SliceToMap(existingTopics, func(topic model.KafkaConfig) (kafkaResourceKey, *model.KafkaConfig) {
+		return kafkaResourceKeyOf(topic), &topic
 	})
 
 	for _, newTopic := range newTopics {
-		existingTopic, ok := existingTopicsByName[newTopic.Name]
+		existingTopic, ok := existingTopicsByName[kafkaResourceKeyOf(newTopic)]
 		if ok {
 			existingTopic.Operations = lo.Uniq(append(existingTopic.Operations, newTopic.Operations...))
 		} else {
-			existingTopicsByName[newTopic.Name] = &newTopic
+			existingTopicsByName[kafkaResourceKeyOf(newTopic)] = &newTopic
 		}
 	}
 
@@ -136,11 +159,7 @@ func mergeHTTPResources(existingResources, newResources []model.HTTPResource) []
 }
 
 func (i *IntentsHolder) addIntentToStore(store IntentsStore, newTimestamp time.Time, intent model.Intent) {
-	key := IntentsStoreKey{
-		Source:      intent.Client.AsNamespacedName(),
-		Destination: intent.Server.AsNamespacedName(),
-		Type:        lo.FromPtr(intent.Type),
-	}
+	key := newIntentsStoreKey(intent)
 
 	existingIntent, ok := store[key]
 	if !ok {
@@ -157,6 +176,9 @@ func (i *IntentsHolder) addIntentToStore(store IntentsStore, newTimestamp time.T
 	}
 	existingIntent.Intent.KafkaTopics = mergeKafkaTopics(existingIntent.Intent.KafkaTopics, intent.KafkaTopics)
 	existingIntent.Intent.HTTPResources = mergeHTTPResources(existingIntent.Intent.HTTPResources, intent.HTTPResources)
+	if existingIntent.Intent.Protocol == nil {
+		existingIntent.Intent.Protocol = intent.Protocol
+	}
 
 	// Replace labels with latest
 	existingIntent.Intent.Client.Labels = intent.Client.Labels
@@ -176,11 +198,7 @@ func (i *IntentsHolder) addIntentToStore(store IntentsStore, newTimestamp time.T
 }
 
 func (i *IntentsHolder) addUniqueCount(intent model.Intent, sourcePorts []int64) {
-	key := IntentsStoreKey{
-		Source:      intent.Client.AsNamespacedName(),
-		Destination: intent.Server.AsNamespacedName(),
-		Type:        lo.FromPtr(intent.Type),
-	}
+	key := newIntentsStoreKey(intent)
 
 	i.connectionsCountDiffer.Increment(key, concurrentconnectioncounter.CounterInput[*concurrentconnectioncounter.CountableIntentIntent]{
 		Intent:      concurrentconnectioncounter.NewCountableIntentIntent(intent),
@@ -209,10 +227,40 @@ func (i *IntentsHolder) PeriodicIntentsUpload(ctx context.Context, interval time
 	}
 }
 
+// EvictIntentsNotSeenSince removes intents last seen before cutoff, returning the number of intents removed.
+func (i *IntentsHolder) EvictIntentsNotSeenSince(cutoff time.Time) int {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	return dropStaleIntents(i.accumulatingStore, cutoff)
+}
+
 func (i *IntentsHolder) RegisterNotifyIntents(callback func(context.Context, []TimestampedIntent)) {
 	i.callbacks = append(i.callbacks, callback)
 }
 
+// RegisterNotifyNewIntent registers a callback that is called whenever an intent is added for the first time.
+// Callbacks are called while the holder is locked, and must not block.
+func (i *IntentsHolder) RegisterNotifyNewIntent(callback func(TimestampedIntent)) {
+	i.newIntentCallbacks = append(i.newIntentCallbacks, callback)
+}
+
+func (i *IntentsHolder) notifyNewIntent(key IntentsStoreKey) {
+	if len(i.newIntentCallbacks) == 0 {
+		return
+	}
+
+	// Callbacks may hold on to the intent, while the store keeps merging into it.
+	intent, err := getIntentDeepCopy(i.accumulatingStore[key])
+	if err != nil {
+		logrus.WithError(err).Error("Failed copying new intent")
+		return
+	}
+	for _, callback := range i.newIntentCallbacks {
+		callback(intent)
+	}
+}
+
 func (i *IntentsHolder) AddIntent(newTimestamp time.Time, intent model.Intent, sourcePorts []int64) {
 	if config.ExcludedNamespaces().Contains(intent.Client.Namespace) || config.ExcludedNamespaces().Contains(intent.Server.Namespace) {
 		return
@@ -221,10 +269,17 @@ func (i *IntentsHolder) AddIntent(newTimestamp time.Time, intent model.Intent, s
 	i.lock.Lock()
 	defer i.lock.Unlock()
 
+	key := newIntentsStoreKey(intent)
+	_, found := i.accumulatingStore[key]
+
 	i.addIntentToStore(i.accumulatingStore, newTimestamp, intent)
 	i.addIntentToStore(i.sinceLastGetStore, newTimestamp, intent)
 	i.addUniqueCount(intent, sourcePorts)
 
+	if !found {
+		i.notifyNewIntent(key)
+	}
+
 	intentLogger := logrus.WithFields(logrus.Fields{
 		"client":          intent.Client.Name,
 		"clientNamespace": intent.Client.Namespace,
@@ -246,6 +301,8 @@ func (i *IntentsHolder) GetIntents(
 	excludeServiceWithLabels []string,
 	includeAllLabels bool,
 	serverFilter *model.ServerFilter,
+	since *time.Time,
+	until *time.Time,
 ) ([]TimestampedIntent, error) {
 	i.lock.Lock()
 	defer i.lock.Unlock()
@@ -256,7 +313,9 @@ func (i *IntentsHolder) GetIntents(
 		includeLabels,
 		excludeServiceWithLabels,
 		includeAllLabels,
-		serverFilter)
+		serverFilter,
+		since,
+		until)
 
 	if err != nil {
 		return []TimestampedIntent{}, errors.Wrap(err)
@@ -274,6 +333,8 @@ func (i *IntentsHolder) GetNewIntentsSinceLastGet() []TimestampedIntent {
 		nil,
 		nil,
 		false,
+		nil,
+		nil,
 		nil)
 
 	i.sinceLastGetStore = make(IntentsStore)
@@ -286,6 +347,7 @@ func (i *IntentsHolder) getIntentsFromStore(
 	namespaces, includeLabels, excludeServiceWithLabels []string,
 	includeAllLabels bool,
 	serverFilter *model.ServerFilter,
+	since, until *time.Time,
 ) ([]TimestampedIntent, error) {
 	namespacesSet := goset.FromSlice(namespaces)
 	includeLabelsSet := goset.FromSlice(includeLabels)
@@ -305,6 +367,13 @@ func (i *IntentsHolder) getIntentsFromStore(
 	}
 
 	for pair, intent := range store {
+		if since != nil && intent.Timestamp.Before(*since) {
+			continue
+		}
+		if until != nil && intent.Timestamp.After(*until) {
+			continue
+		}
+
 		intentCopy, err := getIntentDeepCopy(intent)
 		if err != nil {
 			return result, errors.Wrap(err)
@@ -0,0 +1,137 @@
+package intentsstore
+
+import (
+	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+const testNamespace = "test-namespace"
+
+type IntentsHolderSuite struct {
+	suite.Suite
+	holder *IntentsHolder
+	now    time.Time
+}
+
+func (s *IntentsHolderSuite) SetupTest() {
+	s.holder = NewIntentsHolder()
+	s.now = time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (s *IntentsHolderSuite) addIntent(clientName string, timestamp time.Time) {
+	s.holder.AddIntent(timestamp, model.Intent{
+		Client: &model.OtterizeServiceIdentity{Name: clientName, Namespace: testNamespace},
+		Server: &model.OtterizeServiceIdentity{Name: "server", Namespace: testNamespace},
+	}, nil)
+}
+
+func (s *IntentsHolderSuite) clientNames(intents []TimestampedIntent) []string {
+	return lo.Map(intents, func(intent TimestampedIntent, _ int) string {
+		return intent.Intent.Client.Name
+	})
+}
+
+func (s *IntentsHolderSuite) TestGetIntentsTimeWindow() {
+	s.addIntent("old", s.now.Add(-2*time.Hour))
+	s.addIntent("recent", s.now.Add(-30*time.Minute))
+	s.addIntent("latest", s.now)
+
+	intents, err := s.holder.GetIntents(nil, nil, nil, false, nil, nil, nil)
+	s.Require().NoError(err)
+	s.Require().ElementsMatch([]string{"old", "recent", "latest"}, s.clientNames(intents))
+
+	since := s.now.Add(-time.Hour)
+	intents, err = s.holder.GetIntents(nil, nil, nil, false, nil, &since, nil)
+	s.Require().NoError(err)
+	s.Require().ElementsMatch([]string{"recent", "latest"}, s.clientNames(intents))
+
+	until := s.now.Add(-30 * time.Minute)
+	intents, err = s.holder.GetIntents(nil, nil, nil, false, nil, &since, &until)
+	s.Require().NoError(err)
+	s.Require().ElementsMatch([]string{"recent"}, s.clientNames(intents))
+}
+
+func (s *IntentsHolderSuite) TestEvictIntentsNotSeenSince() {
+	s.addIntent("old", s.now.Add(-2*time.Hour))
+	s.addIntent("latest", s.now)
+
+	evicted := s.holder.EvictIntentsNotSeenSince(s.now.Add(-time.Hour))
+	s.Require().Equal(1, evicted)
+
+	intents, err := s.holder.GetIntents(nil, nil, nil, false, nil, nil, nil)
+	s.Require().NoError(err)
+	s.Require().ElementsMatch([]string{"latest"}, s.clientNames(intents))
+
+	// A call path seen again after eviction is reported again
+	s.addIntent("old", s.now)
+	intents, err = s.holder.GetIntents(nil, nil, nil, false, nil, nil, nil)
+	s.Require().NoError(err)
+	s.Require().ElementsMatch([]string{"old", "latest"}, s.clientNames(intents))
+}
+
+func (s *IntentsHolderSuite) TestSnapshotLeavesStaleIntentsInHolder() {
+	s.addIntent("old", s.now.Add(-2*time.Hour))
+	s.addIntent("latest", s.now)
+
+	data, err := s.holder.Snapshot(s.now.Add(-time.Hour))
+	s.Require().NoError(err)
+
+	intents, err := s.holder.GetIntents(nil, nil, nil, false, nil, nil, nil)
+	s.Require().NoError(err)
+	s.Require().ElementsMatch([]string{"old", "latest"}, s.clientNames(intents))
+
+	restored := NewIntentsHolder()
+	s.Require().NoError(restored.Restore(data, time.Time{}))
+	intents, err = restored.GetIntents(nil, nil, nil, false, nil, nil, nil)
+	s.Require().NoError(err)
+	s.Require().ElementsMatch([]string{"latest"}, s.clientNames(intents))
+}
+
+func (s *IntentsHolderSuite) TestKafkaResourcesMergedByType() {
+	addKafkaIntent := func(config model.KafkaConfig) {
+		s.holder.AddIntent(s.now, model.Intent{
+			Client:      &model.OtterizeServiceIdentity{Name: "client", Namespace: testNamespace},
+			Server:      &model.OtterizeServiceIdentity{Name: "kafka", Namespace: testNamespace},
+			Type:        lo.ToPtr(model.IntentTypeKafka),
+			KafkaTopics: []model.KafkaConfig{config},
+		}, nil)
+	}
+	addKafkaIntent(model.KafkaConfig{Name: "orders", Operations: []model.KafkaOperation{model.KafkaOperationConsume}})
+	addKafkaIntent(model.KafkaConfig{Name: "orders", Operations: []model.KafkaOperation{model.KafkaOperationDescribe}})
+	addKafkaIntent(model.KafkaConfig{Name: "orders", Operations: []model.KafkaOperation{model.KafkaOperationConsume}, ResourceType: lo.ToPtr(model.KafkaResourceTypeGroup)})
+
+	intents, err := s.holder.GetIntents(nil, nil, nil, false, nil, nil, nil)
+	s.Require().NoError(err)
+	s.Require().Len(intents, 1)
+	s.Require().ElementsMatch([]model.KafkaConfig{
+		{Name: "orders", Operations: []model.KafkaOperation{model.KafkaOperationConsume, model.KafkaOperationDescribe}},
+		{Name: "orders", Operations: []model.KafkaOperation{model.KafkaOperationConsume}, ResourceType: lo.ToPtr(model.KafkaResourceTypeGroup)},
+	}, intents[0].Intent.KafkaTopics)
+}
+
+func (s *IntentsHolderSuite) TestIntentsKeyedByProtocol() {
+	addIntent := func(protocol *model.TransportProtocol) {
+		s.holder.AddIntent(s.now, model.Intent{
+			Client:   &model.OtterizeServiceIdentity{Name: "client", Namespace: testNamespace},
+			Server:   &model.OtterizeServiceIdentity{Name: "statsd", Namespace: testNamespace},
+			Protocol: protocol,
+		}, nil)
+	}
+	addIntent(nil)
+	addIntent(lo.ToPtr(model.TransportProtocolTCP))
+	addIntent(lo.ToPtr(model.TransportProtocolUDP))
+
+	intents, err := s.holder.GetIntents(nil, nil, nil, false, nil, nil, nil)
+	s.Require().NoError(err)
+	s.Require().ElementsMatch([]model.TransportProtocol{model.TransportProtocolTCP, model.TransportProtocolUDP},
+		lo.Map(intents, func(intent TimestampedIntent, _ int) model.TransportProtocol {
+			return lo.FromPtr(intent.Intent.Protocol)
+		}))
+}
+
+func TestIntentsHolderSuite(t *testing.T) {
+	suite.Run(t, new(IntentsHolderSuite))
+}
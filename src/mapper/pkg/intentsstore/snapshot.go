@@ -0,0 +1,70 @@
+package intentsstore
+
+import (
+	"encoding/json"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"time"
+)
+
+type intentsSnapshot struct {
+	Accumulating []TimestampedIntent
+	SinceLastGet []TimestampedIntent
+}
+
+// dropStaleIntents removes intents last seen before cutoff from store, returning the number of intents removed.
+func dropStaleIntents(store IntentsStore, cutoff time.Time) int {
+	return DropStale(store, cutoff, func(intent TimestampedIntent) time.Time { return intent.Timestamp })
+}
+
+func storeToSnapshotEntries(store IntentsStore, cutoff time.Time) []TimestampedIntent {
+	entries := make([]TimestampedIntent, 0, len(store))
+	for _, intent := range store {
+		if intent.Timestamp.Before(cutoff) {
+			continue
+		}
+		// Connection counts are only meaningful relative to the current process, so they are not persisted.
+		intent.ConnectionsCount = nil
+		entries = append(entries, intent)
+	}
+	return entries
+}
+
+// Snapshot returns the intents last seen since cutoff, serialized. Older intents are left for the TTL eviction.
+func (i *IntentsHolder) Snapshot(cutoff time.Time) ([]byte, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	data, err := json.Marshal(intentsSnapshot{
+		Accumulating: storeToSnapshotEntries(i.accumulatingStore, cutoff),
+		SinceLastGet: storeToSnapshotEntries(i.sinceLastGetStore, cutoff),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	return data, nil
+}
+
+// Restore merges intents from a snapshot created by Snapshot into the holder, skipping those last seen before cutoff.
+func (i *IntentsHolder) Restore(data []byte, cutoff time.Time) error {
+	var snapshot intentsSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return errors.Wrap(err)
+	}
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	for _, intent := range snapshot.Accumulating {
+		if intent.Timestamp.Before(cutoff) {
+			continue
+		}
+		i.addIntentToStore(i.accumulatingStore, intent.Timestamp, intent.Intent)
+	}
+	for _, intent := range snapshot.SinceLastGet {
+		if intent.Timestamp.Before(cutoff) {
+			continue
+		}
+		i.addIntentToStore(i.sinceLastGetStore, intent.Timestamp, intent.Intent)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package kafkaexporter
+
+import (
+	"github.com/otterize/network-mapper/src/mapper/pkg/config"
+	"github.com/spf13/viper"
+)
+
+type Config struct {
+	Brokers []string
+	Topic   string
+}
+
+func ConfigFromViper() Config {
+	return Config{
+		Brokers: viper.GetStringSlice(config.KafkaExportBrokersKey),
+		Topic:   viper.GetString(config.KafkaExportTopicKey),
+	}
+}
+
+func (c Config) Enabled() bool {
+	return len(c.Brokers) != 0
+}
@@ -0,0 +1,129 @@
+package kafkaexporter
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/IBM/sarama"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"github.com/otterize/network-mapper/src/mapper/pkg/collectors/traffic"
+	"github.com/otterize/network-mapper/src/mapper/pkg/exportpayload"
+	"github.com/otterize/network-mapper/src/mapper/pkg/externaltrafficholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/intentsstore"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// Message is the JSON value of every record the exporter produces. Its Kind determines the type of Item, as documented
+// in the exportpayload package. Records are keyed by the client workload, as "namespace/name", so that all records of
+// a client land on the same partition.
+type Message[T any] struct {
+	Kind   exportpayload.Kind `json:"kind"`
+	SentAt time.Time          `json:"sentAt"`
+	Item   T                  `json:"item"`
+}
+
+// queueSize is the number of notifications waiting to be produced, beyond which new notifications are dropped.
+const queueSize = 100
+
+// Exporter produces discovered intents and traffic levels to a Kafka topic. Notifications are queued and produced by
+// RunForever, so a broker that is down does not hold up the holders that notify it.
+type Exporter struct {
+	producer sarama.SyncProducer
+	topic    string
+	queue    chan []*sarama.ProducerMessage
+}
+
+func newSaramaConfig() *sarama.Config {
+	saramaConfig := sarama.NewConfig()
+	saramaConfig.ClientID = "otterize-network-mapper"
+	saramaConfig.Producer.RequiredAcks = sarama.WaitForAll
+	saramaConfig.Producer.Return.Successes = true
+	return saramaConfig
+}
+
+func NewExporter(config Config) (*Exporter, error) {
+	producer, err := sarama.NewSyncProducer(config.Brokers, newSaramaConfig())
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	return newExporterWithProducer(producer, config.Topic), nil
+}
+
+func newExporterWithProducer(producer sarama.SyncProducer, topic string) *Exporter {
+	return &Exporter{producer: producer, topic: topic, queue: make(chan []*sarama.ProducerMessage, queueSize)}
+}
+
+func (e *Exporter) RunForever(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case messages := <-e.queue:
+			e.send(messages)
+		}
+	}
+}
+
+func (e *Exporter) send(messages []*sarama.ProducerMessage) {
+	// The producer retries internally, so whatever failed here is dropped.
+	if err := e.producer.SendMessages(messages); err != nil {
+		logrus.WithError(err).WithField("count", len(messages)).Error("Failed to produce messages to Kafka")
+	}
+}
+
+func workloadKey(workload exportpayload.Workload) string {
+	return workload.Namespace + "/" + workload.Name
+}
+
+func produce[T any](e *Exporter, kind exportpayload.Kind, items []T, keyFunc func(T) exportpayload.Workload) {
+	if len(items) == 0 {
+		return
+	}
+
+	sentAt := time.Now()
+	messages := make([]*sarama.ProducerMessage, 0, len(items))
+	for _, item := range items {
+		value, err := json.Marshal(Message[T]{Kind: kind, SentAt: sentAt, Item: item})
+		if err != nil {
+			logrus.WithError(err).WithField("kind", kind).Error("Failed to marshal Kafka message")
+			continue
+		}
+		messages = append(messages, &sarama.ProducerMessage{
+			Topic: e.topic,
+			Key:   sarama.StringEncoder(workloadKey(keyFunc(item))),
+			Value: sarama.ByteEncoder(value),
+		})
+	}
+
+	if len(messages) == 0 {
+		return
+	}
+
+	select {
+	case e.queue <- messages:
+	default:
+		logrus.WithField("kind", kind).Warningf("Kafka queue is full, dropping %d messages", len(messages))
+	}
+}
+
+func (e *Exporter) NotifyIntents(_ context.Context, intents []intentsstore.TimestampedIntent) {
+	produce(e, exportpayload.KindIntents, exportpayload.FromIntents(intents), func(intent exportpayload.Intent) exportpayload.Workload {
+		return intent.Client
+	})
+}
+
+func (e *Exporter) NotifyExternalTrafficIntents(_ context.Context, intents []externaltrafficholder.TimestampedExternalTrafficIntent) {
+	produce(e, exportpayload.KindExternalTrafficIntents, exportpayload.FromExternalTrafficIntents(intents), func(intent exportpayload.ExternalTrafficIntent) exportpayload.Workload {
+		return intent.Client
+	})
+}
+
+func (e *Exporter) NotifyTrafficLevels(_ context.Context, trafficLevels traffic.TrafficLevelMap) {
+	produce(e, exportpayload.KindTrafficLevels, exportpayload.FromTrafficLevels(trafficLevels), func(level exportpayload.TrafficLevel) exportpayload.Workload {
+		return level.Client
+	})
+}
+
+func (e *Exporter) Close() error {
+	return errors.Wrap(e.producer.Close())
+}
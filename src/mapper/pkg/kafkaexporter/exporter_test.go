@@ -0,0 +1,224 @@
+package kafkaexporter
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/IBM/sarama"
+	"github.com/otterize/network-mapper/src/mapper/pkg/collectors/traffic"
+	"github.com/otterize/network-mapper/src/mapper/pkg/exportpayload"
+	"github.com/otterize/network-mapper/src/mapper/pkg/externaltrafficholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
+	"github.com/otterize/network-mapper/src/mapper/pkg/intentsstore"
+	"github.com/stretchr/testify/suite"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testTopic          = "test-topic"
+	testPartitionCount = 2
+)
+
+var (
+	testTimestamp = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// recordingInterceptor records the messages the producer sends, which the producer updates with the partition and
+// offset assigned to them once the broker acknowledges them.
+type recordingInterceptor struct {
+	lock     sync.Mutex
+	messages []*sarama.ProducerMessage
+}
+
+func (i *recordingInterceptor) OnSend(msg *sarama.ProducerMessage) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.messages = append(i.messages, msg)
+}
+
+func (i *recordingInterceptor) sent() []*sarama.ProducerMessage {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	return i.messages
+}
+
+type KafkaExporterTestSuite struct {
+	suite.Suite
+	broker      *sarama.MockBroker
+	interceptor *recordingInterceptor
+	exporter    *Exporter
+}
+
+func (s *KafkaExporterTestSuite) SetupTest() {
+	s.broker = sarama.NewMockBroker(s.T(), 1)
+	metadataResponse := sarama.NewMockMetadataResponse(s.T()).SetBroker(s.broker.Addr(), s.broker.BrokerID())
+	for partition := int32(0); partition < testPartitionCount; partition++ {
+		metadataResponse.SetLeader(testTopic, partition, s.broker.BrokerID())
+	}
+	s.broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": metadataResponse,
+		"ProduceRequest":  sarama.NewMockProduceResponse(s.T()),
+	})
+
+	s.interceptor = &recordingInterceptor{}
+	saramaConfig := newSaramaConfig()
+	saramaConfig.Producer.Interceptors = []sarama.ProducerInterceptor{s.interceptor}
+	producer, err := sarama.NewSyncProducer([]string{s.broker.Addr()}, saramaConfig)
+	s.Require().NoError(err)
+	s.exporter = newExporterWithProducer(producer, testTopic)
+}
+
+func (s *KafkaExporterTestSuite) TearDownTest() {
+	s.Require().NoError(s.exporter.Close())
+	s.broker.Close()
+}
+
+// sendQueued produces the notifications queued by the exporter, as RunForever does.
+func (s *KafkaExporterTestSuite) sendQueued() {
+	for len(s.exporter.queue) > 0 {
+		s.exporter.send(<-s.exporter.queue)
+	}
+}
+
+// producedRequests returns the number of produce requests the broker received.
+func (s *KafkaExporterTestSuite) producedRequests() int {
+	count := 0
+	for _, requestResponse := range s.broker.History() {
+		if _, ok := requestResponse.Request.(*sarama.ProduceRequest); ok {
+			count++
+		}
+	}
+	return count
+}
+
+// decodeMessage checks that msg was produced with the given key, and decodes its value into message.
+func decodeMessage[T any](s *KafkaExporterTestSuite, msg *sarama.ProducerMessage, key string, message *Message[T]) {
+	s.Require().Equal(testTopic, msg.Topic)
+	s.Require().Equal(sarama.StringEncoder(key), msg.Key)
+	value, err := msg.Value.Encode()
+	s.Require().NoError(err)
+	s.Require().NoError(json.Unmarshal(value, message))
+}
+
+func (s *KafkaExporterTestSuite) TestNotifyIntents() {
+	s.exporter.NotifyIntents(context.Background(), []intentsstore.TimestampedIntent{
+		{
+			Timestamp: testTimestamp,
+			Intent: model.Intent{
+				Client: &model.OtterizeServiceIdentity{Name: "client1", Namespace: "ns1"},
+				Server: &model.OtterizeServiceIdentity{Name: "server1", Namespace: "ns2"},
+			},
+		},
+		{
+			Timestamp: testTimestamp,
+			Intent: model.Intent{
+				Client: &model.OtterizeServiceIdentity{Name: "client2", Namespace: "ns1"},
+				Server: &model.OtterizeServiceIdentity{Name: "server1", Namespace: "ns2"},
+			},
+		},
+	})
+	s.sendQueued()
+
+	sent := s.interceptor.sent()
+	s.Require().Len(sent, 2)
+	var first, second Message[exportpayload.Intent]
+	decodeMessage(s, sent[0], "ns1/client1", &first)
+	decodeMessage(s, sent[1], "ns1/client2", &second)
+	s.Require().Positive(s.producedRequests())
+
+	s.Require().Equal(exportpayload.KindIntents, first.Kind)
+	s.Require().Equal(exportpayload.Intent{
+		DiscoveredAt: testTimestamp,
+		Client:       exportpayload.Workload{Name: "client1", Namespace: "ns1"},
+		Server:       exportpayload.Workload{Name: "server1", Namespace: "ns2"},
+	}, first.Item)
+	s.Require().Equal("client2", second.Item.Client.Name)
+}
+
+func (s *KafkaExporterTestSuite) TestNotifyExternalTrafficIntents() {
+	s.exporter.NotifyExternalTrafficIntents(context.Background(), []externaltrafficholder.TimestampedExternalTrafficIntent{
+		{
+			Timestamp: testTimestamp,
+			Intent: externaltrafficholder.ExternalTrafficIntent{
+				Client:  model.OtterizeServiceIdentity{Name: "client1", Namespace: "ns1"},
+				DNSName: "example.com",
+				IPs:     map[externaltrafficholder.IP]struct{}{"1.1.1.1": {}},
+			},
+		},
+	})
+	s.sendQueued()
+
+	sent := s.interceptor.sent()
+	s.Require().Len(sent, 1)
+	var message Message[exportpayload.ExternalTrafficIntent]
+	decodeMessage(s, sent[0], "ns1/client1", &message)
+
+	s.Require().Equal(exportpayload.KindExternalTrafficIntents, message.Kind)
+	s.Require().Equal("example.com", message.Item.DNSName)
+	s.Require().Equal([]string{"1.1.1.1"}, message.Item.IPs)
+}
+
+func (s *KafkaExporterTestSuite) TestNotifyTrafficLevels() {
+	s.exporter.NotifyTrafficLevels(context.Background(), traffic.TrafficLevelMap{
+		traffic.TrafficLevelKey{SourceName: "client1", SourceNamespace: "ns1", DestinationName: "server1", DestinationNamespace: "ns2"}: traffic.TrafficLevelData{Bytes: 100, Flows: 2},
+	})
+	s.sendQueued()
+
+	sent := s.interceptor.sent()
+	s.Require().Len(sent, 1)
+	var message Message[exportpayload.TrafficLevel]
+	decodeMessage(s, sent[0], "ns1/client1", &message)
+
+	s.Require().Equal(exportpayload.KindTrafficLevels, message.Kind)
+	s.Require().Equal(100, message.Item.DataBytesPerSecond)
+	s.Require().Equal(2, message.Item.FlowsCountPerSecond)
+}
+
+func (s *KafkaExporterTestSuite) TestRecordsOfAClientShareAPartition() {
+	intents := make([]intentsstore.TimestampedIntent, 0)
+	for _, client := range []string{"client1", "client2", "client3", "client1", "client2", "client3"} {
+		intents = append(intents, intentsstore.TimestampedIntent{
+			Timestamp: testTimestamp,
+			Intent: model.Intent{
+				Client: &model.OtterizeServiceIdentity{Name: client, Namespace: "ns1"},
+				Server: &model.OtterizeServiceIdentity{Name: "server1", Namespace: "ns2"},
+			},
+		})
+	}
+	s.exporter.NotifyIntents(context.Background(), intents)
+	s.sendQueued()
+
+	partitions := map[sarama.Encoder]int32{}
+	for _, msg := range s.interceptor.sent() {
+		s.Require().GreaterOrEqual(msg.Partition, int32(0))
+		s.Require().Less(msg.Partition, int32(testPartitionCount))
+		if partition, ok := partitions[msg.Key]; ok {
+			s.Require().Equal(partition, msg.Partition)
+		}
+		partitions[msg.Key] = msg.Partition
+	}
+	s.Require().Len(partitions, 3)
+}
+
+func (s *KafkaExporterTestSuite) TestNotifyEmptyDoesNotProduce() {
+	s.exporter.NotifyIntents(context.Background(), nil)
+	s.exporter.NotifyTrafficLevels(context.Background(), traffic.TrafficLevelMap{})
+	s.Require().Empty(s.exporter.queue)
+}
+
+func (s *KafkaExporterTestSuite) TestDropsNotificationsWhenQueueIsFull() {
+	levels := traffic.TrafficLevelMap{
+		traffic.TrafficLevelKey{SourceName: "client1", SourceNamespace: "ns1", DestinationName: "server1", DestinationNamespace: "ns2"}: traffic.TrafficLevelData{Bytes: 100, Flows: 2},
+	}
+	// Notifications are only queued, so they don't block while the broker is unreachable.
+	for i := 0; i < queueSize+10; i++ {
+		s.exporter.NotifyTrafficLevels(context.Background(), levels)
+	}
+	s.Require().Len(s.exporter.queue, queueSize)
+	s.Require().Zero(s.producedRequests())
+}
+
+func TestKafkaExporterTestSuite(t *testing.T) {
+	suite.Run(t, new(KafkaExporterTestSuite))
+}
@@ -30,6 +30,8 @@ const (
 	externalIPIndexField                = "spec.externalIPs"
 	nodePortNumberIndexField            = "service.spec.ports.nodePort"
 	nodeIPIndexField                    = "node.status.Addresses.ExternalIP"
+	podServiceAccountIndexField         = "spec.serviceAccountName"
+	podContainerIDIndexField            = "status.containerStatuses.containerID"
 	IstioCanonicalNameLabelKey          = "service.istio.io/canonical-name"
 	apiServerName                       = "kubernetes"
 	apiServerNamespace                  = "default"
@@ -75,7 +77,7 @@ func (k *KubeFinder) initIndexes(ctx context.Context) error {
 			return res
 		}
 		for _, ip := range pod.Status.PodIPs {
-			res = append(res, ip.IP)
+			res = append(res, normalizeIP(ip.IP))
 		}
 		return res
 	})
@@ -92,8 +94,38 @@ func (k *KubeFinder) initIndexes(ctx context.Context) error {
 		}
 
 		for _, ip := range pod.Status.PodIPs {
-			k.seenIPsTTLCache.Add(ip.IP, struct{}{})
-			res = append(res, ip.IP)
+			k.seenIPsTTLCache.Add(normalizeIP(ip.IP), struct{}{})
+			res = append(res, normalizeIP(ip.IP))
+		}
+		return res
+	})
+	if err != nil {
+		return errors.Wrap(err)
+	}
+
+	err = k.mgr.GetCache().IndexField(ctx, &corev1.Pod{}, podServiceAccountIndexField, func(object client.Object) []string {
+		pod := object.(*corev1.Pod)
+		if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning {
+			return nil
+		}
+		return []string{pod.Spec.ServiceAccountName}
+	})
+	if err != nil {
+		return errors.Wrap(err)
+	}
+
+	err = k.mgr.GetCache().IndexField(ctx, &corev1.Pod{}, podContainerIDIndexField, func(object client.Object) []string {
+		pod := object.(*corev1.Pod)
+		if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning {
+			return nil
+		}
+		res := make([]string, 0)
+		for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+			for _, status := range statuses {
+				if containerID := trimContainerRuntime(status.ContainerID); containerID != "" {
+					res = append(res, containerID)
+				}
+			}
 		}
 		return res
 	})
@@ -104,7 +136,10 @@ func (k *KubeFinder) initIndexes(ctx context.Context) error {
 	err = k.mgr.GetCache().IndexField(ctx, &corev1.Service{}, serviceIPIndexField, func(object client.Object) []string {
 		res := make([]string, 0)
 		svc := object.(*corev1.Service)
-		res = append(res, svc.Spec.ClusterIPs...)
+		// ClusterIPs holds both the IPv4 and IPv6 addresses of dual-stack services
+		for _, ip := range svc.Spec.ClusterIPs {
+			res = append(res, normalizeIP(ip))
+		}
 		return res
 	})
 	if err != nil {
@@ -122,7 +157,7 @@ func (k *KubeFinder) initIndexes(ctx context.Context) error {
 		}
 
 		for _, ingress := range svc.Status.LoadBalancer.Ingress {
-			ips.Insert(ingress.IP)
+			ips.Insert(normalizeIP(ingress.IP))
 		}
 		return ips.UnsortedList()
 	})
@@ -160,7 +195,7 @@ func (k *KubeFinder) initIndexes(ctx context.Context) error {
 
 		for _, address := range node.Status.Addresses {
 			if address.Type == corev1.NodeInternalIP || address.Type == corev1.NodeExternalIP {
-				ips.Insert(address.Address)
+				ips.Insert(normalizeIP(address.Address))
 			}
 		}
 		return ips.UnsortedList()
@@ -181,9 +216,21 @@ func (k *KubeFinder) ResolvePodByName(ctx context.Context, name string, namespac
 	return &pod, nil
 }
 
+func (k *KubeFinder) IsNamespace(ctx context.Context, name string) (bool, error) {
+	var namespace corev1.Namespace
+	err := k.client.Get(ctx, types.NamespacedName{Name: name}, &namespace)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(err)
+	}
+	return true, nil
+}
+
 func (k *KubeFinder) ResolveIPToService(ctx context.Context, ip string) (*corev1.Service, bool, error) {
 	var services corev1.ServiceList
-	err := k.client.List(ctx, &services, client.MatchingFields{serviceIPIndexField: ip})
+	err := k.client.List(ctx, &services, client.MatchingFields{serviceIPIndexField: normalizeIP(ip)})
 	if err != nil {
 		return nil, false, errors.Wrap(err)
 	}
@@ -241,7 +288,7 @@ func (k *KubeFinder) ResolveServiceToPods(ctx context.Context, svc *corev1.Servi
 
 func (k *KubeFinder) IsIpHostNetworkIp(ctx context.Context, ip string) (bool, error) {
 	var podsWithHostNetwork corev1.PodList
-	err := k.client.List(ctx, &podsWithHostNetwork, client.MatchingFields{podIPIncludingHostNetworkIndexField: ip})
+	err := k.client.List(ctx, &podsWithHostNetwork, client.MatchingFields{podIPIncludingHostNetworkIndexField: normalizeIP(ip)})
 	if err != nil {
 		return false, errors.Wrap(err)
 	}
@@ -251,7 +298,7 @@ func (k *KubeFinder) IsIpHostNetworkIp(ctx context.Context, ip string) (bool, er
 
 func (k *KubeFinder) ResolveIPToPod(ctx context.Context, ip string) (*corev1.Pod, error) {
 	var pods corev1.PodList
-	err := k.client.List(ctx, &pods, client.MatchingFields{podIPIndexField: ip})
+	err := k.client.List(ctx, &pods, client.MatchingFields{podIPIndexField: normalizeIP(ip)})
 	if err != nil {
 		return nil, errors.Wrap(err)
 	}
@@ -273,7 +320,11 @@ func (k *KubeFinder) ResolveIPToControlPlane(ctx context.Context, ip string) (*c
 		return nil, false, errors.Wrap(err)
 	}
 
-	if ip == svc.Spec.ClusterIP {
+	// Dual-stack clusters have both an IPv4 and an IPv6 cluster IP for the API server
+	clusterIPs := lo.Map(append([]string{svc.Spec.ClusterIP}, svc.Spec.ClusterIPs...), func(clusterIP string, _ int) string {
+		return normalizeIP(clusterIP)
+	})
+	if lo.Contains(clusterIPs, normalizeIP(ip)) {
 		return &svc, true, nil
 	}
 
@@ -298,25 +349,36 @@ func (k *KubeFinder) isIPMatchingControlPlaneEndpoints(ctx context.Context, ip s
 
 	parsedIP := net.ParseIP(ip)
 	controlPlaneCIDRPrefixLength := viper.GetInt(config.ControlPlaneIPv4CidrPrefixLength)
+	controlPlaneIPv6CIDRPrefixLength := viper.GetInt(config.ControlPlaneIPv6CidrPrefixLength)
 
 	for _, subset := range endpoints.Subsets {
 		for _, endpointAddress := range subset.Addresses {
 			// check for exact match
-			if endpointAddress.IP == ip {
+			if normalizeIP(endpointAddress.IP) == normalizeIP(ip) {
 				return true, nil
 			}
 
 			// check if IP matches the control plane CIDR
 			parsedEndpointIP := net.ParseIP(endpointAddress.IP)
+			if parsedIP == nil || parsedEndpointIP == nil {
+				continue
+			}
+			var endpointCIDR string
 			if parsedIP.To4() != nil && parsedEndpointIP.To4() != nil {
-				_, endpointNetwork, err := net.ParseCIDR(fmt.Sprintf("%s/%d", parsedEndpointIP.To4().String(), controlPlaneCIDRPrefixLength))
-				if err != nil {
-					return false, errors.Wrap(err)
-				}
+				endpointCIDR = fmt.Sprintf("%s/%d", parsedEndpointIP.To4().String(), controlPlaneCIDRPrefixLength)
+			} else if parsedIP.To4() == nil && parsedEndpointIP.To4() == nil {
+				endpointCIDR = fmt.Sprintf("%s/%d", parsedEndpointIP.String(), controlPlaneIPv6CIDRPrefixLength)
+			} else {
+				continue
+			}
 
-				if endpointNetwork.Contains(parsedIP) {
-					return true, nil
-				}
+			_, endpointNetwork, err := net.ParseCIDR(endpointCIDR)
+			if err != nil {
+				return false, errors.Wrap(err)
+			}
+
+			if endpointNetwork.Contains(parsedIP) {
+				return true, nil
 			}
 		}
 	}
@@ -342,7 +404,7 @@ func (k *KubeFinder) ResolveIPToExternalAccessService(ctx context.Context, ip st
 
 func (k *KubeFinder) resolveLoadBalancerServiceByExternalIP(ctx context.Context, ip string, port int) (*corev1.Service, bool, error) {
 	var services corev1.ServiceList
-	err := k.client.List(ctx, &services, client.MatchingFields{externalIPIndexField: ip})
+	err := k.client.List(ctx, &services, client.MatchingFields{externalIPIndexField: normalizeIP(ip)})
 	if err != nil {
 		return nil, false, errors.Wrap(err)
 	}
@@ -363,7 +425,7 @@ func (k *KubeFinder) resolveLoadBalancerServiceByExternalIP(ctx context.Context,
 
 func (k *KubeFinder) resolveServiceByNodeIPAndPort(ctx context.Context, ip string, port int) (*corev1.Service, bool, error) {
 	var nodes corev1.NodeList
-	err := k.client.List(ctx, &nodes, client.MatchingFields{nodeIPIndexField: ip})
+	err := k.client.List(ctx, &nodes, client.MatchingFields{nodeIPIndexField: normalizeIP(ip)})
 	if err != nil {
 		return nil, false, errors.Wrap(err)
 	}
@@ -391,6 +453,46 @@ func (k *KubeFinder) resolveServiceByNodeIPAndPort(ctx context.Context, ip strin
 	return &services.Items[0], true, nil
 }
 
+// ResolveServiceAccountToPods returns the running pods that use a ServiceAccount.
+func (k *KubeFinder) ResolveServiceAccountToPods(ctx context.Context, name string, namespace string) ([]corev1.Pod, error) {
+	var pods corev1.PodList
+	err := k.client.List(ctx, &pods, client.InNamespace(namespace), client.MatchingFields{podServiceAccountIndexField: name})
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	if len(pods.Items) == 0 {
+		return nil, errors.Wrap(ErrNoPodFound)
+	}
+	return pods.Items, nil
+}
+
+// ResolveContainerIDToPod returns the running pod that runs a container, by the container's ID without the
+// "<runtime>://" prefix Kubernetes reports it with.
+func (k *KubeFinder) ResolveContainerIDToPod(ctx context.Context, containerID string) (*corev1.Pod, error) {
+	var pods corev1.PodList
+	err := k.client.List(ctx, &pods, client.MatchingFields{podContainerIDIndexField: containerID})
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+
+	if len(pods.Items) == 0 {
+		return nil, errors.Wrap(ErrNoPodFound)
+	}
+
+	if len(pods.Items) != 1 {
+		return nil, errors.Wrap(ErrFoundMoreThanOnePod)
+	}
+	return &pods.Items[0], nil
+}
+
+// trimContainerRuntime strips the "<runtime>://" prefix from a container ID reported in a pod's status.
+func trimContainerRuntime(containerID string) string {
+	if _, id, found := strings.Cut(containerID, "://"); found {
+		return id
+	}
+	return containerID
+}
+
 func (k *KubeFinder) ResolveIstioWorkloadToPod(ctx context.Context, workload string, namespace string) (*corev1.Pod, error) {
 	podList := corev1.PodList{}
 	err := k.client.List(ctx, &podList, client.InNamespace(namespace), client.MatchingLabels{IstioCanonicalNameLabelKey: workload})
@@ -438,8 +540,11 @@ func (k *KubeFinder) ResolveServiceAddressToPods(ctx context.Context, fqdn strin
 
 		return pods, serviceNamespacedName, nil
 	case "pod":
-		// for address format of pods: 172-17-0-3.default.pod.cluster.local
+		// for address format of pods: 172-17-0-3.default.pod.cluster.local, or fd00-10-244--3.default.pod.cluster.local for IPv6
 		ip := strings.ReplaceAll(fqdnWithoutClusterDomainParts[0], "-", ".")
+		if net.ParseIP(ip) == nil {
+			ip = strings.ReplaceAll(fqdnWithoutClusterDomainParts[0], "-", ":")
+		}
 		pod, err := k.ResolveIPToPod(ctx, ip)
 		if err != nil {
 			return make([]corev1.Pod, 0), types.NamespacedName{}, errors.Wrap(err)
@@ -452,6 +557,16 @@ func (k *KubeFinder) ResolveServiceAddressToPods(ctx context.Context, fqdn strin
 	}
 }
 
+// normalizeIP returns the canonical string form of an IP address, so that IPv6 addresses written in different (but
+// equivalent) forms match the same index entries. Non-IP strings are returned as-is.
+func normalizeIP(ip string) string {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return ip
+	}
+	return parsedIP.String()
+}
+
 func ServiceIsAPIServer(name string, namespace string) bool {
 	return name == apiServerName && namespace == apiServerNamespace
 }
@@ -565,7 +680,7 @@ func (k *KubeFinder) IsSrcIpClusterInternal(ctx context.Context, ip string) (boo
 
 func (k *KubeFinder) IsPodIp(ctx context.Context, ip string) (bool, error) {
 	var pods corev1.PodList
-	err := k.client.List(ctx, &pods, client.MatchingFields{podIPIncludingHostNetworkIndexField: ip})
+	err := k.client.List(ctx, &pods, client.MatchingFields{podIPIncludingHostNetworkIndexField: normalizeIP(ip)})
 	if err != nil {
 		return false, errors.Wrap(err)
 	}
@@ -573,12 +688,12 @@ func (k *KubeFinder) IsPodIp(ctx context.Context, ip string) (bool, error) {
 }
 
 func (k *KubeFinder) WasPodIP(ip string) bool {
-	return k.seenIPsTTLCache.Contains(ip)
+	return k.seenIPsTTLCache.Contains(normalizeIP(ip))
 }
 
 func (k *KubeFinder) IsNodeIP(ctx context.Context, ip string) (bool, error) {
 	var nodes corev1.NodeList
-	err := k.client.List(ctx, &nodes, client.MatchingFields{nodeIPIndexField: ip})
+	err := k.client.List(ctx, &nodes, client.MatchingFields{nodeIPIndexField: normalizeIP(ip)})
 	if err != nil {
 		return false, errors.Wrap(err)
 	}
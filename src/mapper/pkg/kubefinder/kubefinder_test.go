@@ -41,6 +41,24 @@ func (s *KubeFinderTestSuite) TestResolveIpToPod() {
 
 }
 
+func (s *KubeFinderTestSuite) TestResolveIPv6ToPod() {
+	s.AddPod("test-pod-ipv6", "fd00:10:244::5", nil, nil)
+	s.Require().True(s.Mgr.GetCache().WaitForCacheSync(context.Background()))
+
+	// Non-canonical forms of the same address should resolve to the same pod
+	for _, ip := range []string{"fd00:10:244::5", "fd00:10:244:0:0:0:0:5", "FD00:10:244::5"} {
+		pod, err := s.kubeFinder.ResolveIPToPod(context.Background(), ip)
+		s.Require().NoError(err)
+		s.Require().Equal("test-pod-ipv6", pod.Name)
+	}
+
+	pods, service, err := s.kubeFinder.ResolveServiceAddressToPods(context.Background(), fmt.Sprintf("fd00-10-244--5.%s.pod.cluster.local", s.TestNamespace))
+	s.Require().NoError(err)
+	s.Require().Empty(service)
+	s.Require().Len(pods, 1)
+	s.Require().Equal("test-pod-ipv6", pods[0].Name)
+}
+
 func (s *KubeFinderTestSuite) TestResolveIpToControlPlane() {
 	endpoints := s.GetAPIServerEndpoints()
 	endpointIP := endpoints.Subsets[0].Addresses[0].IP
@@ -2,6 +2,31 @@ package metricexporter
 
 import "context"
 
+type ConnectionType string
+
+const (
+	// ConnectionTypeInternal is traffic between two workloads in the cluster.
+	ConnectionTypeInternal ConnectionType = "internal"
+	// ConnectionTypeExternal is traffic from a workload to a DNS name outside the cluster.
+	ConnectionTypeExternal ConnectionType = "external"
+	// ConnectionTypeIncoming is traffic from an IP on the internet to a workload.
+	ConnectionTypeIncoming ConnectionType = "incoming"
+)
+
+// Edge describes a connection between two nodes of the service graph. Namespaces are empty for nodes outside the
+// cluster, in which case the name is a DNS name, or "internet" for the clients of incoming traffic.
+type Edge struct {
+	Client          string
+	ClientNamespace string
+	Server          string
+	ServerNamespace string
+	ConnectionType  ConnectionType
+	IntentType      string
+	HTTPPaths       []string
+	KafkaTopics     []string
+}
+
 type EdgeMetric interface {
-	Record(ctx context.Context, from string, to string)
+	Record(ctx context.Context, edge Edge)
+	RecordTraffic(ctx context.Context, edge Edge, bytesPerSecond int, flowsPerSecond int)
 }
@@ -3,6 +3,14 @@ package metricexporter
 import (
 	"context"
 	"github.com/otterize/intents-operator/src/shared/errors"
+	"github.com/otterize/network-mapper/src/mapper/pkg/collectors/traffic"
+	"github.com/otterize/network-mapper/src/mapper/pkg/externaltrafficholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
+	"github.com/otterize/network-mapper/src/mapper/pkg/incomingtrafficholder"
+	"github.com/samber/lo"
+	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/otterize/network-mapper/src/mapper/pkg/intentsstore"
 	"github.com/sirupsen/logrus"
@@ -23,11 +31,101 @@ func NewMetricExporter(ctx context.Context) (*MetricExporter, error) {
 	}, nil
 }
 
+// maxEdgeResources is the number of HTTP paths and of Kafka topics exported per edge, bounding the series an edge adds.
+const maxEdgeResources = 20
+
+// internetClient is the client of incoming traffic edges. Incoming traffic may come from any IP on the internet, so
+// recording the source IP would add a series per IP.
+const internetClient = "internet"
+
+// pathIDSegmentRegex matches path segments that identify a single resource, such as numeric IDs, UUIDs and hashes.
+var pathIDSegmentRegex = regexp.MustCompile(`^([0-9]+|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|[0-9a-fA-F]{16,})$`)
+
+// normalizeHTTPPath replaces path segments that identify a single resource with a placeholder, so requests to different
+// resources of the same API share a path.
+func normalizeHTTPPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if pathIDSegmentRegex.MatchString(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func sortedUniq(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	values = lo.Uniq(values)
+	slices.Sort(values)
+	if len(values) > maxEdgeResources {
+		values = values[:maxEdgeResources]
+	}
+	return values
+}
+
+func intentToEdge(intent model.Intent) Edge {
+	return Edge{
+		Client:          intent.Client.Name,
+		ClientNamespace: intent.Client.Namespace,
+		Server:          intent.Server.Name,
+		ServerNamespace: intent.Server.Namespace,
+		ConnectionType:  ConnectionTypeInternal,
+		IntentType:      string(lo.FromPtr(intent.Type)),
+		HTTPPaths: sortedUniq(lo.Map(intent.HTTPResources, func(resource model.HTTPResource, _ int) string {
+			return normalizeHTTPPath(resource.Path)
+		})),
+		KafkaTopics: sortedUniq(lo.FilterMap(intent.KafkaTopics, func(topic model.KafkaConfig, _ int) (string, bool) {
+			return topic.Name, topic.IsTopic()
+		})),
+	}
+}
+
 func (o *MetricExporter) NotifyIntents(ctx context.Context, intents []intentsstore.TimestampedIntent) {
 	for _, intent := range intents {
-		clientName := intent.Intent.Client.Name
-		serverName := intent.Intent.Server.Name
-		logrus.Debugf("recording metric counter: %s -> %s", clientName, serverName)
-		o.edgeMetric.Record(ctx, clientName, serverName)
+		edge := intentToEdge(intent.Intent)
+		logrus.Debugf("recording metric counter: %s.%s -> %s.%s", edge.Client, edge.ClientNamespace, edge.Server, edge.ServerNamespace)
+		o.edgeMetric.Record(ctx, edge)
+	}
+}
+
+func (o *MetricExporter) NotifyExternalTrafficIntents(ctx context.Context, intents []externaltrafficholder.TimestampedExternalTrafficIntent) {
+	for _, intent := range intents {
+		edge := Edge{
+			Client:          intent.Intent.Client.Name,
+			ClientNamespace: intent.Intent.Client.Namespace,
+			Server:          intent.Intent.DNSName,
+			ConnectionType:  ConnectionTypeExternal,
+		}
+		logrus.Debugf("recording metric counter: %s.%s -> %s", edge.Client, edge.ClientNamespace, edge.Server)
+		o.edgeMetric.Record(ctx, edge)
+	}
+}
+
+func (o *MetricExporter) NotifyIncomingTrafficIntents(ctx context.Context, intents []incomingtrafficholder.TimestampedIncomingTrafficIntent) {
+	for _, intent := range intents {
+		edge := Edge{
+			Client:          internetClient,
+			Server:          intent.Intent.Server.Name,
+			ServerNamespace: intent.Intent.Server.Namespace,
+			ConnectionType:  ConnectionTypeIncoming,
+		}
+		logrus.Debugf("recording metric counter: %s -> %s.%s", intent.Intent.IP, edge.Server, edge.ServerNamespace)
+		o.edgeMetric.Record(ctx, edge)
+	}
+}
+
+func (o *MetricExporter) NotifyTrafficLevels(ctx context.Context, trafficLevels traffic.TrafficLevelMap) {
+	for key, data := range trafficLevels {
+		edge := Edge{
+			Client:          key.SourceName,
+			ClientNamespace: key.SourceNamespace,
+			Server:          key.DestinationName,
+			ServerNamespace: key.DestinationNamespace,
+			ConnectionType:  ConnectionTypeInternal,
+		}
+		logrus.Debugf("recording traffic gauges: %s.%s -> %s.%s", edge.Client, edge.ClientNamespace, edge.Server, edge.ServerNamespace)
+		o.edgeMetric.RecordTraffic(ctx, edge, data.Bytes, data.Flows)
 	}
 }
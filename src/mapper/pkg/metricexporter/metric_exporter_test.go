@@ -5,8 +5,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/otterize/network-mapper/src/mapper/pkg/collectors/traffic"
+	"github.com/otterize/network-mapper/src/mapper/pkg/externaltrafficholder"
 	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
+	"github.com/otterize/network-mapper/src/mapper/pkg/incomingtrafficholder"
 	"github.com/otterize/network-mapper/src/mapper/pkg/intentsstore"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
 )
@@ -52,11 +56,104 @@ func (o *MetricExporterTestSuite) addIntent(source string, srcNamespace string,
 func (o *MetricExporterTestSuite) TestExportIntents() {
 	o.addIntent("client1", o.testNamespace, "server1", o.testNamespace)
 	o.addIntent("client1", o.testNamespace, "server2", "external-namespace")
-	o.edgeMock.EXPECT().Record(context.Background(), "client1", "server1").Times(1)
-	o.edgeMock.EXPECT().Record(context.Background(), "client1", "server2").Times(1)
+	o.edgeMock.EXPECT().Record(context.Background(), Edge{
+		Client:          "client1",
+		ClientNamespace: o.testNamespace,
+		Server:          "server1",
+		ServerNamespace: o.testNamespace,
+		ConnectionType:  ConnectionTypeInternal,
+	}).Times(1)
+	o.edgeMock.EXPECT().Record(context.Background(), Edge{
+		Client:          "client1",
+		ClientNamespace: o.testNamespace,
+		Server:          "server2",
+		ServerNamespace: "external-namespace",
+		ConnectionType:  ConnectionTypeInternal,
+	}).Times(1)
 	o.metricExporter.NotifyIntents(context.Background(), o.intentsHolder.GetNewIntentsSinceLastGet())
 }
 
+func (o *MetricExporterTestSuite) TestExportIntentsWithResources() {
+	o.intentsHolder.AddIntent(
+		testTimestamp,
+		model.Intent{
+			Client: &model.OtterizeServiceIdentity{Name: "client1", Namespace: o.testNamespace},
+			Server: &model.OtterizeServiceIdentity{Name: "server1", Namespace: o.testNamespace},
+			Type:   lo.ToPtr(model.IntentTypeHTTP),
+			HTTPResources: []model.HTTPResource{
+				{Path: "/b", Methods: []model.HTTPMethod{model.HTTPMethodGet}},
+				{Path: "/a", Methods: []model.HTTPMethod{model.HTTPMethodPost}},
+				{Path: "/orders/42", Methods: []model.HTTPMethod{model.HTTPMethodGet}},
+				{Path: "/orders/3f8c2a9b-1d7e-4f6a-5b0c-9d8e7f6a5b4c", Methods: []model.HTTPMethod{model.HTTPMethodGet}},
+			},
+		},
+		make([]int64, 0),
+	)
+	o.edgeMock.EXPECT().Record(context.Background(), Edge{
+		Client:          "client1",
+		ClientNamespace: o.testNamespace,
+		Server:          "server1",
+		ServerNamespace: o.testNamespace,
+		ConnectionType:  ConnectionTypeInternal,
+		IntentType:      "HTTP",
+		HTTPPaths:       []string{"/a", "/b", "/orders/{id}"},
+	}).Times(1)
+	o.metricExporter.NotifyIntents(context.Background(), o.intentsHolder.GetNewIntentsSinceLastGet())
+}
+
+func (o *MetricExporterTestSuite) TestExportExternalAndIncomingTraffic() {
+	o.edgeMock.EXPECT().Record(context.Background(), Edge{
+		Client:          "client1",
+		ClientNamespace: o.testNamespace,
+		Server:          "example.com",
+		ConnectionType:  ConnectionTypeExternal,
+	}).Times(1)
+	o.edgeMock.EXPECT().Record(context.Background(), Edge{
+		Client:          "internet",
+		Server:          "server1",
+		ServerNamespace: o.testNamespace,
+		ConnectionType:  ConnectionTypeIncoming,
+	}).Times(1)
+
+	o.metricExporter.NotifyExternalTrafficIntents(context.Background(), []externaltrafficholder.TimestampedExternalTrafficIntent{
+		{
+			Timestamp: testTimestamp,
+			Intent: externaltrafficholder.ExternalTrafficIntent{
+				Client:  model.OtterizeServiceIdentity{Name: "client1", Namespace: o.testNamespace},
+				DNSName: "example.com",
+			},
+		},
+	})
+	o.metricExporter.NotifyIncomingTrafficIntents(context.Background(), []incomingtrafficholder.TimestampedIncomingTrafficIntent{
+		{
+			Timestamp: testTimestamp,
+			Intent: incomingtrafficholder.IncomingTrafficIntent{
+				Server: model.OtterizeServiceIdentity{Name: "server1", Namespace: o.testNamespace},
+				IP:     "1.1.1.1",
+			},
+		},
+	})
+}
+
+func (o *MetricExporterTestSuite) TestExportTrafficLevels() {
+	o.edgeMock.EXPECT().RecordTraffic(context.Background(), Edge{
+		Client:          "client1",
+		ClientNamespace: o.testNamespace,
+		Server:          "server1",
+		ServerNamespace: "external-namespace",
+		ConnectionType:  ConnectionTypeInternal,
+	}, 100, 2).Times(1)
+
+	o.metricExporter.NotifyTrafficLevels(context.Background(), traffic.TrafficLevelMap{
+		traffic.TrafficLevelKey{
+			SourceName:           "client1",
+			SourceNamespace:      o.testNamespace,
+			DestinationName:      "server1",
+			DestinationNamespace: "external-namespace",
+		}: traffic.TrafficLevelData{Bytes: 100, Flows: 2},
+	})
+}
+
 func TestRunSuite(t *testing.T) {
 	suite.Run(t, new(MetricExporterTestSuite))
 }
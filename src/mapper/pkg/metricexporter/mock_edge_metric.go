@@ -35,13 +35,25 @@ func (m *MockEdgeMetric) EXPECT() *MockEdgeMetricMockRecorder {
 }
 
 // Record mocks base method.
-func (m *MockEdgeMetric) Record(ctx context.Context, from, to string) {
+func (m *MockEdgeMetric) Record(ctx context.Context, edge Edge) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Record", ctx, from, to)
+	m.ctrl.Call(m, "Record", ctx, edge)
 }
 
 // Record indicates an expected call of Record.
-func (mr *MockEdgeMetricMockRecorder) Record(ctx, from, to interface{}) *gomock.Call {
+func (mr *MockEdgeMetricMockRecorder) Record(ctx, edge interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockEdgeMetric)(nil).Record), ctx, from, to)
-}
\ No newline at end of file
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockEdgeMetric)(nil).Record), ctx, edge)
+}
+
+// RecordTraffic mocks base method.
+func (m *MockEdgeMetric) RecordTraffic(ctx context.Context, edge Edge, bytesPerSecond, flowsPerSecond int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordTraffic", ctx, edge, bytesPerSecond, flowsPerSecond)
+}
+
+// RecordTraffic indicates an expected call of RecordTraffic.
+func (mr *MockEdgeMetricMockRecorder) RecordTraffic(ctx, edge, bytesPerSecond, flowsPerSecond interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordTraffic", reflect.TypeOf((*MockEdgeMetric)(nil).RecordTraffic), ctx, edge, bytesPerSecond, flowsPerSecond)
+}
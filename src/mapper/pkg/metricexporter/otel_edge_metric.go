@@ -3,6 +3,7 @@ package metricexporter
 import (
 	"context"
 	"github.com/otterize/intents-operator/src/shared/errors"
+	"slices"
 	"time"
 
 	"github.com/otterize/network-mapper/src/mapper/pkg/config"
@@ -18,8 +19,11 @@ import (
 )
 
 type OtelEdgeMetric struct {
-	meterProvider metric.MeterProvider
-	counter       metric.Int64Counter
+	meterProvider   metric.MeterProvider
+	counter         metric.Int64Counter
+	resourceCounter metric.Int64Counter
+	bytesGauge      metric.Int64Gauge
+	flowsGauge      metric.Int64Gauge
 }
 
 func newResource() (*resource.Resource, error) {
@@ -31,6 +35,17 @@ func newResource() (*resource.Resource, error) {
 
 const ClientAttributeName = "client"
 const ServerAttributeName = "server"
+const ClientNamespaceAttributeName = "client_namespace"
+const ServerNamespaceAttributeName = "server_namespace"
+const ConnectionTypeAttributeName = "connection_type"
+const IntentTypeAttributeName = "intent_type"
+const ResourceTypeAttributeName = "resource_type"
+const ResourceAttributeName = "resource"
+
+const (
+	ResourceTypeHTTPPath   = "http_path"
+	ResourceTypeKafkaTopic = "kafka_topic"
+)
 
 func newMeterProvider(ctx context.Context, res *resource.Resource) (*sdk.MeterProvider, error) {
 	// SDK automatically configured via environment variables:
@@ -63,8 +78,45 @@ func newMeterProvider(ctx context.Context, res *resource.Resource) (*sdk.MeterPr
 	return meterProvider, nil
 }
 
-func (o *OtelEdgeMetric) Record(ctx context.Context, from string, to string) {
-	o.counter.Add(ctx, 1, metric.WithAttributes(attribute.String(ClientAttributeName, from), attribute.String(ServerAttributeName, to)))
+func edgeAttributes(edge Edge) []attribute.KeyValue {
+	attributes := []attribute.KeyValue{
+		attribute.String(ClientAttributeName, edge.Client),
+		attribute.String(ServerAttributeName, edge.Server),
+		attribute.String(ClientNamespaceAttributeName, edge.ClientNamespace),
+		attribute.String(ServerNamespaceAttributeName, edge.ServerNamespace),
+		attribute.String(ConnectionTypeAttributeName, string(edge.ConnectionType)),
+	}
+	// Only set for the edges they apply to, to avoid adding empty dimensions to every series.
+	if edge.IntentType != "" {
+		attributes = append(attributes, attribute.String(IntentTypeAttributeName, edge.IntentType))
+	}
+	return attributes
+}
+
+// Record counts the edge, and each of its HTTP paths and Kafka topics on a separate metric, so that the edge counter's
+// series do not change as resources are added to the edge.
+func (o *OtelEdgeMetric) Record(ctx context.Context, edge Edge) {
+	attributes := edgeAttributes(edge)
+	o.counter.Add(ctx, 1, metric.WithAttributes(attributes...))
+
+	recordResource := func(resourceType string, resource string) {
+		o.resourceCounter.Add(ctx, 1, metric.WithAttributes(append(slices.Clone(attributes),
+			attribute.String(ResourceTypeAttributeName, resourceType),
+			attribute.String(ResourceAttributeName, resource),
+		)...))
+	}
+	for _, path := range edge.HTTPPaths {
+		recordResource(ResourceTypeHTTPPath, path)
+	}
+	for _, topic := range edge.KafkaTopics {
+		recordResource(ResourceTypeKafkaTopic, topic)
+	}
+}
+
+func (o *OtelEdgeMetric) RecordTraffic(ctx context.Context, edge Edge, bytesPerSecond int, flowsPerSecond int) {
+	attributes := metric.WithAttributes(edgeAttributes(edge)...)
+	o.bytesGauge.Record(ctx, int64(bytesPerSecond), attributes)
+	o.flowsGauge.Record(ctx, int64(flowsPerSecond), attributes)
 }
 
 func NewOtelEdgeMetric(ctx context.Context) (*OtelEdgeMetric, error) {
@@ -87,8 +139,37 @@ func NewOtelEdgeMetric(ctx context.Context) (*OtelEdgeMetric, error) {
 		return nil, errors.Wrap(err)
 	}
 
+	resourceCounter, err := meter.Int64Counter(
+		viper.GetString(config.OTelResourceMetricKey),
+		metric.WithDescription("Count of HTTP paths and Kafka topics accessed over edges between two nodes"),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+
+	bytesGauge, err := meter.Int64Gauge(
+		viper.GetString(config.OTelTrafficBytesMetricKey),
+		metric.WithDescription("Average bytes per second sent between two nodes over the last hour"),
+		metric.WithUnit("By/s"),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+
+	flowsGauge, err := meter.Int64Gauge(
+		viper.GetString(config.OTelTrafficFlowsMetricKey),
+		metric.WithDescription("Average flows per second between two nodes over the last hour"),
+		metric.WithUnit("{flow}/s"),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+
 	return &OtelEdgeMetric{
-		counter:       edgeCounter,
-		meterProvider: meterProvider,
+		counter:         edgeCounter,
+		resourceCounter: resourceCounter,
+		bytesGauge:      bytesGauge,
+		flowsGauge:      flowsGauge,
+		meterProvider:   meterProvider,
 	}, nil
 }
@@ -0,0 +1,69 @@
+package persistentstore
+
+import (
+	"context"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"os"
+	"path/filepath"
+)
+
+// Backend stores opaque snapshots by name. Implementations must make Save atomic, so that a crash mid-write never
+// leaves a partially written snapshot behind.
+type Backend interface {
+	Save(ctx context.Context, name string, data []byte) error
+	Load(ctx context.Context, name string) ([]byte, bool, error)
+}
+
+const snapshotFileExtension = ".json"
+
+// FileBackend keeps each snapshot as a file in a local directory, typically a mounted volume.
+type FileBackend struct {
+	dir string
+}
+
+func NewFileBackend(dir string) (*FileBackend, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, errors.Wrap(err)
+	}
+	return &FileBackend{dir: dir}, nil
+}
+
+func (b *FileBackend) path(name string) string {
+	return filepath.Join(b.dir, name+snapshotFileExtension)
+}
+
+func (b *FileBackend) Save(_ context.Context, name string, data []byte) error {
+	tmpFile, err := os.CreateTemp(b.dir, name+"-*.tmp")
+	if err != nil {
+		return errors.Wrap(err)
+	}
+	// Removing the temp file after a successful rename is a no-op.
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		return errors.Wrap(err)
+	}
+	if err := tmpFile.Sync(); err != nil {
+		_ = tmpFile.Close()
+		return errors.Wrap(err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return errors.Wrap(err)
+	}
+	if err := os.Rename(tmpFile.Name(), b.path(name)); err != nil {
+		return errors.Wrap(err)
+	}
+	return nil
+}
+
+func (b *FileBackend) Load(_ context.Context, name string) ([]byte, bool, error) {
+	data, err := os.ReadFile(b.path(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, errors.Wrap(err)
+	}
+	return data, true, nil
+}
@@ -0,0 +1,97 @@
+package persistentstore
+
+import (
+	"context"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// Snapshottable is implemented by the in-memory holders whose content should survive mapper restarts.
+// Entries last seen before the cutoff are considered stale, and must be dropped rather than snapshotted or restored.
+type Snapshottable interface {
+	Snapshot(cutoff time.Time) ([]byte, error)
+	Restore(data []byte, cutoff time.Time) error
+}
+
+type registeredHolder struct {
+	name   string
+	holder Snapshottable
+}
+
+type Store struct {
+	backend   Backend
+	retention time.Duration
+	holders   []registeredHolder
+}
+
+func NewStore(backend Backend, retention time.Duration) *Store {
+	return &Store{
+		backend:   backend,
+		retention: retention,
+		holders:   make([]registeredHolder, 0),
+	}
+}
+
+func (s *Store) Register(name string, holder Snapshottable) {
+	s.holders = append(s.holders, registeredHolder{name: name, holder: holder})
+}
+
+func (s *Store) cutoff() time.Time {
+	return time.Now().Add(-s.retention)
+}
+
+// Restore loads the last snapshot of every registered holder. A holder whose snapshot is missing or corrupt starts
+// out empty, and does not prevent the other holders from being restored.
+func (s *Store) Restore(ctx context.Context) {
+	cutoff := s.cutoff()
+	for _, h := range s.holders {
+		data, found, err := s.backend.Load(ctx, h.name)
+		if err != nil {
+			logrus.WithError(err).WithField("holder", h.name).Error("Failed loading snapshot")
+			continue
+		}
+		if !found {
+			logrus.WithField("holder", h.name).Info("No snapshot found, starting with an empty store")
+			continue
+		}
+		if err := h.holder.Restore(data, cutoff); err != nil {
+			logrus.WithError(err).WithField("holder", h.name).Error("Failed restoring snapshot")
+			continue
+		}
+		logrus.WithField("holder", h.name).Info("Restored snapshot")
+	}
+}
+
+func (s *Store) Snapshot(ctx context.Context) error {
+	cutoff := s.cutoff()
+	for _, h := range s.holders {
+		data, err := h.holder.Snapshot(cutoff)
+		if err != nil {
+			return errors.Wrap(err)
+		}
+		if err := s.backend.Save(ctx, h.name, data); err != nil {
+			return errors.Wrap(err)
+		}
+	}
+	return nil
+}
+
+func (s *Store) PeriodicSnapshot(ctx context.Context, interval time.Duration) {
+	logrus.Info("Starting periodic intents snapshot")
+
+	for {
+		select {
+		case <-time.After(interval):
+			if err := s.Snapshot(ctx); err != nil {
+				logrus.WithError(err).Error("Failed snapshotting intents")
+			}
+		case <-ctx.Done():
+			// Take a final snapshot so that a graceful shutdown loses nothing; the parent context is already done.
+			if err := s.Snapshot(context.Background()); err != nil {
+				logrus.WithError(err).Error("Failed snapshotting intents on shutdown")
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package persistentstore
+
+import (
+	"context"
+	"github.com/otterize/network-mapper/src/mapper/pkg/awsintentsholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
+	"github.com/otterize/network-mapper/src/mapper/pkg/intentsstore"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+const (
+	testRetention = time.Hour
+	testNamespace = "test-namespace"
+)
+
+type PersistentStoreSuite struct {
+	suite.Suite
+	backend *FileBackend
+}
+
+func (s *PersistentStoreSuite) SetupTest() {
+	backend, err := NewFileBackend(s.T().TempDir())
+	s.Require().NoError(err)
+	s.backend = backend
+}
+
+func (s *PersistentStoreSuite) TestFileBackendSaveAndLoad() {
+	ctx := context.Background()
+
+	_, found, err := s.backend.Load(ctx, "intents")
+	s.Require().NoError(err)
+	s.Require().False(found)
+
+	s.Require().NoError(s.backend.Save(ctx, "intents", []byte("first")))
+	s.Require().NoError(s.backend.Save(ctx, "intents", []byte("second")))
+
+	data, found, err := s.backend.Load(ctx, "intents")
+	s.Require().NoError(err)
+	s.Require().True(found)
+	s.Require().Equal("second", string(data))
+}
+
+func (s *PersistentStoreSuite) TestIntentsSurviveRestart() {
+	ctx := context.Background()
+	now := time.Now()
+
+	holder := intentsstore.NewIntentsHolder()
+	holder.AddIntent(now, model.Intent{
+		Client: &model.OtterizeServiceIdentity{Name: "client", Namespace: testNamespace},
+		Server: &model.OtterizeServiceIdentity{Name: "server", Namespace: testNamespace},
+		Type:   lo.ToPtr(model.IntentTypeHTTP),
+		HTTPResources: []model.HTTPResource{
+			{Path: "/api", Methods: []model.HTTPMethod{model.HTTPMethodGet}},
+		},
+	}, nil)
+	holder.AddIntent(now.Add(-2*testRetention), model.Intent{
+		Client: &model.OtterizeServiceIdentity{Name: "stale-client", Namespace: testNamespace},
+		Server: &model.OtterizeServiceIdentity{Name: "server", Namespace: testNamespace},
+	}, nil)
+	awsHolder := awsintentsholder.New()
+	awsHolder.AddIntent(awsintentsholder.AWSIntent{
+		Client:  model.OtterizeServiceIdentity{Name: "client", Namespace: testNamespace},
+		Actions: []string{"s3:GetObject"},
+		ARN:     "arn:aws:s3:::bucket",
+	})
+
+	store := NewStore(s.backend, testRetention)
+	store.Register("intents", holder)
+	store.Register("aws", awsHolder)
+	s.Require().NoError(store.Snapshot(ctx))
+
+	restoredHolder := intentsstore.NewIntentsHolder()
+	restoredAWSHolder := awsintentsholder.New()
+	restoredStore := NewStore(s.backend, testRetention)
+	restoredStore.Register("intents", restoredHolder)
+	restoredStore.Register("aws", restoredAWSHolder)
+	restoredStore.Restore(ctx)
+
+	intents, err := restoredHolder.GetIntents(nil, nil, nil, true, nil, nil, nil)
+	s.Require().NoError(err)
+	s.Require().Len(intents, 1)
+	s.Require().Equal("client", intents[0].Intent.Client.Name)
+	s.Require().Equal("server", intents[0].Intent.Server.Name)
+	s.Require().Equal(model.IntentTypeHTTP, lo.FromPtr(intents[0].Intent.Type))
+	s.Require().Len(intents[0].Intent.HTTPResources, 1)
+	s.Require().True(now.Equal(intents[0].Timestamp))
+
+	// Intents not yet reported are restored as such, so that they are still uploaded after the restart
+	s.Require().Len(restoredHolder.GetNewIntentsSinceLastGet(), 1)
+
+	awsIntents := restoredAWSHolder.GetNewIntentsSinceLastGet()
+	s.Require().Len(awsIntents, 1)
+	s.Require().Equal("arn:aws:s3:::bucket", awsIntents[0].ARN)
+	s.Require().Equal([]string{"s3:GetObject"}, awsIntents[0].Actions)
+}
+
+func (s *PersistentStoreSuite) TestRestoreSkipsMissingAndCorruptSnapshots() {
+	ctx := context.Background()
+	s.Require().NoError(s.backend.Save(ctx, "aws", []byte("not json")))
+
+	holder := intentsstore.NewIntentsHolder()
+	awsHolder := awsintentsholder.New()
+	store := NewStore(s.backend, testRetention)
+	store.Register("intents", holder)
+	store.Register("aws", awsHolder)
+	store.Restore(ctx)
+
+	intents, err := holder.GetIntents(nil, nil, nil, true, nil, nil, nil)
+	s.Require().NoError(err)
+	s.Require().Empty(intents)
+	s.Require().Empty(awsHolder.GetNewIntentsSinceLastGet())
+}
+
+func TestPersistentStoreSuite(t *testing.T) {
+	suite.Run(t, new(PersistentStoreSuite))
+}
@@ -18,6 +18,10 @@ var (
 		Name: "tcp_reported_connections",
 		Help: "The total number of TCP-sourced reported connections",
 	})
+	tlsCaptureReports = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "tls_reported_connections",
+		Help: "The total number of TLS SNI-sourced reported connections",
+	})
 	kafkaReports = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "kafka_reported_topics",
 		Help: "The total number of Kafka-sourced topics",
@@ -26,6 +30,18 @@ var (
 		Name: "istio_reported_connections",
 		Help: "The total number of Istio-sourced connections",
 	})
+	httpReports = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_reported_requests",
+		Help: "The total number of HTTP-sourced requests",
+	})
+	linkerdReports = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "linkerd_reported_connections",
+		Help: "The total number of Linkerd-sourced connections",
+	})
+	accessLogReports = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "accesslog_reported_requests",
+		Help: "The total number of access log-sourced requests",
+	})
 
 	socketScanDrops = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "socketscan_dropped_connections",
@@ -39,6 +55,10 @@ var (
 		Name: "tcp_dropped_connections",
 		Help: "The total number of TCP-sourced reported connections that were dropped for performance",
 	})
+	tlsCaptureDrops = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "tls_dropped_connections",
+		Help: "The total number of TLS SNI-sourced reported connections that were dropped for performance",
+	})
 	kafkaReportsDrops = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "kafka_dropped_topics",
 		Help: "The total number of Kafka-sourced reported topics that were dropped for performance",
@@ -47,6 +67,18 @@ var (
 		Name: "istio_dropped_connections",
 		Help: "The total number of Istio-sourced reported connections that were dropped for performance",
 	})
+	httpReportsDrops = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_dropped_requests",
+		Help: "The total number of HTTP-sourced reported requests that were dropped for performance",
+	})
+	linkerdReportsDrops = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "linkerd_dropped_connections",
+		Help: "The total number of Linkerd-sourced reported connections that were dropped for performance",
+	})
+	accessLogReportsDrops = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "accesslog_dropped_requests",
+		Help: "The total number of access log-sourced reported requests that were dropped for performance",
+	})
 
 	awsReports = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "aws_reports",
@@ -74,6 +106,24 @@ var (
 		Name: "azure_dropped_reports",
 		Help: "The total number of Azure operations reported that were dropped for performance",
 	})
+
+	subscriptionDrops = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "subscription_dropped_intents",
+		Help: "The total number of discovered intents that were not sent to a GraphQL subscriber because it was too slow",
+	})
+
+	intentsDiffMissing = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "intents_diff_missing",
+		Help: "The number of servers a client was seen calling, that are missing from its applied ClientIntents",
+	}, []string{"namespace", "workload"})
+	intentsDiffUnused = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "intents_diff_unused",
+		Help: "The number of targets in a client's applied ClientIntents, that the client was not seen calling",
+	}, []string{"namespace", "workload"})
+	intentsDiffMatching = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "intents_diff_matching",
+		Help: "The number of servers a client was seen calling, that match a target in its applied ClientIntents",
+	}, []string{"namespace", "workload"})
 )
 
 func IncrementTCPCaptureReports(count int) {
@@ -84,6 +134,14 @@ func IncrementTCPCaptureDrops(count int) {
 	tcpCaptureDrops.Add(float64(count))
 }
 
+func IncrementTLSCaptureReports(count int) {
+	tlsCaptureReports.Add(float64(count))
+}
+
+func IncrementTLSCaptureDrops(count int) {
+	tlsCaptureDrops.Add(float64(count))
+}
+
 func IncrementSocketScanReports(count int) {
 	socketScanReports.Add(float64(count))
 }
@@ -100,6 +158,18 @@ func IncrementIstioReports(count int) {
 	istioReports.Add(float64(count))
 }
 
+func IncrementHTTPReports(count int) {
+	httpReports.Add(float64(count))
+}
+
+func IncrementLinkerdReports(count int) {
+	linkerdReports.Add(float64(count))
+}
+
+func IncrementAccessLogReports(count int) {
+	accessLogReports.Add(float64(count))
+}
+
 func IncrementAWSOperationReports(count int) {
 	awsReports.Add(float64(count))
 }
@@ -124,6 +194,18 @@ func IncrementIstioDrops(count int) {
 	istioReportsDrops.Add(float64(count))
 }
 
+func IncrementHTTPDrops(count int) {
+	httpReportsDrops.Add(float64(count))
+}
+
+func IncrementLinkerdDrops(count int) {
+	linkerdReportsDrops.Add(float64(count))
+}
+
+func IncrementAccessLogDrops(count int) {
+	accessLogReportsDrops.Add(float64(count))
+}
+
 func IncrementAWSOperationDrops(count int) {
 	awsReportsDrops.Add(float64(count))
 }
@@ -139,3 +221,20 @@ func IncrementAzureOperationReports(count int) {
 func IncrementAzureOperationDrops(count int) {
 	azureReportsDrops.Add(float64(count))
 }
+
+func IncrementSubscriptionDrops(count int) {
+	subscriptionDrops.Add(float64(count))
+}
+
+func SetIntentsDiff(namespace string, workload string, missing int, unused int, matching int) {
+	intentsDiffMissing.WithLabelValues(namespace, workload).Set(float64(missing))
+	intentsDiffUnused.WithLabelValues(namespace, workload).Set(float64(unused))
+	intentsDiffMatching.WithLabelValues(namespace, workload).Set(float64(matching))
+}
+
+// ResetIntentsDiff removes the intents diff of all clients, so that clients that no longer exist stop being reported.
+func ResetIntentsDiff() {
+	intentsDiffMissing.Reset()
+	intentsDiffUnused.Reset()
+	intentsDiffMatching.Reset()
+}
@@ -0,0 +1,127 @@
+package resolvers
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"github.com/otterize/network-mapper/src/mapper/pkg/accesslogs"
+	"github.com/otterize/network-mapper/src/mapper/pkg/concurrentconnectioncounter"
+	"github.com/otterize/network-mapper/src/mapper/pkg/config"
+	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
+	"github.com/otterize/network-mapper/src/mapper/pkg/prometheus"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"net"
+	"net/http"
+)
+
+// ReportAccessLogEntries queues access log entries for handling, dropping them if the queue is full.
+func (r *Resolver) ReportAccessLogEntries(ctx context.Context, entries accesslogs.Entries) bool {
+	select {
+	case r.accessLogEntries <- entries:
+		prometheus.IncrementAccessLogReports(len(entries))
+		return true
+	case <-ctx.Done():
+		return false
+	default:
+		prometheus.IncrementAccessLogDrops(len(entries))
+		return false
+	}
+}
+
+// handlePostAccessLogs receives Envoy JSON access log entries shipped by an HTTP log collector.
+func (r *Resolver) handlePostAccessLogs(c echo.Context) error {
+	body := http.MaxBytesReader(c.Response(), c.Request().Body, viper.GetInt64(config.AccessLogMaxRequestBytesKey))
+	entries, err := accesslogs.ParseJSONEntries(body, viper.GetInt(config.AccessLogMaxRequestEntriesKey))
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) || errors.Is(err, accesslogs.ErrTooManyEntries) {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, err.Error())
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if len(entries) > 0 && !r.ReportAccessLogEntries(c.Request().Context(), entries) {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "access log queue is full")
+	}
+	return c.NoContent(http.StatusAccepted)
+}
+
+// resolveAccessLogServer resolves the server of a request logged by a proxy, which is the upstream host it was forwarded
+// to, or the authority it was addressed to if the upstream host was not logged.
+func (r *Resolver) resolveAccessLogServer(ctx context.Context, entry accesslogs.Entry) (model.OtterizeServiceIdentity, bool) {
+	if entry.DstIP != "" {
+		return r.resolveHTTPPeerIdentity(ctx, entry.DstIP, entry.LastSeen)
+	}
+
+	host, _, err := net.SplitHostPort(entry.Authority)
+	if err != nil {
+		host = entry.Authority
+	}
+	identity, ok, err := r.resolveOtterizeIdentityForServiceAddress(ctx, model.Destination{Destination: host, LastSeen: entry.LastSeen})
+	if err != nil {
+		logrus.WithError(err).Debugf("Could not resolve authority %s to identity", entry.Authority)
+		return model.OtterizeServiceIdentity{}, false
+	}
+	if !ok {
+		return model.OtterizeServiceIdentity{}, false
+	}
+	return *identity, true
+}
+
+// accessLogHops returns the client and server of each hop a request logged by a proxy took: from the client to the
+// proxy, and from the proxy to the server. Requests from outside the cluster, such as those arriving at a gateway, only
+// take the second hop. When the proxy can't be resolved, the request is attributed directly from client to server.
+func (r *Resolver) accessLogHops(ctx context.Context, entry accesslogs.Entry) [][2]model.OtterizeServiceIdentity {
+	dstSvcIdentity, dstOk := r.resolveAccessLogServer(ctx, entry)
+	srcSvcIdentity, srcOk := r.resolveHTTPPeerIdentity(ctx, entry.SrcIP, entry.LastSeen)
+	proxySvcIdentity, proxyOk := model.OtterizeServiceIdentity{}, false
+	if entry.ProxyIP != "" {
+		proxySvcIdentity, proxyOk = r.resolveHTTPPeerIdentity(ctx, entry.ProxyIP, entry.LastSeen)
+	}
+
+	if !proxyOk {
+		if srcOk && dstOk {
+			return [][2]model.OtterizeServiceIdentity{{srcSvcIdentity, dstSvcIdentity}}
+		}
+		return nil
+	}
+
+	hops := make([][2]model.OtterizeServiceIdentity, 0, 2)
+	// A sidecar proxy logs its own workload's requests, which take a single hop
+	if srcOk && srcSvcIdentity.AsNamespacedName() != proxySvcIdentity.AsNamespacedName() {
+		hops = append(hops, [2]model.OtterizeServiceIdentity{srcSvcIdentity, proxySvcIdentity})
+	}
+	if dstOk {
+		hops = append(hops, [2]model.OtterizeServiceIdentity{proxySvcIdentity, dstSvcIdentity})
+	}
+	return hops
+}
+
+func (r *Resolver) handleAccessLogEntries(ctx context.Context, entries accesslogs.Entries) error {
+	var newResults int
+	for _, entry := range entries {
+		method := model.HTTPMethod(entry.Method)
+		if !method.IsValid() || method == model.HTTPMethodAll {
+			continue
+		}
+
+		for _, hop := range r.accessLogHops(ctx, entry) {
+			intent := model.Intent{
+				Client:         &hop[0],
+				Server:         &hop[1],
+				Type:           lo.ToPtr(model.IntentTypeHTTP),
+				HTTPResources:  []model.HTTPResource{{Path: entry.Path, Methods: []model.HTTPMethod{method}}},
+				ResolutionData: lo.ToPtr(concurrentconnectioncounter.AccessLogIntentResolution),
+			}
+
+			updateTelemetriesCounters(SourceTypeAccessLog, intent)
+			r.intentsHolder.AddIntent(entry.LastSeen, intent, make([]int64, 0))
+			newResults++
+		}
+	}
+
+	prometheus.IncrementAccessLogReports(newResults)
+	r.gotResultsSignal()
+	return nil
+}
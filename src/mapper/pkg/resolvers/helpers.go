@@ -16,6 +16,18 @@ const LinkerdSidecarContainerName = "linkerd-proxy"
 var SourceIsHostNetworkPodError = errors.NewSentinelError("source is a host network pod, ignoring")
 
 func (r *Resolver) discoverInternalSrcIdentity(ctx context.Context, src *model.RecordedDestinationsForSrc) (model.OtterizeServiceIdentity, error) {
+	if src.SrcContainerID != nil && *src.SrcContainerID != "" {
+		srcPod, err := r.kubeFinder.ResolveContainerIDToPod(ctx, *src.SrcContainerID)
+		if err == nil {
+			return r.resolveSrcPodIdentity(ctx, src, srcPod)
+		}
+		if !errors.Is(err, kubefinder.ErrNoPodFound) {
+			return model.OtterizeServiceIdentity{}, errors.Errorf("could not resolve container %s to pod: %w", *src.SrcContainerID, err)
+		}
+		// The container may have been reported before its pod's status was updated, fall back to the source IP
+		logrus.Debugf("No pod found for container %s, resolving source by IP %s", *src.SrcContainerID, src.SrcIP)
+	}
+
 	svc, ok, err := r.kubeFinder.ResolveIPToControlPlane(ctx, src.SrcIP)
 	if err != nil {
 		return model.OtterizeServiceIdentity{}, errors.Errorf("could not resolve %s to service: %w", src.SrcIP, err)
@@ -51,6 +63,14 @@ func (r *Resolver) discoverInternalSrcIdentity(ctx context.Context, src *model.R
 		return model.OtterizeServiceIdentity{}, errors.Errorf("found pod %s (by ip %s) doesn't match captured hostname %s, ignoring", srcPod.Name, src.SrcIP, src.SrcHostname)
 	}
 
+	return r.resolveSrcPodIdentity(ctx, src, srcPod)
+}
+
+func (r *Resolver) resolveSrcPodIdentity(ctx context.Context, src *model.RecordedDestinationsForSrc, srcPod *corev1.Pod) (model.OtterizeServiceIdentity, error) {
+	if srcPod.Spec.HostNetwork {
+		return model.OtterizeServiceIdentity{}, SourceIsHostNetworkPodError
+	}
+
 	// This function requires "src" to be a pointer.
 	// If at some point this function will be called with a non-pointer "src"
 	// It may cause a bug because the function will not be able to modify the "src" object of the caller.
@@ -7,19 +7,26 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/otterize/intents-operator/src/shared/errors"
 	"github.com/otterize/intents-operator/src/shared/serviceidresolver"
+	"github.com/otterize/network-mapper/src/mapper/pkg/accesslogs"
 	"github.com/otterize/network-mapper/src/mapper/pkg/awsintentsholder"
 	"github.com/otterize/network-mapper/src/mapper/pkg/azureintentsholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/clientintentsgenerator"
 	"github.com/otterize/network-mapper/src/mapper/pkg/collectors/traffic"
+	"github.com/otterize/network-mapper/src/mapper/pkg/config"
 	"github.com/otterize/network-mapper/src/mapper/pkg/dnscache"
 	"github.com/otterize/network-mapper/src/mapper/pkg/externaltrafficholder"
 	"github.com/otterize/network-mapper/src/mapper/pkg/gcpintentsholder"
 	"github.com/otterize/network-mapper/src/mapper/pkg/graph/generated"
 	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
 	"github.com/otterize/network-mapper/src/mapper/pkg/incomingtrafficholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/intentsbroadcaster"
+	"github.com/otterize/network-mapper/src/mapper/pkg/intentsdiff"
 	"github.com/otterize/network-mapper/src/mapper/pkg/intentsstore"
 	"github.com/otterize/network-mapper/src/mapper/pkg/kubefinder"
 	"github.com/otterize/network-mapper/src/shared/isrunningonaws"
+	"github.com/spf13/viper"
 	"golang.org/x/sync/errgroup"
+	"net/http"
 )
 
 // This file will not be regenerated automatically.
@@ -35,13 +42,20 @@ type Resolver struct {
 	awsIntentsHolder             *awsintentsholder.AWSIntentsHolder
 	gcpIntentsHolder             *gcpintentsholder.GCPIntentsHolder
 	azureIntentsHolder           *azureintentsholder.AzureIntentsHolder
+	clientIntentsGenerator       *clientintentsgenerator.Generator
+	intentsDiffer                *intentsdiff.Differ
+	intentsBroadcaster           *intentsbroadcaster.Broadcaster
 	dnsCache                     *dnscache.DNSCache
 	trafficCollector             *traffic.Collector
 	dnsCaptureResults            chan model.CaptureResults
 	tcpCaptureResults            chan model.CaptureTCPResults
+	tlsCaptureResults            chan model.CaptureTLSResults
 	socketScanResults            chan model.SocketScanResults
 	kafkaMapperResults           chan model.KafkaMapperResults
 	istioConnectionResults       chan model.IstioConnectionResults
+	httpRequestResults           chan model.HTTPRequestResults
+	linkerdConnectionResults     chan model.LinkerdConnectionResults
+	accessLogEntries             chan accesslogs.Entries
 	awsOperations                chan model.AWSOperationResults
 	gcpOperations                chan model.GCPOperationResults
 	azureOperations              chan model.AzureOperationResults
@@ -62,6 +76,7 @@ func NewResolver(
 	dnsCache *dnscache.DNSCache,
 	incomingTrafficHolder *incomingtrafficholder.IncomingTrafficIntentsHolder,
 	trafficCollector *traffic.Collector,
+	intentsDiffer *intentsdiff.Differ,
 ) *Resolver {
 	r := &Resolver{
 		kubeFinder:                   kubeFinder,
@@ -71,9 +86,13 @@ func NewResolver(
 		incomingTrafficHolder:        incomingTrafficHolder,
 		dnsCaptureResults:            make(chan model.CaptureResults, 200),
 		tcpCaptureResults:            make(chan model.CaptureTCPResults, 200),
+		tlsCaptureResults:            make(chan model.CaptureTLSResults, 200),
 		socketScanResults:            make(chan model.SocketScanResults, 200),
 		kafkaMapperResults:           make(chan model.KafkaMapperResults, 200),
 		istioConnectionResults:       make(chan model.IstioConnectionResults, 200),
+		httpRequestResults:           make(chan model.HTTPRequestResults, 200),
+		linkerdConnectionResults:     make(chan model.LinkerdConnectionResults, 200),
+		accessLogEntries:             make(chan accesslogs.Entries, 200),
 		awsOperations:                make(chan model.AWSOperationResults, 200),
 		azureOperations:              make(chan model.AzureOperationResults, 200),
 		gcpOperations:                make(chan model.GCPOperationResults, 200),
@@ -81,11 +100,17 @@ func NewResolver(
 		awsIntentsHolder:             awsIntentsHolder,
 		gcpIntentsHolder:             gcpIntentsHolder,
 		azureIntentsHolder:           azureIntentsHolder,
+		clientIntentsGenerator:       clientintentsgenerator.NewGenerator(intentsHolder, externalTrafficHolder, awsIntentsHolder, gcpIntentsHolder, azureIntentsHolder),
 		trafficCollector:             trafficCollector,
+		intentsDiffer:                intentsDiffer,
+		intentsBroadcaster:           intentsbroadcaster.NewBroadcaster(),
 		dnsCache:                     dnsCache,
 		isRunningOnAws:               isrunningonaws.Check(),
 	}
 	r.gotResultsCtx, r.gotResultsSignal = context.WithCancel(context.Background())
+	intentsHolder.RegisterNotifyNewIntent(r.intentsBroadcaster.NotifyNewIntent)
+	externalTrafficHolder.RegisterNotifyNewIntent(r.intentsBroadcaster.NotifyNewExternalTrafficIntent)
+	incomingTrafficHolder.RegisterNotifyNewIntent(r.intentsBroadcaster.NotifyNewIncomingTrafficIntent)
 
 	return r
 }
@@ -97,6 +122,20 @@ func (r *Resolver) Register(e *echo.Echo) {
 		srv.ServeHTTP(c.Response(), c.Request())
 		return nil
 	})
+	e.GET("/clientintents", r.handleGetClientIntents)
+	if viper.GetBool(config.EnableAccessLogReceiverKey) {
+		e.POST("/accesslogs", r.handlePostAccessLogs)
+	}
+}
+
+// handleGetClientIntents serves the discovered intents as ClientIntents YAML, optionally filtered by one or more
+// "namespace" query params.
+func (r *Resolver) handleGetClientIntents(c echo.Context) error {
+	clientIntentsYAML, err := r.clientIntentsGenerator.GenerateYAML(c.QueryParams()["namespace"])
+	if err != nil {
+		return errors.Wrap(err)
+	}
+	return c.Blob(http.StatusOK, "application/yaml", []byte(clientIntentsYAML))
 }
 
 func (r *Resolver) RunForever(ctx context.Context) error {
@@ -109,6 +148,10 @@ func (r *Resolver) RunForever(ctx context.Context) error {
 		defer bugsnag.AutoNotify(errGrpCtx)
 		return runHandleLoop(errGrpCtx, r.tcpCaptureResults, r.handleReportTCPCaptureResults)
 	})
+	errgrp.Go(func() error {
+		defer bugsnag.AutoNotify(errGrpCtx)
+		return runHandleLoop(errGrpCtx, r.tlsCaptureResults, r.handleReportTLSCaptureResults)
+	})
 	errgrp.Go(func() error {
 		defer bugsnag.AutoNotify(errGrpCtx)
 		return runHandleLoop(errGrpCtx, r.socketScanResults, r.handleReportSocketScanResults)
@@ -121,6 +164,18 @@ func (r *Resolver) RunForever(ctx context.Context) error {
 		defer bugsnag.AutoNotify(errGrpCtx)
 		return runHandleLoop(errGrpCtx, r.istioConnectionResults, r.handleReportIstioConnectionResults)
 	})
+	errgrp.Go(func() error {
+		defer bugsnag.AutoNotify(errGrpCtx)
+		return runHandleLoop(errGrpCtx, r.httpRequestResults, r.handleReportHTTPRequestResults)
+	})
+	errgrp.Go(func() error {
+		defer bugsnag.AutoNotify(errGrpCtx)
+		return runHandleLoop(errGrpCtx, r.linkerdConnectionResults, r.handleReportLinkerdConnectionResults)
+	})
+	errgrp.Go(func() error {
+		defer bugsnag.AutoNotify(errGrpCtx)
+		return runHandleLoop(errGrpCtx, r.accessLogEntries, r.handleAccessLogEntries)
+	})
 	errgrp.Go(func() error {
 		defer bugsnag.AutoNotify(errGrpCtx)
 		return runHandleLoop(errGrpCtx, r.awsOperations, r.handleAWSOperationReport)
@@ -7,6 +7,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/otterize/intents-operator/src/shared/errors"
 	"github.com/otterize/intents-operator/src/shared/serviceidresolver"
+	"github.com/otterize/network-mapper/src/mapper/pkg/accesslogs"
 	"github.com/otterize/network-mapper/src/mapper/pkg/awsintentsholder"
 	"github.com/otterize/network-mapper/src/mapper/pkg/azureintentsholder"
 	"github.com/otterize/network-mapper/src/mapper/pkg/collectors/traffic"
@@ -16,13 +17,16 @@ import (
 	"github.com/otterize/network-mapper/src/mapper/pkg/gcpintentsholder"
 	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
 	"github.com/otterize/network-mapper/src/mapper/pkg/incomingtrafficholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/intentsdiff"
 	"github.com/otterize/network-mapper/src/mapper/pkg/intentsstore"
 	"github.com/otterize/network-mapper/src/mapper/pkg/kubefinder"
 	"github.com/otterize/network-mapper/src/mapper/pkg/resolvers/test_gql_client"
+	sharedconfig "github.com/otterize/network-mapper/src/shared/config"
 	"github.com/otterize/network-mapper/src/shared/testbase"
 	"github.com/otterize/nilable"
 	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/suite"
 	"golang.org/x/exp/slices"
 	v1 "k8s.io/api/core/v1"
@@ -78,6 +82,7 @@ func (s *ResolverTestSuite) SetupTest() {
 		dnsCache,
 		s.incomingTrafficIntentsHolder,
 		traffic.NewCollector(),
+		intentsdiff.NewDiffer(s.Mgr.GetClient(), s.intentsHolder),
 	)
 
 	resolver.Register(e)
@@ -760,6 +765,89 @@ func (s *ResolverTestSuite) TestReportCaptureResultsIgnoreOldPacket() {
 	s.Require().ElementsMatch(res.ServiceIntents, []test_gql_client.ServiceIntentsServiceIntents{})
 }
 
+func (s *ResolverTestSuite) TestReportTLSCaptureResults() {
+	viper.Set(sharedconfig.EnableTLSKey, true)
+	defer viper.Set(sharedconfig.EnableTLSKey, sharedconfig.EnableTLSSnifferDefault)
+	viper.Set(config.TLSEgressProxiesKey, []string{fmt.Sprintf("svc-egress.%s", s.TestNamespace), fmt.Sprintf("deployment-gateway.%s", s.TestNamespace)})
+	defer viper.Set(config.TLSEgressProxiesKey, []string{})
+
+	s.AddDeploymentWithService("client", []string{"1.1.1.1"}, map[string]string{"app": "client"}, "10.0.0.16")
+	s.AddDeploymentWithService("redis", []string{"1.1.1.2"}, map[string]string{"app": "redis"}, "10.0.0.17")
+	s.AddDeploymentWithService("egress", []string{"1.1.1.3"}, map[string]string{"app": "egress"}, "10.0.0.18")
+	s.AddDeployment("gateway", []string{"1.1.1.4"}, map[string]string{"app": "gateway"})
+	s.Require().True(s.Mgr.GetCache().WaitForCacheSync(context.Background()))
+
+	packetTime := time.Now().Add(time.Minute)
+	destination := func(serverName string, ip string) test_gql_client.Destination {
+		return test_gql_client.Destination{
+			Destination:     serverName,
+			DestinationIP:   nilable.From(ip),
+			DestinationPort: nilable.From(443),
+			LastSeen:        packetTime,
+		}
+	}
+	_, err := test_gql_client.ReportTLSCaptureResults(context.Background(), s.client, test_gql_client.CaptureTLSResults{
+		Results: []test_gql_client.RecordedDestinationsForSrc{
+			{
+				SrcIp: "1.1.1.1",
+				Destinations: []test_gql_client.Destination{
+					destination("api.example.com", "93.184.216.34"),
+					// Connections through egress proxies, by service IP and by pod IP
+					destination("github.com", "10.0.0.18"),
+					destination("pypi.org", "1.1.1.4"),
+					// In-cluster server names
+					destination("redis-master", "10.0.0.17"),
+					destination("payments.prod.svc", "93.184.216.35"),
+					destination(fmt.Sprintf("svc-redis.%s", s.TestNamespace), "93.184.216.36"),
+					destination(fmt.Sprintf("svc-redis.%s.svc.cluster.local", s.TestNamespace), "10.0.0.17"),
+					// In-cluster IPs that are not egress proxies
+					destination("evil.example.com", "10.0.0.17"),
+					destination("evil.example.org", "1.1.1.2"),
+				},
+			},
+		},
+	})
+	s.Require().NoError(err)
+
+	s.waitForCaptureResultsProcessed(10 * time.Second)
+
+	intents := s.externalTrafficIntentsHolder.GetIntents()
+	s.Require().ElementsMatch([]string{"api.example.com", "github.com", "pypi.org"}, lo.Map(intents, func(intent externaltrafficholder.TimestampedExternalTrafficIntent, _ int) string {
+		return intent.Intent.DNSName
+	}))
+	for _, intent := range intents {
+		s.Require().Equal("deployment-client", intent.Intent.Client.Name)
+		if intent.Intent.DNSName == "api.example.com" {
+			s.Require().Equal(map[externaltrafficholder.IP]struct{}{"93.184.216.34": {}}, intent.Intent.IPs)
+		} else {
+			s.Require().Empty(intent.Intent.IPs)
+		}
+	}
+	// Server names are chosen by clients, so they do not resolve hostnames to IPs for other clients' intents
+	s.Require().Empty(s.resolver.dnsCache.GetResolvedIPs("api.example.com"))
+}
+
+func (s *ResolverTestSuite) TestAccessLogEntriesThroughGateway() {
+	s.AddDeploymentWithService("web", []string{"1.1.3.1"}, map[string]string{"app": "web"}, "10.0.0.40")
+	s.AddDeploymentWithService("gateway", []string{"1.1.3.2"}, map[string]string{"app": "gateway"}, "10.0.0.41")
+	s.AddDeploymentWithService("orders", []string{"1.1.3.3"}, map[string]string{"app": "orders"}, "10.0.0.42")
+	s.Require().True(s.Mgr.GetCache().WaitForCacheSync(context.Background()))
+
+	requestTime := time.Now().Add(config.TimeServerHasToLiveBeforeWeTrustItDefault).Add(time.Minute)
+	err := s.resolver.handleAccessLogEntries(context.Background(), accesslogs.Entries{
+		// An in-cluster client, whose request takes a hop to the gateway and another to the upstream
+		{SrcIP: "1.1.3.1", ProxyIP: "1.1.3.2", DstIP: "1.1.3.3", Path: "/api/orders", Method: "GET", LastSeen: requestTime},
+		// A client outside the cluster, whose request only takes the hop from the gateway to the upstream
+		{SrcIP: "203.0.113.7", ProxyIP: "1.1.3.2", DstIP: "1.1.3.3", Path: "/api/orders", Method: "POST", LastSeen: requestTime},
+	})
+	s.Require().NoError(err)
+
+	hops := lo.Map(s.intentsHolder.GetNewIntentsSinceLastGet(), func(intent intentsstore.TimestampedIntent, _ int) string {
+		return fmt.Sprintf("%s->%s", intent.Intent.Client.Name, intent.Intent.Server.Name)
+	})
+	s.Require().ElementsMatch([]string{"deployment-web->deployment-gateway", "deployment-gateway->deployment-orders"}, hops)
+}
+
 func (s *ResolverTestSuite) TestSocketScanResults() {
 	const (
 		service1podIP = "1.1.2.1"
@@ -1510,6 +1598,52 @@ func (s *ResolverTestSuite) TestDiscoverInternalSrcIdentityIgnoreControlPlaneIfB
 	s.Require().Empty(identity)
 }
 
+func (s *ResolverTestSuite) setPodContainerID(pod *v1.Pod, containerID string) {
+	var readPod v1.Pod
+	err := s.Mgr.GetClient().Get(context.Background(), types.NamespacedName{Name: pod.GetName(), Namespace: pod.GetNamespace()}, &readPod)
+	s.Require().NoError(err)
+	readPod.Status.ContainerStatuses = []v1.ContainerStatus{{Name: pod.GetName(), ContainerID: "containerd://" + containerID}}
+	s.Require().NoError(s.Mgr.GetClient().Status().Update(context.Background(), &readPod))
+
+	s.Require().NoError(wait.PollUntilContextTimeout(
+		context.Background(),
+		100*time.Millisecond,
+		10*time.Second,
+		true,
+		func(ctx context.Context) (done bool, err error) {
+			_, err = s.kubeFinder.ResolveContainerIDToPod(ctx, containerID)
+			if errors.Is(err, kubefinder.ErrNoPodFound) {
+				return false, nil
+			}
+			return err == nil, errors.Wrap(err)
+		}))
+}
+
+func (s *ResolverTestSuite) TestDiscoverInternalSrcIdentityByContainerID() {
+	containerID := "6f0c2a1d4e5b8c9a0f1e2d3c4b5a69788796a5b4c3d2e1f0a9b8c7d6e5f4a3b2"
+	pod := s.AddPod("traced-pod", "1.1.1.4", nil, nil)
+	_ = s.AddPod("ip-reusing-pod", "1.1.1.5", nil, nil)
+	s.setPodContainerID(pod, containerID)
+
+	// The source IP now belongs to another pod, the container ID still identifies the pod the connection was traced in
+	identity, err := s.resolver.discoverInternalSrcIdentity(context.Background(), &model.RecordedDestinationsForSrc{
+		SrcIP:          "1.1.1.5",
+		SrcContainerID: lo.ToPtr(containerID),
+		Destinations:   []model.Destination{{Destination: "8.8.8.8", LastSeen: time.Now()}},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal("traced-pod", identity.Name)
+
+	// Unknown containers fall back to resolving the source IP
+	identity, err = s.resolver.discoverInternalSrcIdentity(context.Background(), &model.RecordedDestinationsForSrc{
+		SrcIP:          "1.1.1.5",
+		SrcContainerID: lo.ToPtr("unknown"),
+		Destinations:   []model.Destination{{Destination: "8.8.8.8", LastSeen: time.Now()}},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal("ip-reusing-pod", identity.Name)
+}
+
 func (s *ResolverTestSuite) TestReportTCPResultsIgnoreTargetsWithShortUptime() {
 	srcPodIP := "1.1.1.3"
 	_ = s.AddPod("pod3", srcPodIP, nil, nil)
@@ -1579,6 +1713,89 @@ func (s *ResolverTestSuite) TestReportTCPResultsIgnoreTargetsWithShortUptime() {
 
 }
 
+func (s *ResolverTestSuite) addPodWithServiceAccount(name string, podIP string, serviceAccount string) *v1.Pod {
+	pod := &v1.Pod{}
+	pod.Name = name
+	pod.Namespace = s.TestNamespace
+	pod.Spec = v1.PodSpec{
+		ServiceAccountName: serviceAccount,
+		Containers:         []v1.Container{{Name: name, Image: "nginx", ImagePullPolicy: "Always"}},
+	}
+	s.Require().NoError(s.Mgr.GetClient().Create(context.Background(), pod))
+
+	pod.Status.PodIP = podIP
+	pod.Status.PodIPs = []v1.PodIP{{IP: podIP}}
+	pod.Status.Phase = v1.PodRunning
+	s.Require().NoError(s.Mgr.GetClient().Status().Update(context.Background(), pod))
+	return pod
+}
+
+func (s *ResolverTestSuite) waitForServiceAccountPods(serviceAccount string, count int) {
+	s.Require().NoError(wait.PollUntilContextTimeout(
+		context.Background(),
+		100*time.Millisecond,
+		10*time.Second,
+		true,
+		func(ctx context.Context) (done bool, err error) {
+			pods, err := s.kubeFinder.ResolveServiceAccountToPods(ctx, serviceAccount, s.TestNamespace)
+			if errors.Is(err, kubefinder.ErrNoPodFound) {
+				return false, nil
+			}
+			return len(pods) == count, errors.Wrap(err)
+		}))
+}
+
+func (s *ResolverTestSuite) TestKafkaClientResolvedByServiceAccountOfSingleWorkload() {
+	s.addPodWithServiceAccount("kafka-client", "1.1.1.10", "client-sa")
+	s.waitForServiceAccountPods("client-sa", 1)
+
+	identity, ok := s.resolver.resolveKafkaClientByServiceAccount(context.Background(), model.KafkaMapperResult{
+		SrcIP:             "1.1.1.99",
+		SrcServiceAccount: &model.NamespacedName{Name: "client-sa", Namespace: s.TestNamespace},
+	})
+	s.Require().True(ok)
+	s.Require().Equal("kafka-client", identity.Name)
+	s.Require().Equal(s.TestNamespace, identity.Namespace)
+}
+
+func (s *ResolverTestSuite) TestKafkaClientNotResolvedByServiceAccountOfSeveralWorkloads() {
+	s.addPodWithServiceAccount("kafka-client-a", "1.1.1.11", "shared-sa")
+	s.addPodWithServiceAccount("kafka-client-b", "1.1.1.12", "shared-sa")
+	s.waitForServiceAccountPods("shared-sa", 2)
+
+	_, ok := s.resolver.resolveKafkaClientByServiceAccount(context.Background(), model.KafkaMapperResult{
+		SrcIP:             "1.1.1.11",
+		SrcServiceAccount: &model.NamespacedName{Name: "shared-sa", Namespace: s.TestNamespace},
+	})
+	s.Require().False(ok)
+}
+
+func (s *ResolverTestSuite) TestKafkaClientFallsBackToIPForUnresolvableServiceAccount() {
+	s.AddPod("kafka-client", "1.1.1.13", nil, nil)
+	server := s.AddPod("kafka-server", "1.1.1.14", nil, nil)
+	s.Require().True(s.Mgr.GetCache().WaitForCacheSync(context.Background()))
+
+	err := s.resolver.handleReportKafkaMapperResults(context.Background(), model.KafkaMapperResults{
+		Results: []model.KafkaMapperResult{
+			{
+				SrcIP:             "1.1.1.13",
+				ServerPodName:     lo.ToPtr(server.Name),
+				ServerNamespace:   lo.ToPtr(server.Namespace),
+				Topic:             "mytopic",
+				Operation:         "Read",
+				LastSeen:          time.Now().Add(time.Minute),
+				SrcServiceAccount: &model.NamespacedName{Name: "missing-sa", Namespace: s.TestNamespace},
+			},
+		},
+	})
+	s.Require().NoError(err)
+
+	intents := s.intentsHolder.GetNewIntentsSinceLastGet()
+	s.Require().Len(intents, 1)
+	s.Require().Equal("kafka-client", intents[0].Intent.Client.Name)
+	s.Require().Equal("kafka-server", intents[0].Intent.Server.Name)
+}
+
 func TestRunSuite(t *testing.T) {
 	suite.Run(t, new(ResolverTestSuite))
 }
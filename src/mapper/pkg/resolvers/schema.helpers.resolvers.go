@@ -21,6 +21,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	corev1 "k8s.io/api/core/v1"
+	"net"
 	"strings"
 	"time"
 )
@@ -33,6 +34,9 @@ const (
 	SourceTypeSocketScan  SourceType = "SocketScan"
 	SourceTypeKafkaMapper SourceType = "KafkaMapper"
 	SourceTypeIstio       SourceType = "Istio"
+	SourceTypeHTTPCapture SourceType = "HTTPCapture"
+	SourceTypeLinkerd     SourceType = "Linkerd"
+	SourceTypeAccessLog   SourceType = "AccessLog"
 )
 
 func updateTelemetriesCounters(sourceType SourceType, intent model.Intent) {
@@ -184,6 +188,7 @@ func (r *Resolver) addSocketScanServiceIntent(ctx context.Context, srcSvcIdentit
 		Client:         &srcSvcIdentity,
 		Server:         &dstSvcIdentity,
 		ResolutionData: lo.ToPtr(concurrentconnectioncounter.SocketScanServiceIntentResolution),
+		Protocol:       dest.Protocol,
 	}
 
 	r.intentsHolder.AddIntent(
@@ -237,6 +242,7 @@ func (r *Resolver) addSocketScanPodIntent(ctx context.Context, srcSvcIdentity mo
 		Client:         &srcSvcIdentity,
 		Server:         dstSvcIdentity,
 		ResolutionData: lo.ToPtr(concurrentconnectioncounter.SocketScanPodIntentResolution),
+		Protocol:       dest.Protocol,
 	}
 
 	r.intentsHolder.AddIntent(
@@ -250,14 +256,31 @@ func (r *Resolver) addSocketScanPodIntent(ctx context.Context, srcSvcIdentity mo
 }
 
 func (r *Resolver) handleDNSCaptureResultsAsExternalTraffic(_ context.Context, dest model.Destination, srcSvcIdentity model.OtterizeServiceIdentity) error {
-	if !viper.GetBool(config.ExternalTrafficCaptureEnabledKey) {
+	if !r.addExternalTrafficIntent(dest, srcSvcIdentity) {
 		return nil
 	}
 
+	if dest.DestinationIP != nil {
+		ttl := 120 * time.Second
+		if dest.TTL != nil {
+			ttl = time.Duration(*dest.TTL) * time.Second
+		}
+		r.dnsCache.AddOrUpdateDNSData(dest.Destination, *dest.DestinationIP, ttl)
+	}
+	return nil
+}
+
+// addExternalTrafficIntent records traffic from srcSvcIdentity to the hostname dest.Destination, and returns whether it
+// was recorded.
+func (r *Resolver) addExternalTrafficIntent(dest model.Destination, srcSvcIdentity model.OtterizeServiceIdentity) bool {
+	if !viper.GetBool(config.ExternalTrafficCaptureEnabledKey) {
+		return false
+	}
+
 	// Ignore external traffic from the network mapper except to Otterize Cloud, which is caused
 	// by the network mapper resolving all external IP traffic when Internet intents are in use.
 	if srcSvcIdentity.Name == "otterize-network-mapper" && dest.Destination != "app.otterize.com" {
-		return nil
+		return false
 	}
 	intent := externaltrafficholder.ExternalTrafficIntent{
 		Client:   srcSvcIdentity,
@@ -269,16 +292,11 @@ func (r *Resolver) handleDNSCaptureResultsAsExternalTraffic(_ context.Context, d
 	if dest.DestinationIP != nil {
 		ip = *dest.DestinationIP
 		intent.IPs = map[externaltrafficholder.IP]struct{}{externaltrafficholder.IP(*dest.DestinationIP): {}}
-		ttl := 120 * time.Second
-		if dest.TTL != nil {
-			ttl = time.Duration(*dest.TTL) * time.Second
-		}
-		r.dnsCache.AddOrUpdateDNSData(dest.Destination, ip, ttl)
 	}
 	logrus.Debugf("Saw external traffic, from '%s.%s' to '%s' (IP '%s')", srcSvcIdentity.Name, srcSvcIdentity.Namespace, dest.Destination, ip)
 
 	r.externalTrafficIntentsHolder.AddIntent(intent)
-	return nil
+	return true
 }
 
 // ReportAWSOperation is the resolver for the reportAWSOperation field.
@@ -466,6 +484,18 @@ func (r *Resolver) handleDNSCaptureResultsAsKubernetesPods(ctx context.Context,
 }
 
 func (r *Resolver) resolveOtterizeIdentityForDestinationAddress(ctx context.Context, dest model.Destination) (*model.OtterizeServiceIdentity, bool, error) {
+	dstSvcIdentity, ok, err := r.resolveOtterizeIdentityForServiceAddress(ctx, dest)
+	if err != nil {
+		logrus.WithError(err).Warningf("Could not resolve service address %s", dest.Destination)
+		// Intentionally no error return
+		return nil, false, nil
+	}
+	return dstSvcIdentity, ok, nil
+}
+
+// resolveOtterizeIdentityForServiceAddress is like resolveOtterizeIdentityForDestinationAddress, but returns an error
+// when the address cannot be resolved, for callers that report many addresses which are expected not to resolve.
+func (r *Resolver) resolveOtterizeIdentityForServiceAddress(ctx context.Context, dest model.Destination) (*model.OtterizeServiceIdentity, bool, error) {
 	destAddress := dest.Destination
 	resolutionData := model.IdentityResolutionData{
 		Host:      lo.ToPtr(destAddress),
@@ -475,9 +505,7 @@ func (r *Resolver) resolveOtterizeIdentityForDestinationAddress(ctx context.Cont
 	}
 	pods, serviceName, err := r.kubeFinder.ResolveServiceAddressToPods(ctx, destAddress)
 	if err != nil {
-		logrus.WithError(err).Warningf("Could not resolve service address %s", destAddress)
-		// Intentionally no error return
-		return nil, false, nil
+		return nil, false, errors.Wrap(err)
 	}
 	if kubefinder.ServiceIsAPIServer(serviceName.Name, serviceName.Namespace) {
 		return &model.OtterizeServiceIdentity{
@@ -697,6 +725,7 @@ func (r *Resolver) handleInternalTrafficTCPResult(ctx context.Context, srcIdenti
 		Client:         &srcIdentity,
 		Server:         &destIdentity,
 		ResolutionData: lo.ToPtr(concurrentconnectioncounter.TCPTrafficIntentResolution),
+		Protocol:       dest.Protocol,
 	}
 
 	r.intentsHolder.AddIntent(
@@ -746,6 +775,105 @@ func (r *Resolver) handleReportCaptureResults(ctx context.Context, results model
 	return nil
 }
 
+// handleReportTLSCaptureResults attributes external traffic by the server names clients sent when opening TLS
+// connections, for connections whose DNS lookups were not captured - such as hosts resolved over DoH, by /etc/hosts or
+// long before the connection was made. Server names are chosen by the client, so they are not added to the DNS cache.
+func (r *Resolver) handleReportTLSCaptureResults(ctx context.Context, results model.CaptureTLSResults) error {
+	if !viper.GetBool(sharedconfig.EnableTLSKey) {
+		return nil
+	}
+
+	for _, captureItem := range results.Results {
+		srcSvcIdentity, err := r.discoverInternalSrcIdentity(ctx, &captureItem)
+		if err != nil {
+			logrus.WithError(err).Debugf("could not discover src identity for '%s'", captureItem.SrcIP)
+			continue
+		}
+		for _, dest := range captureItem.Destinations {
+			isExternal, err := r.isExternalTLSServerName(ctx, dest.Destination)
+			if err != nil {
+				logrus.WithError(err).Error("could not check whether TLS server name is in the cluster")
+				continue
+			}
+			if !isExternal {
+				logrus.Debugf("Ignoring TLS connection to in-cluster server name '%s'", dest.Destination)
+				continue
+			}
+
+			destCopy := dest
+			if dest.DestinationIP != nil {
+				isInCluster, isEgressProxy, err := r.resolveInClusterTLSDestination(ctx, *dest.DestinationIP)
+				if err != nil {
+					logrus.WithError(err).Error("could not check whether TLS destination IP is in the cluster")
+					continue
+				}
+				if isInCluster && !isEgressProxy {
+					logrus.Debugf("Ignoring TLS connection to '%s' at in-cluster IP '%s'", dest.Destination, *dest.DestinationIP)
+					continue
+				}
+				// The egress proxy's IP is not one the server name resolves to.
+				if isEgressProxy {
+					destCopy.DestinationIP = nil
+				}
+			}
+			r.addExternalTrafficIntent(destCopy, srcSvcIdentity)
+		}
+	}
+
+	r.gotResultsSignal()
+	return nil
+}
+
+// isExternalTLSServerName returns whether a server name may be of an Internet host, rather than of an in-cluster service
+// such as 'redis-master', 'payments.prod.svc' or 'kafka-0.kafka-headless.prod'.
+func (r *Resolver) isExternalTLSServerName(ctx context.Context, serverName string) (bool, error) {
+	lastDot := strings.LastIndex(serverName, ".")
+	if lastDot == -1 {
+		return false, nil
+	}
+	if strings.HasSuffix(serverName, ".svc") || strings.HasSuffix(serverName, "."+viper.GetString(config.ClusterDomainKey)) {
+		return false, nil
+	}
+	isNamespace, err := r.kubeFinder.IsNamespace(ctx, serverName[lastDot+1:])
+	if err != nil {
+		return false, errors.Wrap(err)
+	}
+	return !isNamespace, nil
+}
+
+// resolveInClusterTLSDestination returns whether ip is in the cluster, and whether it is of one of the egress proxies
+// configured by TLSEgressProxiesKey, by the name of its service or of its pod's workload.
+func (r *Resolver) resolveInClusterTLSDestination(ctx context.Context, ip string) (bool, bool, error) {
+	egressProxies := viper.GetStringSlice(config.TLSEgressProxiesKey)
+	svc, found, err := r.kubeFinder.ResolveIPToService(ctx, ip)
+	if err != nil {
+		return false, false, errors.Wrap(err)
+	}
+	if found {
+		return true, lo.Contains(egressProxies, fmt.Sprintf("%s.%s", svc.Name, svc.Namespace)), nil
+	}
+
+	isInCluster, err := r.kubeFinder.IsSrcIpClusterInternal(ctx, ip)
+	if err != nil {
+		return false, false, errors.Wrap(err)
+	}
+	if !isInCluster {
+		return false, false, nil
+	}
+	pod, err := r.kubeFinder.ResolveIPToPod(ctx, ip)
+	if err != nil {
+		if errors.Is(err, kubefinder.ErrNoPodFound) || errors.Is(err, kubefinder.ErrFoundMoreThanOnePod) {
+			return true, false, nil
+		}
+		return false, false, errors.Wrap(err)
+	}
+	workload, err := r.serviceIdResolver.ResolvePodToServiceIdentity(ctx, pod)
+	if err != nil {
+		return false, false, errors.Wrap(err)
+	}
+	return true, lo.Contains(egressProxies, fmt.Sprintf("%s.%s", workload.Name, pod.Namespace)), nil
+}
+
 func (r *Resolver) handleReportSocketScanResults(ctx context.Context, results model.SocketScanResults) error {
 	if !viper.GetBool(sharedconfig.EnableSocketScannerKey) {
 		return nil
@@ -788,40 +916,106 @@ func (r *Resolver) handleReportSocketScanResults(ctx context.Context, results mo
 	return nil
 }
 
-func (r *Resolver) handleReportKafkaMapperResults(ctx context.Context, results model.KafkaMapperResults) error {
-	var newResults int
-	for _, result := range results.Results {
-		srcPod, err := r.kubeFinder.ResolveIPToPod(ctx, result.SrcIP)
+// resolveKafkaClientByServiceAccount resolves the client of a Kafka result from the ServiceAccount its principal was
+// mapped to. This works when the source IP does not belong to the client pod, e.g. behind a proxy or on hostNetwork.
+func (r *Resolver) resolveKafkaClientByServiceAccount(ctx context.Context, result model.KafkaMapperResult) (model.OtterizeServiceIdentity, bool) {
+	if result.SrcServiceAccount == nil {
+		return model.OtterizeServiceIdentity{}, false
+	}
+
+	serviceAccount := result.SrcServiceAccount
+	pods, err := r.kubeFinder.ResolveServiceAccountToPods(ctx, serviceAccount.Name, serviceAccount.Namespace)
+	if err != nil {
+		logrus.WithError(err).Debugf("Could not resolve service account %s.%s to pods", serviceAccount.Name, serviceAccount.Namespace)
+		return model.OtterizeServiceIdentity{}, false
+	}
+
+	var identity *model.OtterizeServiceIdentity
+	var ownerKind string
+	for _, pod := range pods {
+		service, err := r.serviceIdResolver.ResolvePodToServiceIdentity(ctx, &pod)
 		if err != nil {
-			if errors.Is(err, kubefinder.ErrFoundMoreThanOnePod) {
-				logrus.WithError(err).Debugf("Ip %s belongs to more than one pod, ignoring", result.SrcIP)
-			} else {
-				logrus.WithError(err).Debugf("Could not resolve %s to pod", result.SrcIP)
-			}
-			continue
+			logrus.WithError(err).Debugf("Could not resolve pod %s to identity", pod.Name)
+			return model.OtterizeServiceIdentity{}, false
 		}
-
-		if srcPod.DeletionTimestamp != nil {
-			logrus.Debugf("Pod %s is being deleted, ignoring", srcPod.Name)
-			continue
+		// Workloads of different kinds may share a name, e.g. a Deployment and a StatefulSet.
+		if identity != nil && (identity.Name != service.Name || ownerKind != service.Kind) {
+			// The principal can't be attributed to a single workload, so fall back to the source IP.
+			logrus.Debugf("Service account %s.%s is used by more than one workload", serviceAccount.Name, serviceAccount.Namespace)
+			return model.OtterizeServiceIdentity{}, false
 		}
+		identity = &model.OtterizeServiceIdentity{Name: service.Name, Namespace: pod.Namespace, Labels: kubefinder.PodLabelsToOtterizeLabels(&pod)}
+		ownerKind = service.Kind
+	}
 
-		if srcPod.CreationTimestamp.After(result.LastSeen) {
-			logrus.Debugf("Pod %s was created after scan time %s, ignoring", srcPod.Name, result.LastSeen)
-			continue
+	return *identity, true
+}
+
+func (r *Resolver) resolveKafkaClientByIP(ctx context.Context, result model.KafkaMapperResult) (model.OtterizeServiceIdentity, bool) {
+	srcPod, err := r.kubeFinder.ResolveIPToPod(ctx, result.SrcIP)
+	if err != nil {
+		if errors.Is(err, kubefinder.ErrFoundMoreThanOnePod) {
+			logrus.WithError(err).Debugf("Ip %s belongs to more than one pod, ignoring", result.SrcIP)
+		} else {
+			logrus.WithError(err).Debugf("Could not resolve %s to pod", result.SrcIP)
 		}
+		return model.OtterizeServiceIdentity{}, false
+	}
 
-		srcService, err := r.serviceIdResolver.ResolvePodToServiceIdentity(ctx, srcPod)
+	if srcPod.DeletionTimestamp != nil {
+		logrus.Debugf("Pod %s is being deleted, ignoring", srcPod.Name)
+		return model.OtterizeServiceIdentity{}, false
+	}
+
+	if srcPod.CreationTimestamp.After(result.LastSeen) {
+		logrus.Debugf("Pod %s was created after scan time %s, ignoring", srcPod.Name, result.LastSeen)
+		return model.OtterizeServiceIdentity{}, false
+	}
+
+	srcService, err := r.serviceIdResolver.ResolvePodToServiceIdentity(ctx, srcPod)
+	if err != nil {
+		logrus.WithError(err).Debugf("Could not resolve pod %s to identity", srcPod.Name)
+		return model.OtterizeServiceIdentity{}, false
+	}
+
+	return model.OtterizeServiceIdentity{Name: srcService.Name, Namespace: srcPod.Namespace, Labels: kubefinder.PodLabelsToOtterizeLabels(srcPod)}, true
+}
+
+func (r *Resolver) resolveKafkaServerPod(ctx context.Context, result model.KafkaMapperResult) (*corev1.Pod, bool) {
+	if result.ServerPodName != nil && result.ServerNamespace != nil {
+		dstPod, err := r.kubeFinder.ResolvePodByName(ctx, *result.ServerPodName, *result.ServerNamespace)
 		if err != nil {
-			logrus.WithError(err).Debugf("Could not resolve pod %s to identity", srcPod.Name)
-			continue
+			logrus.WithError(err).Debugf("Could not resolve pod %s to identity", *result.ServerPodName)
+			return nil, false
 		}
+		return dstPod, true
+	}
 
-		srcSvcIdentity := model.OtterizeServiceIdentity{Name: srcService.Name, Namespace: srcPod.Namespace, Labels: kubefinder.PodLabelsToOtterizeLabels(srcPod)}
+	if result.ServerIP == nil {
+		logrus.Debugf("Kafka result for %s has neither a server pod nor a server IP, ignoring", result.SrcIP)
+		return nil, false
+	}
+	dstPod, err := r.kubeFinder.ResolveIPToPod(ctx, *result.ServerIP)
+	if err != nil {
+		logrus.WithError(err).Debugf("Could not resolve %s to pod", *result.ServerIP)
+		return nil, false
+	}
+	return dstPod, true
+}
 
-		dstPod, err := r.kubeFinder.ResolvePodByName(ctx, result.ServerPodName, result.ServerNamespace)
-		if err != nil {
-			logrus.WithError(err).Debugf("Could not resolve pod %s to identity", result.ServerPodName)
+func (r *Resolver) handleReportKafkaMapperResults(ctx context.Context, results model.KafkaMapperResults) error {
+	var newResults int
+	for _, result := range results.Results {
+		srcSvcIdentity, ok := r.resolveKafkaClientByServiceAccount(ctx, result)
+		if !ok {
+			srcSvcIdentity, ok = r.resolveKafkaClientByIP(ctx, result)
+		}
+		if !ok {
+			continue
+		}
+
+		dstPod, ok := r.resolveKafkaServerPod(ctx, result)
+		if !ok {
 			continue
 		}
 		dstService, err := r.serviceIdResolver.ResolvePodToServiceIdentity(ctx, dstPod)
@@ -837,16 +1031,23 @@ func (r *Resolver) handleReportKafkaMapperResults(ctx context.Context, results m
 			return err
 		}
 
+		kafkaConfig := model.KafkaConfig{
+			Name:       result.Topic,
+			Operations: []model.KafkaOperation{operation},
+		}
+		if lo.FromPtr(result.PatternType) == model.KafkaPatternTypePrefixed {
+			kafkaConfig.Name += "*"
+		}
+		// Topics are left without a resource type, as they were before other resource types were reported.
+		if result.ResourceType != nil && *result.ResourceType != model.KafkaResourceTypeTopic {
+			kafkaConfig.ResourceType = result.ResourceType
+		}
+
 		intent := model.Intent{
-			Client: &srcSvcIdentity,
-			Server: &dstSvcIdentity,
-			Type:   lo.ToPtr(model.IntentTypeKafka),
-			KafkaTopics: []model.KafkaConfig{
-				{
-					Name:       result.Topic,
-					Operations: []model.KafkaOperation{operation},
-				},
-			},
+			Client:         &srcSvcIdentity,
+			Server:         &dstSvcIdentity,
+			Type:           lo.ToPtr(model.IntentTypeKafka),
+			KafkaTopics:    []model.KafkaConfig{kafkaConfig},
 			ResolutionData: lo.ToPtr(concurrentconnectioncounter.KafkaResultIntentResolution),
 		}
 
@@ -904,10 +1105,13 @@ func (r *Resolver) handleReportIstioConnectionResults(ctx context.Context, resul
 		intent := model.Intent{
 			Client:         &srcSvcIdentity,
 			Server:         &dstSvcIdentity,
-			Type:           lo.ToPtr(model.IntentTypeHTTP),
-			HTTPResources:  []model.HTTPResource{{Path: result.Path, Methods: result.Methods}},
 			ResolutionData: lo.ToPtr(concurrentconnectioncounter.IstioResultIntentResolution),
 		}
+		// Connections reported by ztunnel in Istio ambient mode are L4 only, and have no path
+		if result.Path != "" {
+			intent.Type = lo.ToPtr(model.IntentTypeHTTP)
+			intent.HTTPResources = []model.HTTPResource{{Path: result.Path, Methods: result.Methods}}
+		}
 
 		updateTelemetriesCounters(SourceTypeIstio, intent)
 		r.intentsHolder.AddIntent(result.LastSeen, intent, make([]int64, 0))
@@ -919,6 +1123,132 @@ func (r *Resolver) handleReportIstioConnectionResults(ctx context.Context, resul
 	return nil
 }
 
+// resolveHTTPPeerIdentity resolves the client or server of a sniffed HTTP request. Servers may be addressed by the IP of
+// a Kubernetes service, or by the IP of a pod.
+func (r *Resolver) resolveHTTPPeerIdentity(ctx context.Context, ip string, lastSeen time.Time) (model.OtterizeServiceIdentity, bool) {
+	svc, ok, err := r.kubeFinder.ResolveIPToService(ctx, ip)
+	if err != nil {
+		logrus.WithError(err).Debugf("Could not resolve %s to service", ip)
+		return model.OtterizeServiceIdentity{}, false
+	}
+	if ok {
+		identity, ok, err := r.kubeFinder.ResolveOtterizeIdentityForService(ctx, svc, lastSeen)
+		if err != nil {
+			logrus.WithError(err).Debugf("Could not resolve service %s to identity", svc.Name)
+			return model.OtterizeServiceIdentity{}, false
+		}
+		return identity, ok
+	}
+
+	pod, err := r.kubeFinder.ResolveIPToPod(ctx, ip)
+	if err != nil {
+		logrus.WithError(err).Debugf("Could not resolve %s to pod", ip)
+		return model.OtterizeServiceIdentity{}, false
+	}
+	if pod.DeletionTimestamp != nil {
+		logrus.Debugf("Pod %s is being deleted, ignoring", pod.Name)
+		return model.OtterizeServiceIdentity{}, false
+	}
+	if pod.CreationTimestamp.After(lastSeen) {
+		logrus.Debugf("Pod %s was created after scan time %s, ignoring", pod.Name, lastSeen)
+		return model.OtterizeServiceIdentity{}, false
+	}
+	service, err := r.serviceIdResolver.ResolvePodToServiceIdentity(ctx, pod)
+	if err != nil {
+		logrus.WithError(err).Debugf("Could not resolve pod %s to identity", pod.Name)
+		return model.OtterizeServiceIdentity{}, false
+	}
+
+	identity := model.OtterizeServiceIdentity{Name: service.Name, Namespace: pod.Namespace, Labels: kubefinder.PodLabelsToOtterizeLabels(pod), NameResolvedUsingAnnotation: service.ResolvedUsingOverrideAnnotation}
+	if service.OwnerObject != nil {
+		identity.PodOwnerKind = model.GroupVersionKindFromKubeGVK(service.OwnerObject.GetObjectKind().GroupVersionKind())
+	}
+	return identity, true
+}
+
+func (r *Resolver) handleReportHTTPRequestResults(ctx context.Context, results model.HTTPRequestResults) error {
+	var newResults int
+	for _, result := range results.Results {
+		srcSvcIdentity, ok := r.resolveHTTPPeerIdentity(ctx, result.SrcIP, result.LastSeen)
+		if !ok {
+			continue
+		}
+		dstSvcIdentity, ok := r.resolveHTTPPeerIdentity(ctx, result.DstIP, result.LastSeen)
+		if !ok {
+			continue
+		}
+
+		intent := model.Intent{
+			Client:         &srcSvcIdentity,
+			Server:         &dstSvcIdentity,
+			Type:           lo.ToPtr(model.IntentTypeHTTP),
+			HTTPResources:  []model.HTTPResource{{Path: result.Path, Methods: []model.HTTPMethod{result.Method}}},
+			ResolutionData: lo.ToPtr(concurrentconnectioncounter.HTTPResultIntentResolution),
+		}
+
+		updateTelemetriesCounters(SourceTypeHTTPCapture, intent)
+		r.intentsHolder.AddIntent(result.LastSeen, intent, make([]int64, 0))
+		newResults++
+	}
+
+	prometheus.IncrementHTTPReports(newResults)
+	r.gotResultsSignal()
+	return nil
+}
+
+func (r *Resolver) handleReportLinkerdConnectionResults(ctx context.Context, results model.LinkerdConnectionResults) error {
+	var newResults int
+	for _, result := range results.Results {
+		srcPod, err := r.kubeFinder.ResolvePodByName(ctx, result.SrcPodName, result.SrcNamespace)
+		if err != nil {
+			logrus.WithError(err).Debugf("Could not resolve pod %s", result.SrcPodName)
+			continue
+		}
+		srcService, err := r.serviceIdResolver.ResolvePodToServiceIdentity(ctx, srcPod)
+		if err != nil {
+			logrus.WithError(err).Debugf("Could not resolve pod %s to identity", srcPod.Name)
+			continue
+		}
+		srcSvcIdentity := model.OtterizeServiceIdentity{Name: srcService.Name, Namespace: srcPod.Namespace, Labels: kubefinder.PodLabelsToOtterizeLabels(srcPod), NameResolvedUsingAnnotation: srcService.ResolvedUsingOverrideAnnotation}
+		if srcService.OwnerObject != nil {
+			srcSvcIdentity.PodOwnerKind = model.GroupVersionKindFromKubeGVK(srcService.OwnerObject.GetObjectKind().GroupVersionKind())
+		}
+
+		// The authority is the address the client used, which includes a port unless it is the default one
+		host, _, err := net.SplitHostPort(result.Authority)
+		if err != nil {
+			host = result.Authority
+		}
+		dstSvcIdentity, ok, err := r.resolveOtterizeIdentityForServiceAddress(ctx, model.Destination{Destination: host, LastSeen: result.LastSeen})
+		if err != nil {
+			logrus.WithError(err).Debugf("Could not resolve authority %s to identity", result.Authority)
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		intent := model.Intent{
+			Client:         &srcSvcIdentity,
+			Server:         dstSvcIdentity,
+			ResolutionData: lo.ToPtr(concurrentconnectioncounter.LinkerdResultIntentResolution),
+		}
+		// Without a ServiceProfile route named after its method and path, only the connection itself is known
+		if result.Path != nil {
+			intent.Type = lo.ToPtr(model.IntentTypeHTTP)
+			intent.HTTPResources = []model.HTTPResource{{Path: *result.Path, Methods: result.Methods}}
+		}
+
+		updateTelemetriesCounters(SourceTypeLinkerd, intent)
+		r.intentsHolder.AddIntent(result.LastSeen, intent, make([]int64, 0))
+		newResults++
+	}
+
+	prometheus.IncrementLinkerdReports(newResults)
+	r.gotResultsSignal()
+	return nil
+}
+
 type Results interface {
 	Length() int
 }
@@ -14,12 +14,18 @@ import (
 	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/exp/slices"
+	"time"
 )
 
 // ResetCapture is the resolver for the resetCapture field.
 func (r *mutationResolver) ResetCapture(ctx context.Context) (bool, error) {
 	logrus.Info("Resetting stored intents")
 	r.intentsHolder.Reset()
+	r.externalTrafficIntentsHolder.Reset()
+	r.awsIntentsHolder.Reset()
+	r.gcpIntentsHolder.Reset()
+	r.azureIntentsHolder.Reset()
+	r.incomingTrafficHolder.Reset()
 	return true, nil
 }
 
@@ -51,6 +57,20 @@ func (r *mutationResolver) ReportTCPCaptureResults(ctx context.Context, results
 	}
 }
 
+// ReportTLSCaptureResults is the resolver for the reportTLSCaptureResults field.
+func (r *mutationResolver) ReportTLSCaptureResults(ctx context.Context, results model.CaptureTLSResults) (bool, error) {
+	select {
+	case r.tlsCaptureResults <- results:
+		prometheus.IncrementTLSCaptureReports(len(results.Results))
+		return true, nil
+	case <-ctx.Done():
+		return false, ctx.Err()
+	default:
+		prometheus.IncrementTLSCaptureDrops(len(results.Results))
+		return false, nil
+	}
+}
+
 // ReportSocketScanResults is the resolver for the reportSocketScanResults field.
 func (r *mutationResolver) ReportSocketScanResults(ctx context.Context, results model.SocketScanResults) (bool, error) {
 	select {
@@ -93,6 +113,34 @@ func (r *mutationResolver) ReportIstioConnectionResults(ctx context.Context, res
 	}
 }
 
+// ReportLinkerdConnectionResults is the resolver for the reportLinkerdConnectionResults field.
+func (r *mutationResolver) ReportLinkerdConnectionResults(ctx context.Context, results model.LinkerdConnectionResults) (bool, error) {
+	select {
+	case r.linkerdConnectionResults <- results:
+		prometheus.IncrementLinkerdReports(len(results.Results))
+		return true, nil
+	case <-ctx.Done():
+		return false, ctx.Err()
+	default:
+		prometheus.IncrementLinkerdDrops(len(results.Results))
+		return false, nil
+	}
+}
+
+// ReportHTTPRequestResults is the resolver for the reportHTTPRequestResults field.
+func (r *mutationResolver) ReportHTTPRequestResults(ctx context.Context, results model.HTTPRequestResults) (bool, error) {
+	select {
+	case r.httpRequestResults <- results:
+		prometheus.IncrementHTTPReports(len(results.Results))
+		return true, nil
+	case <-ctx.Done():
+		return false, ctx.Err()
+	default:
+		prometheus.IncrementHTTPDrops(len(results.Results))
+		return false, nil
+	}
+}
+
 // ReportAWSOperation is the resolver for the reportAWSOperation field.
 func (r *mutationResolver) ReportAWSOperation(ctx context.Context, operation []model.AWSOperation) (bool, error) {
 	select {
@@ -148,12 +196,12 @@ func (r *mutationResolver) ReportTrafficLevelResults(ctx context.Context, result
 }
 
 // ServiceIntents is the resolver for the serviceIntents field.
-func (r *queryResolver) ServiceIntents(ctx context.Context, namespaces []string, includeLabels []string, includeAllLabels *bool) ([]model.ServiceIntents, error) {
+func (r *queryResolver) ServiceIntents(ctx context.Context, namespaces []string, includeLabels []string, includeAllLabels *bool, since *time.Time, until *time.Time) ([]model.ServiceIntents, error) {
 	shouldIncludeAllLabels := false
 	if includeAllLabels != nil && *includeAllLabels {
 		shouldIncludeAllLabels = true
 	}
-	discoveredIntents, err := r.intentsHolder.GetIntents(namespaces, includeLabels, []string{}, shouldIncludeAllLabels, nil)
+	discoveredIntents, err := r.intentsHolder.GetIntents(namespaces, includeLabels, []string{}, shouldIncludeAllLabels, nil, since, until)
 	if err != nil {
 		return []model.ServiceIntents{}, errors.Wrap(err)
 	}
@@ -185,7 +233,7 @@ func (r *queryResolver) ServiceIntents(ctx context.Context, namespaces []string,
 }
 
 // Intents is the resolver for the intents field.
-func (r *queryResolver) Intents(ctx context.Context, namespaces []string, includeLabels []string, excludeServiceWithLabels []string, includeAllLabels *bool, server *model.ServerFilter) ([]model.Intent, error) {
+func (r *queryResolver) Intents(ctx context.Context, namespaces []string, includeLabels []string, excludeServiceWithLabels []string, includeAllLabels *bool, server *model.ServerFilter, since *time.Time, until *time.Time) ([]model.Intent, error) {
 	shouldIncludeAllLabels := false
 	if includeAllLabels != nil && *includeAllLabels {
 		shouldIncludeAllLabels = true
@@ -197,6 +245,8 @@ func (r *queryResolver) Intents(ctx context.Context, namespaces []string, includ
 		excludeServiceWithLabels,
 		shouldIncludeAllLabels,
 		server,
+		since,
+		until,
 	)
 	if err != nil {
 		return []model.Intent{}, errors.Wrap(err)
@@ -230,16 +280,43 @@ func (r *queryResolver) Intents(ctx context.Context, namespaces []string, includ
 	return intents, nil
 }
 
+// ClientIntentsYaml is the resolver for the clientIntentsYAML field.
+func (r *queryResolver) ClientIntentsYaml(ctx context.Context, namespaces []string) (string, error) {
+	clientIntentsYAML, err := r.clientIntentsGenerator.GenerateYAML(namespaces)
+	if err != nil {
+		return "", errors.Wrap(err)
+	}
+	return clientIntentsYAML, nil
+}
+
+// IntentsDiff is the resolver for the intentsDiff field.
+func (r *queryResolver) IntentsDiff(ctx context.Context, namespaces []string) ([]model.ClientIntentsDiff, error) {
+	diffs, err := r.intentsDiffer.Diff(ctx, namespaces)
+	if err != nil {
+		return []model.ClientIntentsDiff{}, errors.Wrap(err)
+	}
+	return diffs, nil
+}
+
 // Health is the resolver for the health field.
 func (r *queryResolver) Health(ctx context.Context) (bool, error) {
 	return true, nil
 }
 
+// IntentDiscovered is the resolver for the intentDiscovered field.
+func (r *subscriptionResolver) IntentDiscovered(ctx context.Context, namespaces []string) (<-chan *model.DiscoveredIntent, error) {
+	return r.intentsBroadcaster.Subscribe(ctx, namespaces), nil
+}
+
 // Mutation returns generated.MutationResolver implementation.
 func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
 
 // Query returns generated.QueryResolver implementation.
 func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
 
+// Subscription returns generated.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() generated.SubscriptionResolver { return &subscriptionResolver{r} }
+
 type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
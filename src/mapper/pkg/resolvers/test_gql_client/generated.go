@@ -24,13 +24,21 @@ type CaptureTCPResults struct {
 // GetResults returns CaptureTCPResults.Results, and is useful for accessing the field via an interface.
 func (v *CaptureTCPResults) GetResults() []RecordedDestinationsForSrc { return v.Results }
 
+type CaptureTLSResults struct {
+	Results []RecordedDestinationsForSrc `json:"results"`
+}
+
+// GetResults returns CaptureTLSResults.Results, and is useful for accessing the field via an interface.
+func (v *CaptureTLSResults) GetResults() []RecordedDestinationsForSrc { return v.Results }
+
 type Destination struct {
-	Destination     string                  `json:"destination"`
-	DestinationIP   nilable.Nilable[string] `json:"destinationIP"`
-	DestinationPort nilable.Nilable[int]    `json:"destinationPort"`
-	TTL             nilable.Nilable[int]    `json:"TTL"`
-	LastSeen        time.Time               `json:"lastSeen"`
-	SrcPorts        []int                   `json:"srcPorts"`
+	Destination     string                             `json:"destination"`
+	DestinationIP   nilable.Nilable[string]            `json:"destinationIP"`
+	DestinationPort nilable.Nilable[int]               `json:"destinationPort"`
+	TTL             nilable.Nilable[int]               `json:"TTL"`
+	LastSeen        time.Time                          `json:"lastSeen"`
+	SrcPorts        []int                              `json:"srcPorts"`
+	Protocol        nilable.Nilable[TransportProtocol] `json:"protocol"`
 }
 
 // GetDestination returns Destination.Destination, and is useful for accessing the field via an interface.
@@ -51,6 +59,9 @@ func (v *Destination) GetLastSeen() time.Time { return v.LastSeen }
 // GetSrcPorts returns Destination.SrcPorts, and is useful for accessing the field via an interface.
 func (v *Destination) GetSrcPorts() []int { return v.SrcPorts }
 
+// GetProtocol returns Destination.Protocol, and is useful for accessing the field via an interface.
+func (v *Destination) GetProtocol() nilable.Nilable[TransportProtocol] { return v.Protocol }
+
 // IntentsIntentsIntent includes the requested fields of the GraphQL type Intent.
 type IntentsIntentsIntent struct {
 	Client IntentsIntentsIntentClientOtterizeServiceIdentity `json:"client"`
@@ -163,6 +174,8 @@ type IntentsResponse struct {
 	// includeLabels: Labels to include in the response. Ignored if includeAllLabels is specified.
 	// excludeLabels: Labels to exclude from the response. Ignored if includeAllLabels is specified.
 	// includeAllLabels: Return all labels for the pod in the response.
+	// since: Only include intents last seen at or after this time.
+	// until: Only include intents last seen at or before this time.
 	Intents []IntentsIntentsIntent `json:"intents"`
 }
 
@@ -212,6 +225,16 @@ func (v *ReportTCPCaptureResultsResponse) GetReportTCPCaptureResults() bool {
 	return v.ReportTCPCaptureResults
 }
 
+// ReportTLSCaptureResultsResponse is returned by ReportTLSCaptureResults on success.
+type ReportTLSCaptureResultsResponse struct {
+	ReportTLSCaptureResults bool `json:"reportTLSCaptureResults"`
+}
+
+// GetReportTLSCaptureResults returns ReportTLSCaptureResultsResponse.ReportTLSCaptureResults, and is useful for accessing the field via an interface.
+func (v *ReportTLSCaptureResultsResponse) GetReportTLSCaptureResults() bool {
+	return v.ReportTLSCaptureResults
+}
+
 type ServerFilter struct {
 	Name      string `json:"name"`
 	Namespace string `json:"namespace"`
@@ -230,6 +253,8 @@ type ServiceIntentsResponse struct {
 	// namespaces: Namespaces filter.
 	// includeLabels: Labels to include in the response. Ignored if includeAllLabels is specified.
 	// includeAllLabels: Return all labels for the pod in the response.
+	// since: Only include intents last seen at or after this time.
+	// until: Only include intents last seen at or before this time.
 	ServiceIntents []ServiceIntentsServiceIntents `json:"serviceIntents"`
 }
 
@@ -325,6 +350,13 @@ type SocketScanResults struct {
 // GetResults returns SocketScanResults.Results, and is useful for accessing the field via an interface.
 func (v *SocketScanResults) GetResults() []RecordedDestinationsForSrc { return v.Results }
 
+type TransportProtocol string
+
+const (
+	TransportProtocolTcp TransportProtocol = "TCP"
+	TransportProtocolUdp TransportProtocol = "UDP"
+)
+
 // __IntentsInput is used internally by genqlient
 type __IntentsInput struct {
 	Namespaces               []string              `json:"namespaces"`
@@ -373,6 +405,14 @@ type __ReportTCPCaptureResultsInput struct {
 // GetResults returns __ReportTCPCaptureResultsInput.Results, and is useful for accessing the field via an interface.
 func (v *__ReportTCPCaptureResultsInput) GetResults() CaptureTCPResults { return v.Results }
 
+// __ReportTLSCaptureResultsInput is used internally by genqlient
+type __ReportTLSCaptureResultsInput struct {
+	Results CaptureTLSResults `json:"results"`
+}
+
+// GetResults returns __ReportTLSCaptureResultsInput.Results, and is useful for accessing the field via an interface.
+func (v *__ReportTLSCaptureResultsInput) GetResults() CaptureTLSResults { return v.Results }
+
 // __ServiceIntentsInput is used internally by genqlient
 type __ServiceIntentsInput struct {
 	Namespaces []string `json:"namespaces"`
@@ -541,6 +581,39 @@ func ReportTCPCaptureResults(
 	return &data_, err_
 }
 
+// The query or mutation executed by ReportTLSCaptureResults.
+const ReportTLSCaptureResults_Operation = `
+mutation ReportTLSCaptureResults ($results: CaptureTLSResults!) {
+	reportTLSCaptureResults(results: $results)
+}
+`
+
+func ReportTLSCaptureResults(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	results CaptureTLSResults,
+) (*ReportTLSCaptureResultsResponse, error) {
+	req_ := &graphql.Request{
+		OpName: "ReportTLSCaptureResults",
+		Query:  ReportTLSCaptureResults_Operation,
+		Variables: &__ReportTLSCaptureResultsInput{
+			Results: results,
+		},
+	}
+	var err_ error
+
+	var data_ ReportTLSCaptureResultsResponse
+	resp_ := &graphql.Response{Data: &data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return &data_, err_
+}
+
 // The query or mutation executed by ServiceIntents.
 const ServiceIntents_Operation = `
 query ServiceIntents ($namespaces: [String!]) {
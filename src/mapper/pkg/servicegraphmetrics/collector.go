@@ -0,0 +1,119 @@
+package servicegraphmetrics
+
+import (
+	"context"
+	"github.com/otterize/network-mapper/src/mapper/pkg/collectors/traffic"
+	"github.com/otterize/network-mapper/src/mapper/pkg/concurrentconnectioncounter"
+	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
+	"github.com/otterize/network-mapper/src/mapper/pkg/intentsstore"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"sync"
+)
+
+var (
+	edgeLabels = []string{"client", "client_namespace", "server", "server_namespace", "intent_type", "protocol", "source"}
+	pairLabels = []string{"client", "client_namespace", "server", "server_namespace"}
+
+	edgeDesc = prometheus.NewDesc(
+		"network_mapper_service_graph_edge",
+		"Set to 1 for every client/server pair discovered by the network mapper",
+		edgeLabels, nil,
+	)
+	edgeLastSeenDesc = prometheus.NewDesc(
+		"network_mapper_service_graph_edge_last_seen_timestamp_seconds",
+		"The last time traffic was seen between a client and a server, in seconds since the epoch",
+		edgeLabels, nil,
+	)
+	trafficBytesDesc = prometheus.NewDesc(
+		"network_mapper_service_graph_traffic_bytes_per_second",
+		"Average bytes per second sent from a client to a server over the last hour",
+		pairLabels, nil,
+	)
+	trafficFlowsDesc = prometheus.NewDesc(
+		"network_mapper_service_graph_traffic_flows_per_second",
+		"Average flows per second from a client to a server over the last hour",
+		pairLabels, nil,
+	)
+)
+
+var resolutionSources = map[string]string{
+	concurrentconnectioncounter.SocketScanServiceIntentResolution: "socketscan",
+	concurrentconnectioncounter.SocketScanPodIntentResolution:     "socketscan",
+	concurrentconnectioncounter.TCPTrafficIntentResolution:        "tcp",
+	concurrentconnectioncounter.DNSTrafficIntentResolution:        "dns",
+	concurrentconnectioncounter.KafkaResultIntentResolution:       "kafka",
+	concurrentconnectioncounter.IstioResultIntentResolution:       "istio",
+	concurrentconnectioncounter.HTTPResultIntentResolution:        "http",
+	concurrentconnectioncounter.LinkerdResultIntentResolution:     "linkerd",
+	concurrentconnectioncounter.AccessLogIntentResolution:         "accesslog",
+}
+
+// Collector is a prometheus.Collector that exposes the service graph known to the network mapper at scrape time.
+type Collector struct {
+	intentsHolder *intentsstore.IntentsHolder
+	trafficLevels traffic.TrafficLevelMap
+	lock          sync.Mutex
+}
+
+func NewCollector(intentsHolder *intentsstore.IntentsHolder) *Collector {
+	return &Collector{
+		intentsHolder: intentsHolder,
+		trafficLevels: make(traffic.TrafficLevelMap),
+	}
+}
+
+// NotifyTrafficLevels keeps the latest traffic levels, so that they can be served on scrape. It is meant to be
+// registered with traffic.Collector.RegisterNotifyTraffic, as reading the traffic collector directly is not
+// thread-safe.
+func (c *Collector) NotifyTrafficLevels(_ context.Context, trafficLevels traffic.TrafficLevelMap) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.trafficLevels = trafficLevels
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- edgeDesc
+	ch <- edgeLastSeenDesc
+	ch <- trafficBytesDesc
+	ch <- trafficFlowsDesc
+}
+
+func resolutionSource(resolutionData *string) string {
+	source, ok := resolutionSources[lo.FromPtr(resolutionData)]
+	if !ok {
+		return "unknown"
+	}
+	return source
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	intents, err := c.intentsHolder.GetIntents(nil, nil, nil, false, nil, nil, nil)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get intents for service graph metrics")
+		return
+	}
+
+	for _, intent := range intents {
+		labels := []string{
+			intent.Intent.Client.Name,
+			intent.Intent.Client.Namespace,
+			intent.Intent.Server.Name,
+			intent.Intent.Server.Namespace,
+			string(lo.FromPtr(intent.Intent.Type)),
+			string(lo.FromPtrOr(intent.Intent.Protocol, model.TransportProtocolTCP)),
+			resolutionSource(intent.Intent.ResolutionData),
+		}
+		ch <- prometheus.MustNewConstMetric(edgeDesc, prometheus.GaugeValue, 1, labels...)
+		ch <- prometheus.MustNewConstMetric(edgeLastSeenDesc, prometheus.GaugeValue, float64(intent.Timestamp.Unix()), labels...)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for key, data := range c.trafficLevels {
+		labels := []string{key.SourceName, key.SourceNamespace, key.DestinationName, key.DestinationNamespace}
+		ch <- prometheus.MustNewConstMetric(trafficBytesDesc, prometheus.GaugeValue, float64(data.Bytes), labels...)
+		ch <- prometheus.MustNewConstMetric(trafficFlowsDesc, prometheus.GaugeValue, float64(data.Flows), labels...)
+	}
+}
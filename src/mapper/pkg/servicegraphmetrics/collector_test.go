@@ -0,0 +1,84 @@
+package servicegraphmetrics
+
+import (
+	"context"
+	"fmt"
+	"github.com/otterize/network-mapper/src/mapper/pkg/collectors/traffic"
+	"github.com/otterize/network-mapper/src/mapper/pkg/concurrentconnectioncounter"
+	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
+	"github.com/otterize/network-mapper/src/mapper/pkg/intentsstore"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	testTimestamp = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+type ServiceGraphCollectorTestSuite struct {
+	suite.Suite
+	intentsHolder *intentsstore.IntentsHolder
+	collector     *Collector
+}
+
+func (s *ServiceGraphCollectorTestSuite) SetupTest() {
+	s.intentsHolder = intentsstore.NewIntentsHolder()
+	s.collector = NewCollector(s.intentsHolder)
+}
+
+func (s *ServiceGraphCollectorTestSuite) TestCollectEdges() {
+	s.intentsHolder.AddIntent(testTimestamp, model.Intent{
+		Client:         &model.OtterizeServiceIdentity{Name: "client1", Namespace: "ns1"},
+		Server:         &model.OtterizeServiceIdentity{Name: "server1", Namespace: "ns2"},
+		ResolutionData: lo.ToPtr(concurrentconnectioncounter.DNSTrafficIntentResolution),
+	}, nil)
+	s.intentsHolder.AddIntent(testTimestamp, model.Intent{
+		Client:         &model.OtterizeServiceIdentity{Name: "client1", Namespace: "ns1"},
+		Server:         &model.OtterizeServiceIdentity{Name: "kafka", Namespace: "ns2"},
+		Type:           lo.ToPtr(model.IntentTypeKafka),
+		ResolutionData: lo.ToPtr(concurrentconnectioncounter.KafkaResultIntentResolution),
+	}, nil)
+
+	expected := fmt.Sprintf(`
+# HELP network_mapper_service_graph_edge Set to 1 for every client/server pair discovered by the network mapper
+# TYPE network_mapper_service_graph_edge gauge
+network_mapper_service_graph_edge{client="client1",client_namespace="ns1",intent_type="",protocol="TCP",server="server1",server_namespace="ns2",source="dns"} 1
+network_mapper_service_graph_edge{client="client1",client_namespace="ns1",intent_type="KAFKA",protocol="TCP",server="kafka",server_namespace="ns2",source="kafka"} 1
+# HELP network_mapper_service_graph_edge_last_seen_timestamp_seconds The last time traffic was seen between a client and a server, in seconds since the epoch
+# TYPE network_mapper_service_graph_edge_last_seen_timestamp_seconds gauge
+network_mapper_service_graph_edge_last_seen_timestamp_seconds{client="client1",client_namespace="ns1",intent_type="",protocol="TCP",server="server1",server_namespace="ns2",source="dns"} %[1]d
+network_mapper_service_graph_edge_last_seen_timestamp_seconds{client="client1",client_namespace="ns1",intent_type="KAFKA",protocol="TCP",server="kafka",server_namespace="ns2",source="kafka"} %[1]d
+`, testTimestamp.Unix())
+
+	s.Require().NoError(testutil.CollectAndCompare(s.collector, strings.NewReader(expected),
+		"network_mapper_service_graph_edge", "network_mapper_service_graph_edge_last_seen_timestamp_seconds"))
+}
+
+func (s *ServiceGraphCollectorTestSuite) TestCollectTrafficLevels() {
+	s.collector.NotifyTrafficLevels(context.Background(), traffic.TrafficLevelMap{
+		traffic.TrafficLevelKey{
+			SourceName:           "client1",
+			SourceNamespace:      "ns1",
+			DestinationName:      "server1",
+			DestinationNamespace: "ns2",
+		}: traffic.TrafficLevelData{Bytes: 100, Flows: 2},
+	})
+
+	expected := `
+# HELP network_mapper_service_graph_traffic_bytes_per_second Average bytes per second sent from a client to a server over the last hour
+# TYPE network_mapper_service_graph_traffic_bytes_per_second gauge
+network_mapper_service_graph_traffic_bytes_per_second{client="client1",client_namespace="ns1",server="server1",server_namespace="ns2"} 100
+# HELP network_mapper_service_graph_traffic_flows_per_second Average flows per second from a client to a server over the last hour
+# TYPE network_mapper_service_graph_traffic_flows_per_second gauge
+network_mapper_service_graph_traffic_flows_per_second{client="client1",client_namespace="ns1",server="server1",server_namespace="ns2"} 2
+`
+	s.Require().NoError(testutil.CollectAndCompare(s.collector, strings.NewReader(expected)))
+}
+
+func TestServiceGraphCollectorTestSuite(t *testing.T) {
+	suite.Run(t, new(ServiceGraphCollectorTestSuite))
+}
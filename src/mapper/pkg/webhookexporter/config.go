@@ -0,0 +1,36 @@
+package webhookexporter
+
+import (
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"github.com/otterize/network-mapper/src/mapper/pkg/config"
+	"github.com/spf13/viper"
+	"time"
+)
+
+type Config struct {
+	URL             string
+	AuthHeaderName  string
+	AuthHeaderValue string
+	BatchSize       int
+	MaxRetries      int
+	Timeout         time.Duration
+}
+
+func ConfigFromViper() (Config, error) {
+	c := Config{
+		URL:             viper.GetString(config.WebhookExportURLKey),
+		AuthHeaderName:  viper.GetString(config.WebhookExportAuthHeaderNameKey),
+		AuthHeaderValue: viper.GetString(config.WebhookExportAuthHeaderValueKey),
+		BatchSize:       viper.GetInt(config.WebhookExportBatchSizeKey),
+		MaxRetries:      viper.GetInt(config.WebhookExportMaxRetriesKey),
+		Timeout:         viper.GetDuration(config.WebhookExportTimeoutKey),
+	}
+	if c.Enabled() && c.BatchSize < 1 {
+		return Config{}, errors.Errorf("%s must be at least 1, got %d", config.WebhookExportBatchSizeKey, c.BatchSize)
+	}
+	return c, nil
+}
+
+func (c Config) Enabled() bool {
+	return c.URL != ""
+}
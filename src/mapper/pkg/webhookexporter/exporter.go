@@ -0,0 +1,146 @@
+package webhookexporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"github.com/otterize/network-mapper/src/mapper/pkg/awsintentsholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/collectors/traffic"
+	"github.com/otterize/network-mapper/src/mapper/pkg/exportpayload"
+	"github.com/otterize/network-mapper/src/mapper/pkg/externaltrafficholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/gcpintentsholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
+	"github.com/otterize/network-mapper/src/mapper/pkg/incomingtrafficholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/intentsstore"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"time"
+)
+
+// queueSize is the number of notifications waiting to be sent to the webhook, beyond which new notifications are dropped.
+const queueSize = 100
+
+// Exporter POSTs the same notifications the cloud uploader receives to a user-provided webhook, as Payload documents.
+// Notifications are queued and sent by RunForever, so retrying a webhook that is down does not hold up the holders that
+// notify it.
+type Exporter struct {
+	config     Config
+	httpClient *http.Client
+	queue      chan func(ctx context.Context)
+}
+
+func NewExporter(config Config) *Exporter {
+	return &Exporter{
+		config:     config,
+		httpClient: &http.Client{Timeout: config.Timeout},
+		queue:      make(chan func(ctx context.Context), queueSize),
+	}
+}
+
+func (e *Exporter) RunForever(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sendQueued := <-e.queue:
+			sendQueued(ctx)
+		}
+	}
+}
+
+func (e *Exporter) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return backoff.Permanent(errors.Wrap(err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.config.AuthHeaderValue != "" {
+		req.Header.Set(e.config.AuthHeaderName, e.config.AuthHeaderValue)
+	}
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	err = errors.Errorf("webhook responded with status %d", resp.StatusCode)
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		// Retrying won't help a request the webhook rejected.
+		return backoff.Permanent(err)
+	}
+	return err
+}
+
+func enqueue[T any](e *Exporter, kind exportpayload.Kind, items []T) {
+	if len(items) == 0 {
+		return
+	}
+
+	select {
+	case e.queue <- func(ctx context.Context) { send(ctx, e, kind, items) }:
+	default:
+		logrus.WithField("kind", kind).Warningf("Webhook queue is full, dropping %d items", len(items))
+	}
+}
+
+func send[T any](ctx context.Context, e *Exporter, kind exportpayload.Kind, items []T) {
+	for i, chunk := range lo.Chunk(items, e.config.BatchSize) {
+		body, err := json.Marshal(Payload[T]{
+			Kind:   kind,
+			SentAt: time.Now(),
+			Items:  chunk,
+		})
+		if err != nil {
+			logrus.WithError(err).WithField("kind", kind).Error("Failed to marshal webhook payload")
+			return
+		}
+
+		retryPolicy := backoff.WithContext(backoff.WithMaxRetries(backoff.NewExponentialBackOff(), uint64(e.config.MaxRetries)), ctx)
+		err = backoff.RetryNotify(func() error {
+			return e.post(ctx, body)
+		}, retryPolicy, func(err error, _ time.Duration) {
+			logrus.WithError(err).WithField("kind", kind).Warnf("Failed to send chunk %d to webhook, retrying", i)
+		})
+		if err != nil {
+			logrus.WithError(err).WithField("kind", kind).Errorf("Failed to send chunk %d to webhook, giving up", i)
+			return
+		}
+	}
+}
+
+func (e *Exporter) NotifyIntents(_ context.Context, intents []intentsstore.TimestampedIntent) {
+	enqueue(e, exportpayload.KindIntents, exportpayload.FromIntents(intents))
+}
+
+func (e *Exporter) NotifyExternalTrafficIntents(_ context.Context, intents []externaltrafficholder.TimestampedExternalTrafficIntent) {
+	enqueue(e, exportpayload.KindExternalTrafficIntents, exportpayload.FromExternalTrafficIntents(intents))
+}
+
+func (e *Exporter) NotifyIncomingTrafficIntents(_ context.Context, intents []incomingtrafficholder.TimestampedIncomingTrafficIntent) {
+	enqueue(e, exportpayload.KindIncomingTrafficIntents, exportpayload.FromIncomingTrafficIntents(intents))
+}
+
+func (e *Exporter) NotifyAWSIntents(_ context.Context, intents []awsintentsholder.AWSIntent) {
+	enqueue(e, exportpayload.KindAWSIntents, exportpayload.FromAWSIntents(intents))
+}
+
+func (e *Exporter) NotifyGCPIntents(_ context.Context, intents []gcpintentsholder.GCPIntent) {
+	enqueue(e, exportpayload.KindGCPIntents, exportpayload.FromGCPIntents(intents))
+}
+
+func (e *Exporter) NotifyAzureIntents(_ context.Context, ops []model.AzureOperation) {
+	enqueue(e, exportpayload.KindAzureIntents, exportpayload.FromAzureIntents(ops))
+}
+
+func (e *Exporter) NotifyTrafficLevels(_ context.Context, trafficLevels traffic.TrafficLevelMap) {
+	enqueue(e, exportpayload.KindTrafficLevels, exportpayload.FromTrafficLevels(trafficLevels))
+}
@@ -0,0 +1,205 @@
+package webhookexporter
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/otterize/network-mapper/src/mapper/pkg/awsintentsholder"
+	"github.com/otterize/network-mapper/src/mapper/pkg/cloudclient"
+	"github.com/otterize/network-mapper/src/mapper/pkg/config"
+	"github.com/otterize/network-mapper/src/mapper/pkg/exportpayload"
+	"github.com/otterize/network-mapper/src/mapper/pkg/graph/model"
+	"github.com/otterize/network-mapper/src/mapper/pkg/intentsstore"
+	"github.com/samber/lo"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+var (
+	testTimestamp = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+type WebhookExporterTestSuite struct {
+	suite.Suite
+	server        *httptest.Server
+	lock          sync.Mutex
+	requests      []receivedRequest
+	failResponses int
+	status        int
+}
+
+func (s *WebhookExporterTestSuite) SetupTest() {
+	s.requests = nil
+	s.failResponses = 0
+	s.status = http.StatusInternalServerError
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		if s.failResponses > 0 {
+			s.failResponses--
+			w.WriteHeader(s.status)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		s.Require().NoError(err)
+		s.requests = append(s.requests, receivedRequest{header: r.Header.Clone(), body: body})
+		w.WriteHeader(http.StatusNoContent)
+	}))
+}
+
+func (s *WebhookExporterTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *WebhookExporterTestSuite) newExporter(batchSize int) *Exporter {
+	return NewExporter(Config{
+		URL:             s.server.URL,
+		AuthHeaderName:  "Authorization",
+		AuthHeaderValue: "Bearer secret",
+		BatchSize:       batchSize,
+		MaxRetries:      3,
+		Timeout:         time.Second,
+	})
+}
+
+// sendQueued sends the notifications queued by the exporter, as RunForever does.
+func (s *WebhookExporterTestSuite) sendQueued(exporter *Exporter) {
+	for len(exporter.queue) > 0 {
+		(<-exporter.queue)(context.Background())
+	}
+}
+
+func (s *WebhookExporterTestSuite) decodeIntents(request receivedRequest) Payload[exportpayload.Intent] {
+	var payload Payload[exportpayload.Intent]
+	s.Require().NoError(json.Unmarshal(request.body, &payload))
+	return payload
+}
+
+func (s *WebhookExporterTestSuite) intent(client string, server string) intentsstore.TimestampedIntent {
+	return intentsstore.TimestampedIntent{
+		Timestamp: testTimestamp,
+		ConnectionsCount: &cloudclient.ConnectionsCount{
+			Current: lo.ToPtr(2),
+			Added:   lo.ToPtr(1),
+			Removed: lo.ToPtr(0),
+		},
+		Intent: model.Intent{
+			Client: &model.OtterizeServiceIdentity{Name: client, Namespace: "ns1", PodOwnerKind: &model.GroupVersionKind{Kind: "Deployment"}},
+			Server: &model.OtterizeServiceIdentity{Name: server, Namespace: "ns2", KubernetesService: lo.ToPtr(server + "-svc")},
+			Type:   lo.ToPtr(model.IntentTypeHTTP),
+			HTTPResources: []model.HTTPResource{
+				{Path: "/api", Methods: []model.HTTPMethod{model.HTTPMethodGet}},
+			},
+		},
+	}
+}
+
+func (s *WebhookExporterTestSuite) TestNotifyIntents() {
+	exporter := s.newExporter(100)
+	exporter.NotifyIntents(context.Background(), []intentsstore.TimestampedIntent{s.intent("client1", "server1")})
+	s.sendQueued(exporter)
+
+	s.Require().Len(s.requests, 1)
+	s.Require().Equal("application/json", s.requests[0].header.Get("Content-Type"))
+	s.Require().Equal("Bearer secret", s.requests[0].header.Get("Authorization"))
+
+	payload := s.decodeIntents(s.requests[0])
+	s.Require().Equal(exportpayload.KindIntents, payload.Kind)
+	s.Require().Equal([]exportpayload.Intent{
+		{
+			DiscoveredAt:  testTimestamp,
+			Client:        exportpayload.Workload{Name: "client1", Namespace: "ns1", Kind: "Deployment"},
+			Server:        exportpayload.Workload{Name: "server1", Namespace: "ns2", KubernetesService: "server1-svc"},
+			Type:          "HTTP",
+			HTTPResources: []exportpayload.HTTPResource{{Path: "/api", Methods: []string{"GET"}}},
+			ConnectionsCount: &exportpayload.ConnectionsCount{
+				Current: 2,
+				Added:   1,
+				Removed: 0,
+			},
+		},
+	}, payload.Items)
+}
+
+func (s *WebhookExporterTestSuite) TestNotifyIntentsBatches() {
+	intents := []intentsstore.TimestampedIntent{
+		s.intent("client1", "server1"),
+		s.intent("client2", "server2"),
+		s.intent("client3", "server3"),
+	}
+	exporter := s.newExporter(2)
+	exporter.NotifyIntents(context.Background(), intents)
+	s.sendQueued(exporter)
+
+	s.Require().Len(s.requests, 2)
+	s.Require().Len(s.decodeIntents(s.requests[0]).Items, 2)
+	s.Require().Len(s.decodeIntents(s.requests[1]).Items, 1)
+}
+
+func (s *WebhookExporterTestSuite) TestNotifyEmptyDoesNotSend() {
+	exporter := s.newExporter(100)
+	exporter.NotifyAWSIntents(context.Background(), []awsintentsholder.AWSIntent{})
+
+	s.Require().Empty(exporter.queue)
+	s.Require().Empty(s.requests)
+}
+
+func (s *WebhookExporterTestSuite) TestDropsNotificationsWhenQueueIsFull() {
+	exporter := s.newExporter(100)
+	for i := 0; i < queueSize+1; i++ {
+		exporter.NotifyIntents(context.Background(), []intentsstore.TimestampedIntent{s.intent("client1", "server1")})
+	}
+	s.sendQueued(exporter)
+
+	s.Require().Len(s.requests, queueSize)
+}
+
+func (s *WebhookExporterTestSuite) TestConfigRejectsInvalidBatchSize() {
+	viper.Set(config.WebhookExportURLKey, s.server.URL)
+	viper.Set(config.WebhookExportBatchSizeKey, 0)
+	defer viper.Set(config.WebhookExportURLKey, "")
+	defer viper.Set(config.WebhookExportBatchSizeKey, config.WebhookExportBatchSizeDefault)
+
+	_, err := ConfigFromViper()
+	s.Require().Error(err)
+
+	viper.Set(config.WebhookExportBatchSizeKey, 1)
+	exporterConfig, err := ConfigFromViper()
+	s.Require().NoError(err)
+	s.Require().Equal(1, exporterConfig.BatchSize)
+}
+
+func (s *WebhookExporterTestSuite) TestRetriesOnServerError() {
+	s.failResponses = 1
+	exporter := s.newExporter(100)
+	exporter.NotifyIntents(context.Background(), []intentsstore.TimestampedIntent{s.intent("client1", "server1")})
+	s.sendQueued(exporter)
+
+	s.Require().Len(s.requests, 1)
+	s.Require().Equal(0, s.failResponses)
+}
+
+func (s *WebhookExporterTestSuite) TestDoesNotRetryOnClientError() {
+	s.failResponses = 2
+	s.status = http.StatusBadRequest
+	exporter := s.newExporter(100)
+	exporter.NotifyIntents(context.Background(), []intentsstore.TimestampedIntent{s.intent("client1", "server1")})
+	s.sendQueued(exporter)
+
+	s.Require().Empty(s.requests)
+	s.Require().Equal(1, s.failResponses)
+}
+
+func TestWebhookExporterTestSuite(t *testing.T) {
+	suite.Run(t, new(WebhookExporterTestSuite))
+}
@@ -0,0 +1,14 @@
+package webhookexporter
+
+import (
+	"github.com/otterize/network-mapper/src/mapper/pkg/exportpayload"
+	"time"
+)
+
+// Payload is the JSON body POSTed to the webhook. Its Kind determines the type of its Items, as documented in the
+// exportpayload package.
+type Payload[T any] struct {
+	Kind   exportpayload.Kind `json:"kind"`
+	SentAt time.Time          `json:"sentAt"`
+	Items  []T                `json:"items"`
+}
@@ -46,6 +46,11 @@ func (c *Client) ReportKafkaMapperResults(ctx context.Context, results KafkaMapp
 	return errors.Wrap(err)
 }
 
+func (c *Client) ReportHTTPRequestResults(ctx context.Context, results HTTPRequestResults) error {
+	_, err := reportHTTPRequestResults(ctx, c.client, results)
+	return errors.Wrap(err)
+}
+
 func (c *Client) ReportCaptureResults(ctx context.Context, results CaptureResults) error {
 	_, err := reportCaptureResults(ctx, c.client, results)
 	return errors.Wrap(err)
@@ -56,6 +61,11 @@ func (c *Client) ReportTCPCaptureResults(ctx context.Context, results CaptureTCP
 	return err
 }
 
+func (c *Client) ReportTLSCaptureResults(ctx context.Context, results CaptureTLSResults) error {
+	_, err := reportTLSCaptureResults(ctx, c.client, results)
+	return errors.Wrap(err)
+}
+
 func (c *Client) ReportSocketScanResults(ctx context.Context, results SocketScanResults) error {
 	_, err := reportSocketScanResults(ctx, c.client, results)
 	return errors.Wrap(err)
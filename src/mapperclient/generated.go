@@ -70,13 +70,21 @@ type CaptureTCPResults struct {
 // GetResults returns CaptureTCPResults.Results, and is useful for accessing the field via an interface.
 func (v *CaptureTCPResults) GetResults() []RecordedDestinationsForSrc { return v.Results }
 
+type CaptureTLSResults struct {
+	Results []RecordedDestinationsForSrc `json:"results"`
+}
+
+// GetResults returns CaptureTLSResults.Results, and is useful for accessing the field via an interface.
+func (v *CaptureTLSResults) GetResults() []RecordedDestinationsForSrc { return v.Results }
+
 type Destination struct {
-	Destination     string                  `json:"destination"`
-	DestinationIP   nilable.Nilable[string] `json:"destinationIP"`
-	DestinationPort nilable.Nilable[int]    `json:"destinationPort"`
-	TTL             nilable.Nilable[int]    `json:"TTL"`
-	LastSeen        time.Time               `json:"lastSeen"`
-	SrcPorts        []int                   `json:"srcPorts"`
+	Destination     string                             `json:"destination"`
+	DestinationIP   nilable.Nilable[string]            `json:"destinationIP"`
+	DestinationPort nilable.Nilable[int]               `json:"destinationPort"`
+	TTL             nilable.Nilable[int]               `json:"TTL"`
+	LastSeen        time.Time                          `json:"lastSeen"`
+	SrcPorts        []int                              `json:"srcPorts"`
+	Protocol        nilable.Nilable[TransportProtocol] `json:"protocol"`
 }
 
 // GetDestination returns Destination.Destination, and is useful for accessing the field via an interface.
@@ -97,6 +105,9 @@ func (v *Destination) GetLastSeen() time.Time { return v.LastSeen }
 // GetSrcPorts returns Destination.SrcPorts, and is useful for accessing the field via an interface.
 func (v *Destination) GetSrcPorts() []int { return v.SrcPorts }
 
+// GetProtocol returns Destination.Protocol, and is useful for accessing the field via an interface.
+func (v *Destination) GetProtocol() nilable.Nilable[TransportProtocol] { return v.Protocol }
+
 type GCPOperation struct {
 	Resource    string                          `json:"resource"`
 	Permissions []string                        `json:"permissions"`
@@ -116,6 +127,42 @@ func (v *GCPOperation) GetSrcIp() nilable.Nilable[string] { return v.SrcIp }
 // GetClient returns GCPOperation.Client, and is useful for accessing the field via an interface.
 func (v *GCPOperation) GetClient() nilable.Nilable[NamespacedName] { return v.Client }
 
+// A plaintext HTTP request, sniffed from the traffic between a client and a server.
+type HTTPRequestResult struct {
+	SrcIp   string     `json:"srcIp"`
+	DstIp   string     `json:"dstIp"`
+	DstPort int        `json:"dstPort"`
+	Method  HttpMethod `json:"method"`
+	// The path of the request, without its query string.
+	Path     string    `json:"path"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+// GetSrcIp returns HTTPRequestResult.SrcIp, and is useful for accessing the field via an interface.
+func (v *HTTPRequestResult) GetSrcIp() string { return v.SrcIp }
+
+// GetDstIp returns HTTPRequestResult.DstIp, and is useful for accessing the field via an interface.
+func (v *HTTPRequestResult) GetDstIp() string { return v.DstIp }
+
+// GetDstPort returns HTTPRequestResult.DstPort, and is useful for accessing the field via an interface.
+func (v *HTTPRequestResult) GetDstPort() int { return v.DstPort }
+
+// GetMethod returns HTTPRequestResult.Method, and is useful for accessing the field via an interface.
+func (v *HTTPRequestResult) GetMethod() HttpMethod { return v.Method }
+
+// GetPath returns HTTPRequestResult.Path, and is useful for accessing the field via an interface.
+func (v *HTTPRequestResult) GetPath() string { return v.Path }
+
+// GetLastSeen returns HTTPRequestResult.LastSeen, and is useful for accessing the field via an interface.
+func (v *HTTPRequestResult) GetLastSeen() time.Time { return v.LastSeen }
+
+type HTTPRequestResults struct {
+	Results []HTTPRequestResult `json:"results"`
+}
+
+// GetResults returns HTTPRequestResults.Results, and is useful for accessing the field via an interface.
+func (v *HTTPRequestResults) GetResults() []HTTPRequestResult { return v.Results }
+
 // HealthResponse is returned by Health on success.
 type HealthResponse struct {
 	Health bool `json:"health"`
@@ -124,23 +171,51 @@ type HealthResponse struct {
 // GetHealth returns HealthResponse.Health, and is useful for accessing the field via an interface.
 func (v *HealthResponse) GetHealth() bool { return v.Health }
 
+type HttpMethod string
+
+const (
+	HttpMethodGet     HttpMethod = "GET"
+	HttpMethodPost    HttpMethod = "POST"
+	HttpMethodPut     HttpMethod = "PUT"
+	HttpMethodDelete  HttpMethod = "DELETE"
+	HttpMethodOptions HttpMethod = "OPTIONS"
+	HttpMethodTrace   HttpMethod = "TRACE"
+	HttpMethodPatch   HttpMethod = "PATCH"
+	HttpMethodConnect HttpMethod = "CONNECT"
+	HttpMethodAll     HttpMethod = "ALL"
+)
+
 type KafkaMapperResult struct {
-	SrcIp           string    `json:"srcIp"`
-	ServerPodName   string    `json:"serverPodName"`
-	ServerNamespace string    `json:"serverNamespace"`
-	Topic           string    `json:"topic"`
-	Operation       string    `json:"operation"`
-	LastSeen        time.Time `json:"lastSeen"`
+	SrcIp string `json:"srcIp"`
+	// The server pod. When not set, the server is resolved from serverIp.
+	ServerPodName   nilable.Nilable[string] `json:"serverPodName"`
+	ServerNamespace nilable.Nilable[string] `json:"serverNamespace"`
+	// The IP of the server, for results that were not read from the server itself, such as results sniffed from the
+	// Kafka protocol.
+	ServerIp nilable.Nilable[string] `json:"serverIp"`
+	// The name of the resource, which is a topic unless resourceType is set.
+	Topic        string                             `json:"topic"`
+	Operation    string                             `json:"operation"`
+	LastSeen     time.Time                          `json:"lastSeen"`
+	Principal    nilable.Nilable[string]            `json:"principal"`
+	ResourceType nilable.Nilable[KafkaResourceType] `json:"resourceType"`
+	PatternType  nilable.Nilable[KafkaPatternType]  `json:"patternType"`
+	// The ServiceAccount the principal was mapped to. When its pods belong to a single workload, that workload is used as
+	// the client instead of resolving srcIp.
+	SrcServiceAccount nilable.Nilable[NamespacedName] `json:"srcServiceAccount"`
 }
 
 // GetSrcIp returns KafkaMapperResult.SrcIp, and is useful for accessing the field via an interface.
 func (v *KafkaMapperResult) GetSrcIp() string { return v.SrcIp }
 
 // GetServerPodName returns KafkaMapperResult.ServerPodName, and is useful for accessing the field via an interface.
-func (v *KafkaMapperResult) GetServerPodName() string { return v.ServerPodName }
+func (v *KafkaMapperResult) GetServerPodName() nilable.Nilable[string] { return v.ServerPodName }
 
 // GetServerNamespace returns KafkaMapperResult.ServerNamespace, and is useful for accessing the field via an interface.
-func (v *KafkaMapperResult) GetServerNamespace() string { return v.ServerNamespace }
+func (v *KafkaMapperResult) GetServerNamespace() nilable.Nilable[string] { return v.ServerNamespace }
+
+// GetServerIp returns KafkaMapperResult.ServerIp, and is useful for accessing the field via an interface.
+func (v *KafkaMapperResult) GetServerIp() nilable.Nilable[string] { return v.ServerIp }
 
 // GetTopic returns KafkaMapperResult.Topic, and is useful for accessing the field via an interface.
 func (v *KafkaMapperResult) GetTopic() string { return v.Topic }
@@ -151,6 +226,22 @@ func (v *KafkaMapperResult) GetOperation() string { return v.Operation }
 // GetLastSeen returns KafkaMapperResult.LastSeen, and is useful for accessing the field via an interface.
 func (v *KafkaMapperResult) GetLastSeen() time.Time { return v.LastSeen }
 
+// GetPrincipal returns KafkaMapperResult.Principal, and is useful for accessing the field via an interface.
+func (v *KafkaMapperResult) GetPrincipal() nilable.Nilable[string] { return v.Principal }
+
+// GetResourceType returns KafkaMapperResult.ResourceType, and is useful for accessing the field via an interface.
+func (v *KafkaMapperResult) GetResourceType() nilable.Nilable[KafkaResourceType] {
+	return v.ResourceType
+}
+
+// GetPatternType returns KafkaMapperResult.PatternType, and is useful for accessing the field via an interface.
+func (v *KafkaMapperResult) GetPatternType() nilable.Nilable[KafkaPatternType] { return v.PatternType }
+
+// GetSrcServiceAccount returns KafkaMapperResult.SrcServiceAccount, and is useful for accessing the field via an interface.
+func (v *KafkaMapperResult) GetSrcServiceAccount() nilable.Nilable[NamespacedName] {
+	return v.SrcServiceAccount
+}
+
 type KafkaMapperResults struct {
 	Results []KafkaMapperResult `json:"results"`
 }
@@ -158,6 +249,22 @@ type KafkaMapperResults struct {
 // GetResults returns KafkaMapperResults.Results, and is useful for accessing the field via an interface.
 func (v *KafkaMapperResults) GetResults() []KafkaMapperResult { return v.Results }
 
+type KafkaPatternType string
+
+const (
+	KafkaPatternTypeLiteral  KafkaPatternType = "LITERAL"
+	KafkaPatternTypePrefixed KafkaPatternType = "PREFIXED"
+)
+
+type KafkaResourceType string
+
+const (
+	KafkaResourceTypeTopic           KafkaResourceType = "TOPIC"
+	KafkaResourceTypeGroup           KafkaResourceType = "GROUP"
+	KafkaResourceTypeTransactionalId KafkaResourceType = "TRANSACTIONAL_ID"
+	KafkaResourceTypeCluster         KafkaResourceType = "CLUSTER"
+)
+
 type NamespacedName struct {
 	Name      string `json:"name"`
 	Namespace string `json:"namespace"`
@@ -170,9 +277,12 @@ func (v *NamespacedName) GetName() string { return v.Name }
 func (v *NamespacedName) GetNamespace() string { return v.Namespace }
 
 type RecordedDestinationsForSrc struct {
-	SrcIp        string        `json:"srcIp"`
-	SrcHostname  string        `json:"srcHostname"`
-	Destinations []Destination `json:"destinations"`
+	SrcIp       string `json:"srcIp"`
+	SrcHostname string `json:"srcHostname"`
+	// The ID of the container the requests were made from, when traced at the socket. It identifies the source pod even
+	// if srcIp was since reused by another pod.
+	SrcContainerId nilable.Nilable[string] `json:"srcContainerId"`
+	Destinations   []Destination           `json:"destinations"`
 }
 
 // GetSrcIp returns RecordedDestinationsForSrc.SrcIp, and is useful for accessing the field via an interface.
@@ -181,6 +291,11 @@ func (v *RecordedDestinationsForSrc) GetSrcIp() string { return v.SrcIp }
 // GetSrcHostname returns RecordedDestinationsForSrc.SrcHostname, and is useful for accessing the field via an interface.
 func (v *RecordedDestinationsForSrc) GetSrcHostname() string { return v.SrcHostname }
 
+// GetSrcContainerId returns RecordedDestinationsForSrc.SrcContainerId, and is useful for accessing the field via an interface.
+func (v *RecordedDestinationsForSrc) GetSrcContainerId() nilable.Nilable[string] {
+	return v.SrcContainerId
+}
+
 // GetDestinations returns RecordedDestinationsForSrc.Destinations, and is useful for accessing the field via an interface.
 func (v *RecordedDestinationsForSrc) GetDestinations() []Destination { return v.Destinations }
 
@@ -217,6 +332,13 @@ type TrafficLevelResults struct {
 // GetResults returns TrafficLevelResults.Results, and is useful for accessing the field via an interface.
 func (v *TrafficLevelResults) GetResults() []TrafficLevelResult { return v.Results }
 
+type TransportProtocol string
+
+const (
+	TransportProtocolTcp TransportProtocol = "TCP"
+	TransportProtocolUdp TransportProtocol = "UDP"
+)
+
 // __reportAWSOperationInput is used internally by genqlient
 type __reportAWSOperationInput struct {
 	Operation []AWSOperation `json:"operation"`
@@ -249,6 +371,14 @@ type __reportGCPOperationInput struct {
 // GetOperation returns __reportGCPOperationInput.Operation, and is useful for accessing the field via an interface.
 func (v *__reportGCPOperationInput) GetOperation() []GCPOperation { return v.Operation }
 
+// __reportHTTPRequestResultsInput is used internally by genqlient
+type __reportHTTPRequestResultsInput struct {
+	Results HTTPRequestResults `json:"results"`
+}
+
+// GetResults returns __reportHTTPRequestResultsInput.Results, and is useful for accessing the field via an interface.
+func (v *__reportHTTPRequestResultsInput) GetResults() HTTPRequestResults { return v.Results }
+
 // __reportKafkaMapperResultsInput is used internally by genqlient
 type __reportKafkaMapperResultsInput struct {
 	Results KafkaMapperResults `json:"results"`
@@ -273,6 +403,14 @@ type __reportTCPCaptureResultsInput struct {
 // GetResults returns __reportTCPCaptureResultsInput.Results, and is useful for accessing the field via an interface.
 func (v *__reportTCPCaptureResultsInput) GetResults() CaptureTCPResults { return v.Results }
 
+// __reportTLSCaptureResultsInput is used internally by genqlient
+type __reportTLSCaptureResultsInput struct {
+	Results CaptureTLSResults `json:"results"`
+}
+
+// GetResults returns __reportTLSCaptureResultsInput.Results, and is useful for accessing the field via an interface.
+func (v *__reportTLSCaptureResultsInput) GetResults() CaptureTLSResults { return v.Results }
+
 // __reportTrafficLevelResultsInput is used internally by genqlient
 type __reportTrafficLevelResultsInput struct {
 	Results TrafficLevelResults `json:"results"`
@@ -313,6 +451,16 @@ type reportGCPOperationResponse struct {
 // GetReportGCPOperation returns reportGCPOperationResponse.ReportGCPOperation, and is useful for accessing the field via an interface.
 func (v *reportGCPOperationResponse) GetReportGCPOperation() bool { return v.ReportGCPOperation }
 
+// reportHTTPRequestResultsResponse is returned by reportHTTPRequestResults on success.
+type reportHTTPRequestResultsResponse struct {
+	ReportHTTPRequestResults bool `json:"reportHTTPRequestResults"`
+}
+
+// GetReportHTTPRequestResults returns reportHTTPRequestResultsResponse.ReportHTTPRequestResults, and is useful for accessing the field via an interface.
+func (v *reportHTTPRequestResultsResponse) GetReportHTTPRequestResults() bool {
+	return v.ReportHTTPRequestResults
+}
+
 // reportKafkaMapperResultsResponse is returned by reportKafkaMapperResults on success.
 type reportKafkaMapperResultsResponse struct {
 	ReportKafkaMapperResults bool `json:"reportKafkaMapperResults"`
@@ -343,6 +491,16 @@ func (v *reportTCPCaptureResultsResponse) GetReportTCPCaptureResults() bool {
 	return v.ReportTCPCaptureResults
 }
 
+// reportTLSCaptureResultsResponse is returned by reportTLSCaptureResults on success.
+type reportTLSCaptureResultsResponse struct {
+	ReportTLSCaptureResults bool `json:"reportTLSCaptureResults"`
+}
+
+// GetReportTLSCaptureResults returns reportTLSCaptureResultsResponse.ReportTLSCaptureResults, and is useful for accessing the field via an interface.
+func (v *reportTLSCaptureResultsResponse) GetReportTLSCaptureResults() bool {
+	return v.ReportTLSCaptureResults
+}
+
 // reportTrafficLevelResultsResponse is returned by reportTrafficLevelResults on success.
 type reportTrafficLevelResultsResponse struct {
 	ReportTrafficLevelResults bool `json:"reportTrafficLevelResults"`
@@ -514,6 +672,39 @@ func reportGCPOperation(
 	return &data_, err_
 }
 
+// The query or mutation executed by reportHTTPRequestResults.
+const reportHTTPRequestResults_Operation = `
+mutation reportHTTPRequestResults ($results: HTTPRequestResults!) {
+	reportHTTPRequestResults(results: $results)
+}
+`
+
+func reportHTTPRequestResults(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	results HTTPRequestResults,
+) (*reportHTTPRequestResultsResponse, error) {
+	req_ := &graphql.Request{
+		OpName: "reportHTTPRequestResults",
+		Query:  reportHTTPRequestResults_Operation,
+		Variables: &__reportHTTPRequestResultsInput{
+			Results: results,
+		},
+	}
+	var err_ error
+
+	var data_ reportHTTPRequestResultsResponse
+	resp_ := &graphql.Response{Data: &data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return &data_, err_
+}
+
 // The query or mutation executed by reportKafkaMapperResults.
 const reportKafkaMapperResults_Operation = `
 mutation reportKafkaMapperResults ($results: KafkaMapperResults!) {
@@ -613,6 +804,39 @@ func reportTCPCaptureResults(
 	return &data_, err_
 }
 
+// The query or mutation executed by reportTLSCaptureResults.
+const reportTLSCaptureResults_Operation = `
+mutation reportTLSCaptureResults ($results: CaptureTLSResults!) {
+	reportTLSCaptureResults(results: $results)
+}
+`
+
+func reportTLSCaptureResults(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	results CaptureTLSResults,
+) (*reportTLSCaptureResultsResponse, error) {
+	req_ := &graphql.Request{
+		OpName: "reportTLSCaptureResults",
+		Query:  reportTLSCaptureResults_Operation,
+		Variables: &__reportTLSCaptureResultsInput{
+			Results: results,
+		},
+	}
+	var err_ error
+
+	var data_ reportTLSCaptureResultsResponse
+	resp_ := &graphql.Response{Data: &data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return &data_, err_
+}
+
 // The query or mutation executed by reportTrafficLevelResults.
 const reportTrafficLevelResults_Operation = `
 mutation reportTrafficLevelResults ($results: TrafficLevelResults!) {
@@ -0,0 +1,86 @@
+package mapperclient
+
+import (
+	"github.com/samber/lo"
+	"time"
+)
+
+// The functions in this file key and merge results of the same source, for results that are queued before they are
+// reported.
+
+type RecordedSrc struct {
+	SrcIp          string
+	SrcHostname    string
+	SrcContainerId string
+}
+
+func RecordedDestinationsForSrcKey(result RecordedDestinationsForSrc) RecordedSrc {
+	return RecordedSrc{SrcIp: result.SrcIp, SrcHostname: result.SrcHostname, SrcContainerId: result.SrcContainerId.Item}
+}
+
+type destinationKey struct {
+	destination     string
+	destinationIP   string
+	destinationPort int
+	hasPort         bool
+	protocol        TransportProtocol
+}
+
+func newDestinationKey(destination Destination) destinationKey {
+	return destinationKey{
+		destination:     destination.Destination,
+		destinationIP:   destination.DestinationIP.Item,
+		destinationPort: destination.DestinationPort.Item,
+		hasPort:         destination.DestinationPort.Set,
+		protocol:        destination.Protocol.Item,
+	}
+}
+
+// MergeRecordedDestinationsForSrc merges the destinations of two results of the same source, keeping the latest
+// sighting of each destination along with the source ports of both.
+func MergeRecordedDestinationsForSrc(older RecordedDestinationsForSrc, newer RecordedDestinationsForSrc) RecordedDestinationsForSrc {
+	merged := RecordedDestinationsForSrc{SrcIp: newer.SrcIp, SrcHostname: newer.SrcHostname, SrcContainerId: newer.SrcContainerId}
+	indexes := make(map[destinationKey]int)
+	for _, destination := range append(append([]Destination{}, older.Destinations...), newer.Destinations...) {
+		key := newDestinationKey(destination)
+		i, ok := indexes[key]
+		if !ok {
+			indexes[key] = len(merged.Destinations)
+			merged.Destinations = append(merged.Destinations, destination)
+			continue
+		}
+
+		srcPorts := lo.Union(merged.Destinations[i].SrcPorts, destination.SrcPorts)
+		if !destination.LastSeen.Before(merged.Destinations[i].LastSeen) {
+			merged.Destinations[i] = destination
+		}
+		merged.Destinations[i].SrcPorts = srcPorts
+	}
+	return merged
+}
+
+// KafkaMapperResultKey returns the result without its last seen time, so results differing only by it are merged.
+func KafkaMapperResultKey(result KafkaMapperResult) KafkaMapperResult {
+	result.LastSeen = time.Time{}
+	return result
+}
+
+func MergeKafkaMapperResults(older KafkaMapperResult, newer KafkaMapperResult) KafkaMapperResult {
+	if newer.LastSeen.Before(older.LastSeen) {
+		return older
+	}
+	return newer
+}
+
+// HTTPRequestResultKey returns the result without its last seen time, so results differing only by it are merged.
+func HTTPRequestResultKey(result HTTPRequestResult) HTTPRequestResult {
+	result.LastSeen = time.Time{}
+	return result
+}
+
+func MergeHTTPRequestResults(older HTTPRequestResult, newer HTTPRequestResult) HTTPRequestResult {
+	if newer.LastSeen.Before(older.LastSeen) {
+		return older
+	}
+	return newer
+}
@@ -0,0 +1,63 @@
+package mapperclient
+
+import (
+	"github.com/otterize/nilable"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestMergeRecordedDestinationsForSrc(t *testing.T) {
+	earlier := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Minute)
+
+	older := RecordedDestinationsForSrc{
+		SrcIp:       "10.244.0.5",
+		SrcHostname: "client",
+		Destinations: []Destination{
+			{Destination: "10.244.0.12", DestinationIP: nilable.From("10.244.0.12"), DestinationPort: nilable.From(8080), LastSeen: earlier, SrcPorts: []int{40000}},
+			{Destination: "orders.shop.svc.cluster.local", DestinationIP: nilable.From("10.96.0.20"), LastSeen: earlier, TTL: nilable.From(30)},
+		},
+	}
+	newer := RecordedDestinationsForSrc{
+		SrcIp:       "10.244.0.5",
+		SrcHostname: "client",
+		Destinations: []Destination{
+			{Destination: "10.244.0.12", DestinationIP: nilable.From("10.244.0.12"), DestinationPort: nilable.From(8080), LastSeen: later, SrcPorts: []int{40001}},
+			{Destination: "10.244.0.12", DestinationIP: nilable.From("10.244.0.12"), DestinationPort: nilable.From(9090), LastSeen: later, SrcPorts: []int{40002}},
+		},
+	}
+
+	require.Equal(t, RecordedSrc{SrcIp: "10.244.0.5", SrcHostname: "client"}, RecordedDestinationsForSrcKey(newer))
+	require.Equal(t, RecordedDestinationsForSrc{
+		SrcIp:       "10.244.0.5",
+		SrcHostname: "client",
+		Destinations: []Destination{
+			{Destination: "10.244.0.12", DestinationIP: nilable.From("10.244.0.12"), DestinationPort: nilable.From(8080), LastSeen: later, SrcPorts: []int{40000, 40001}},
+			{Destination: "orders.shop.svc.cluster.local", DestinationIP: nilable.From("10.96.0.20"), LastSeen: earlier, TTL: nilable.From(30)},
+			{Destination: "10.244.0.12", DestinationIP: nilable.From("10.244.0.12"), DestinationPort: nilable.From(9090), LastSeen: later, SrcPorts: []int{40002}},
+		},
+	}, MergeRecordedDestinationsForSrc(older, newer))
+}
+
+func TestMergeRecordedDestinationsForSrcKeepsProtocols(t *testing.T) {
+	lastSeen := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	tcp := Destination{Destination: "10.96.0.30", DestinationIP: nilable.From("10.96.0.30"), DestinationPort: nilable.From(8125), LastSeen: lastSeen, Protocol: nilable.From(TransportProtocolTcp)}
+	udp := Destination{Destination: "10.96.0.30", DestinationIP: nilable.From("10.96.0.30"), DestinationPort: nilable.From(8125), LastSeen: lastSeen, Protocol: nilable.From(TransportProtocolUdp)}
+
+	merged := MergeRecordedDestinationsForSrc(
+		RecordedDestinationsForSrc{SrcIp: "10.244.0.5", Destinations: []Destination{tcp}},
+		RecordedDestinationsForSrc{SrcIp: "10.244.0.5", Destinations: []Destination{udp}},
+	)
+	require.Equal(t, []Destination{tcp, udp}, merged.Destinations)
+}
+
+func TestMergeHTTPRequestResults(t *testing.T) {
+	earlier := HTTPRequestResult{SrcIp: "10.244.0.5", DstIp: "10.244.0.12", DstPort: 8080, Method: HttpMethodGet, Path: "/api/orders", LastSeen: time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)}
+	later := earlier
+	later.LastSeen = earlier.LastSeen.Add(time.Minute)
+
+	require.Equal(t, HTTPRequestResultKey(earlier), HTTPRequestResultKey(later))
+	require.Equal(t, later, MergeHTTPRequestResults(earlier, later))
+	require.Equal(t, later, MergeHTTPRequestResults(later, earlier))
+}
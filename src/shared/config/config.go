@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/spf13/viper"
 	"strings"
+	"time"
 )
 
 /*
@@ -27,6 +28,16 @@ const (
 	EnableSocketScannerDefault   = true
 	EnableDNSKey                 = "enable-dns"
 	EnableDNSSnifferDefault      = true
+	EnableTLSKey                 = "enable-tls" // Capture the server names (SNI) of outbound TLS connections
+	EnableTLSSnifferDefault      = false
+
+	ReportQueueMaxSizeKey            = "report-queue-max-size" // Results queued for reporting to the mapper, beyond which the oldest are dropped
+	ReportQueueMaxSizeDefault        = 10000
+	ReportQueueInitialBackoffKey     = "report-queue-initial-backoff"
+	ReportQueueInitialBackoffDefault = 1 * time.Second
+	ReportQueueMaxBackoffKey         = "report-queue-max-backoff"
+	ReportQueueMaxBackoffDefault     = 1 * time.Minute
+	ReportQueueDirKey                = "report-queue-dir" // Persists queued reports across restarts, disabled when empty
 
 	EnvPodKey       = "pod"
 	EnvNamespaceKey = "namespace"
@@ -49,6 +60,11 @@ func init() {
 	viper.SetDefault(EnableTCPKey, EnableTCPSnifferDefault)
 	viper.SetDefault(EnableSocketScannerKey, EnableSocketScannerDefault)
 	viper.SetDefault(EnableDNSKey, EnableDNSSnifferDefault)
+	viper.SetDefault(EnableTLSKey, EnableTLSSnifferDefault)
+	viper.SetDefault(ReportQueueMaxSizeKey, ReportQueueMaxSizeDefault)
+	viper.SetDefault(ReportQueueInitialBackoffKey, ReportQueueInitialBackoffDefault)
+	viper.SetDefault(ReportQueueMaxBackoffKey, ReportQueueMaxBackoffDefault)
+	viper.SetDefault(ReportQueueDirKey, "")
 	viper.SetEnvPrefix(envPrefix)
 	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 	viper.AutomaticEnv()
@@ -0,0 +1,155 @@
+package prometheusmetrics
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// SourcePrometheus is the metrics source shared by the service mesh watchers, reading metrics from a Prometheus server
+// that already scrapes the mesh's proxies instead of scraping them directly.
+const SourcePrometheus = "prometheus"
+
+// QueryPath is the path of the Prometheus HTTP API endpoint for instant queries.
+const QueryPath = "/api/v1/query"
+
+// Sample is a single sample of an instant vector returned by a Prometheus query.
+type Sample struct {
+	Labels map[string]string
+	Value  int
+}
+
+type queryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			Value  []any             `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// ValidateSource checks that source is either one of localSources or SourcePrometheus, in which case prometheusURL,
+// configured by prometheusURLKey, must be set.
+func ValidateSource(source string, prometheusURL string, prometheusURLKey string, localSources ...string) error {
+	if source == SourcePrometheus {
+		if prometheusURL == "" {
+			return errors.Errorf("%s must be set when the metrics source is %s", prometheusURLKey, SourcePrometheus)
+		}
+		return nil
+	}
+
+	for _, localSource := range localSources {
+		if source == localSource {
+			return nil
+		}
+	}
+	return errors.Errorf("unknown metrics source: %s", source)
+}
+
+// Fetch sends a GET request to target, returning an error for any response other than 200 OK. The caller must close
+// the body of the returned response.
+func Fetch(ctx context.Context, client *http.Client, target string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, errors.Errorf("unexpected status code %d fetching %s", resp.StatusCode, target)
+	}
+
+	return resp, nil
+}
+
+// Scrape reads the metric families served in the Prometheus text format at target.
+func Scrape(ctx context.Context, client *http.Client, target string) (map[string]*dto.MetricFamily, error) {
+	resp, err := Fetch(ctx, client, target)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	defer resp.Body.Close()
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	return families, nil
+}
+
+// Labels returns the labels of a scraped sample by name.
+func Labels(metric *dto.Metric) map[string]string {
+	labels := make(map[string]string, len(metric.GetLabel()))
+	for _, label := range metric.GetLabel() {
+		labels[label.GetName()] = label.GetValue()
+	}
+	return labels
+}
+
+// SampleValue returns the value of a scraped counter, which proxies may also expose as untyped.
+func SampleValue(metric *dto.Metric) int {
+	switch {
+	case metric.GetCounter() != nil:
+		return int(metric.GetCounter().GetValue())
+	case metric.GetUntyped() != nil:
+		return int(metric.GetUntyped().GetValue())
+	default:
+		return 0
+	}
+}
+
+// Query runs an instant query against the Prometheus server at prometheusURL. Samples whose value is not a number
+// are skipped.
+func Query(ctx context.Context, client *http.Client, prometheusURL string, query string) ([]Sample, error) {
+	target := strings.TrimSuffix(prometheusURL, "/") + QueryPath + "?" + url.Values{"query": {query}}.Encode()
+	resp, err := Fetch(ctx, client, target)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	defer resp.Body.Close()
+
+	response := queryResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, errors.Wrap(err)
+	}
+	if response.Status != "success" {
+		return nil, errors.Errorf("Prometheus query failed: %s", response.Error)
+	}
+	if response.Data.ResultType != "vector" {
+		return nil, errors.Errorf("unexpected Prometheus result type: %s", response.Data.ResultType)
+	}
+
+	samples := make([]Sample, 0, len(response.Data.Result))
+	for _, result := range response.Data.Result {
+		if len(result.Value) != 2 {
+			continue
+		}
+		rawValue, ok := result.Value[1].(string)
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseFloat(rawValue, 64)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+			continue
+		}
+		samples = append(samples, Sample{Labels: result.Metric, Value: int(value)})
+	}
+
+	return samples, nil
+}
@@ -0,0 +1,110 @@
+package prometheusmetrics
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const scrapedMetrics = `# TYPE requests_total counter
+requests_total{src="client",dst="server"} 5
+requests_total{src="client",dst="other"} 2
+# TYPE connections untyped
+connections{src="client"} 3
+`
+
+const queryResult = `{
+	"status": "success",
+	"data": {
+		"resultType": "vector",
+		"result": [
+			{"metric": {"src": "client", "dst": "server"}, "value": [1700000000.123, "12"]},
+			{"metric": {"src": "client", "dst": "other"}, "value": [1700000000.123, "NaN"]},
+			{"metric": {"src": "client", "dst": "missing"}, "value": [1700000000.123]}
+		]
+	}
+}`
+
+type PrometheusMetricsTestSuite struct {
+	suite.Suite
+	client *http.Client
+}
+
+func (s *PrometheusMetricsTestSuite) SetupTest() {
+	s.client = &http.Client{}
+}
+
+func (s *PrometheusMetricsTestSuite) TestScrape() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, scrapedMetrics)
+	}))
+	defer server.Close()
+
+	families, err := Scrape(context.Background(), s.client, server.URL)
+	s.Require().NoError(err)
+	s.Require().Contains(families, "requests_total")
+	s.Require().Contains(families, "connections")
+
+	requests := families["requests_total"].GetMetric()
+	s.Require().Len(requests, 2)
+	s.Require().Equal(map[string]string{"src": "client", "dst": "server"}, Labels(requests[0]))
+	s.Require().Equal(5, SampleValue(requests[0]))
+	s.Require().Equal(3, SampleValue(families["connections"].GetMetric()[0]))
+}
+
+func (s *PrometheusMetricsTestSuite) TestFetchUnexpectedStatus() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := Scrape(context.Background(), s.client, server.URL)
+	s.Require().ErrorContains(err, "503")
+}
+
+func (s *PrometheusMetricsTestSuite) TestQuery() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Require().Equal(QueryPath, r.URL.Path)
+		s.Require().Equal("sum(requests_total)", r.URL.Query().Get("query"))
+		fmt.Fprint(w, queryResult)
+	}))
+	defer server.Close()
+
+	samples, err := Query(context.Background(), s.client, server.URL+"/", "sum(requests_total)")
+	s.Require().NoError(err)
+	s.Require().Equal([]Sample{{Labels: map[string]string{"src": "client", "dst": "server"}, Value: 12}}, samples)
+}
+
+func (s *PrometheusMetricsTestSuite) TestQueryError() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": "error", "errorType": "bad_data", "error": "parse error"}`)
+	}))
+	defer server.Close()
+
+	_, err := Query(context.Background(), s.client, server.URL, "sum(")
+	s.Require().ErrorContains(err, "parse error")
+}
+
+func (s *PrometheusMetricsTestSuite) TestQueryUnexpectedResultType() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": "success", "data": {"resultType": "matrix", "result": []}}`)
+	}))
+	defer server.Close()
+
+	_, err := Query(context.Background(), s.client, server.URL, "requests_total[5m]")
+	s.Require().ErrorContains(err, "matrix")
+}
+
+func (s *PrometheusMetricsTestSuite) TestValidateSource() {
+	s.Require().NoError(ValidateSource("proxy", "", "prometheus-url", "proxy"))
+	s.Require().NoError(ValidateSource(SourcePrometheus, "http://prometheus:9090", "prometheus-url", "proxy"))
+	s.Require().ErrorContains(ValidateSource(SourcePrometheus, "", "prometheus-url", "proxy"), "prometheus-url")
+	s.Require().Error(ValidateSource("tap", "", "prometheus-url", "proxy"))
+}
+
+func TestPrometheusMetricsTestSuite(t *testing.T) {
+	suite.Run(t, new(PrometheusMetricsTestSuite))
+}
@@ -0,0 +1,41 @@
+package reportqueue
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	queuedResults = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "report_queue_queued_results",
+		Help: "The total number of results queued for reporting to the mapper",
+	}, []string{"queue"})
+	retriedReports = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "report_queue_retried_reports",
+		Help: "The total number of reports to the mapper that failed and were retried",
+	}, []string{"queue"})
+	droppedResults = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "report_queue_dropped_results",
+		Help: "The total number of queued results dropped because the queue was full",
+	}, []string{"queue"})
+	queueSize = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "report_queue_size",
+		Help: "The number of results waiting to be reported to the mapper",
+	}, []string{"queue"})
+)
+
+func incrementQueued(queue string, count int) {
+	queuedResults.WithLabelValues(queue).Add(float64(count))
+}
+
+func incrementRetries(queue string) {
+	retriedReports.WithLabelValues(queue).Inc()
+}
+
+func incrementDropped(queue string, count int) {
+	droppedResults.WithLabelValues(queue).Add(float64(count))
+}
+
+func setSize(queue string, size int) {
+	queueSize.WithLabelValues(queue).Set(float64(size))
+}
@@ -0,0 +1,300 @@
+package reportqueue
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	sharedconfig "github.com/otterize/network-mapper/src/shared/config"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// SendFunc sends a batch of results to the mapper.
+type SendFunc[T any] func(ctx context.Context, results []T) error
+
+type Options struct {
+	// MaxSize is the number of results queued, beyond which the oldest results are dropped.
+	MaxSize        int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// SendTimeout limits each attempt to send, or is 0 for no limit.
+	SendTimeout time.Duration
+	// FilePath persists queued results so that they are sent after a restart, or is empty to queue in memory only.
+	FilePath string
+}
+
+// OptionsFromConfig returns the options of the queue named name, as set by the shared report queue config keys.
+func OptionsFromConfig(name string) Options {
+	options := Options{
+		MaxSize:        viper.GetInt(sharedconfig.ReportQueueMaxSizeKey),
+		InitialBackoff: viper.GetDuration(sharedconfig.ReportQueueInitialBackoffKey),
+		MaxBackoff:     viper.GetDuration(sharedconfig.ReportQueueMaxBackoffKey),
+	}
+	if dir := viper.GetString(sharedconfig.ReportQueueDirKey); dir != "" {
+		options.FilePath = filepath.Join(dir, name+".json")
+	}
+	return options
+}
+
+type entry[K comparable, T any] struct {
+	key    K
+	result T
+}
+
+// Queue holds results until they are sent to the mapper, retrying with exponential backoff when sending fails. Results
+// with the same key, such as those of the same source, are merged while queued, and the oldest results are dropped
+// when the queue is full.
+type Queue[K comparable, T any] struct {
+	name    string
+	send    SendFunc[T]
+	key     func(T) K
+	merge   func(older T, newer T) T
+	options Options
+
+	lock      sync.Mutex
+	order     *list.List // Oldest first
+	pending   map[K]*list.Element
+	inFlight  []T
+	callbacks []func()
+	notify    chan struct{}
+}
+
+func New[K comparable, T any](name string, send SendFunc[T], key func(T) K, merge func(older T, newer T) T, options Options) *Queue[K, T] {
+	q := &Queue[K, T]{
+		name:    name,
+		send:    send,
+		key:     key,
+		merge:   merge,
+		options: options,
+		order:   list.New(),
+		pending: make(map[K]*list.Element),
+		notify:  make(chan struct{}, 1),
+	}
+
+	// A queue file that cannot be read only loses the reports queued before the restart, so it does not fail startup.
+	results, err := q.load()
+	if err != nil {
+		logrus.WithError(err).Warningf("Failed to load queued %s reports, discarding them", name)
+	} else if len(results) > 0 {
+		logrus.Infof("Loaded %d queued %s reports", len(results), name)
+		q.Enqueue(results, nil)
+	}
+	return q
+}
+
+// Enqueue queues results to be sent, merging them with queued results of the same key. onSent, if set, is called once
+// results were sent or dropped.
+func (q *Queue[K, T]) Enqueue(results []T, onSent func()) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for _, result := range results {
+		key := q.key(result)
+		if element, ok := q.pending[key]; ok {
+			queued := element.Value.(*entry[K, T])
+			queued.result = q.merge(queued.result, result)
+			q.order.MoveToBack(element)
+			continue
+		}
+		q.pending[key] = q.order.PushBack(&entry[K, T]{key: key, result: result})
+	}
+	incrementQueued(q.name, len(results))
+	if onSent != nil {
+		q.callbacks = append(q.callbacks, onSent)
+	}
+	q.dropOverflow()
+	q.persist()
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (q *Queue[K, T]) dropOverflow() {
+	dropped := 0
+	for q.options.MaxSize > 0 && q.order.Len() > q.options.MaxSize {
+		oldest := q.order.Remove(q.order.Front()).(*entry[K, T])
+		delete(q.pending, oldest.key)
+		dropped++
+	}
+	if dropped > 0 {
+		logrus.Warningf("%s report queue is full, dropped the %d oldest results", q.name, dropped)
+		incrementDropped(q.name, dropped)
+	}
+	setSize(q.name, q.order.Len())
+}
+
+// Len returns the number of results waiting to be sent.
+func (q *Queue[K, T]) Len() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.order.Len()
+}
+
+func (q *Queue[K, T]) take() ([]T, []func()) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	results := make([]T, 0, q.order.Len())
+	for element := q.order.Front(); element != nil; element = element.Next() {
+		results = append(results, element.Value.(*entry[K, T]).result)
+	}
+	callbacks := q.callbacks
+	q.order.Init()
+	q.pending = make(map[K]*list.Element)
+	q.callbacks = nil
+	q.inFlight = results
+	setSize(q.name, 0)
+	return results, callbacks
+}
+
+// requeue returns results that failed to send to the front of the queue, as they are older than any results queued
+// while they were being sent.
+func (q *Queue[K, T]) requeue(results []T, callbacks []func()) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for i := len(results) - 1; i >= 0; i-- {
+		key := q.key(results[i])
+		if element, ok := q.pending[key]; ok {
+			queued := element.Value.(*entry[K, T])
+			queued.result = q.merge(results[i], queued.result)
+			continue
+		}
+		q.pending[key] = q.order.PushFront(&entry[K, T]{key: key, result: results[i]})
+	}
+	q.callbacks = append(callbacks, q.callbacks...)
+	q.inFlight = nil
+	q.dropOverflow()
+	q.persist()
+}
+
+func (q *Queue[K, T]) sent() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.inFlight = nil
+	q.persist()
+}
+
+// Flush sends all queued results once, returning them to the queue if sending fails. It must not be called while the
+// queue is running.
+func (q *Queue[K, T]) Flush(ctx context.Context) error {
+	results, callbacks := q.take()
+	if len(results) > 0 {
+		sendCtx := ctx
+		if q.options.SendTimeout > 0 {
+			var cancel context.CancelFunc
+			sendCtx, cancel = context.WithTimeout(ctx, q.options.SendTimeout)
+			defer cancel()
+		}
+		if err := q.send(sendCtx, results); err != nil {
+			q.requeue(results, callbacks)
+			return errors.Wrap(err)
+		}
+		q.sent()
+	}
+
+	for _, callback := range callbacks {
+		callback()
+	}
+	return nil
+}
+
+func (q *Queue[K, T]) nextBackoff(backoff time.Duration) time.Duration {
+	if backoff == 0 {
+		return q.options.InitialBackoff
+	}
+	return min(2*backoff, q.options.MaxBackoff)
+}
+
+// RunForever sends results as they are queued until ctx is done, backing off exponentially while sending fails.
+func (q *Queue[K, T]) RunForever(ctx context.Context) {
+	var backoff time.Duration
+	for {
+		if backoff == 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-q.notify:
+			}
+		} else {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+		}
+
+		err := q.Flush(ctx)
+		if err == nil {
+			backoff = 0
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		backoff = q.nextBackoff(backoff)
+		incrementRetries(q.name)
+		logrus.WithError(err).Warningf("Failed to report %d queued %s results, retrying in %s", q.Len(), q.name, backoff)
+	}
+}
+
+func (q *Queue[K, T]) load() ([]T, error) {
+	if q.options.FilePath == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(q.options.FilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+
+	var results []T
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, errors.Errorf("failed parsing report queue file %s: %w", q.options.FilePath, err)
+	}
+	return results, nil
+}
+
+// persist writes the queued results, including those being sent, to the queue file. It is called with the lock held.
+func (q *Queue[K, T]) persist() {
+	if q.options.FilePath == "" {
+		return
+	}
+	results := append(make([]T, 0, len(q.inFlight)+q.order.Len()), q.inFlight...)
+	for element := q.order.Front(); element != nil; element = element.Next() {
+		results = append(results, element.Value.(*entry[K, T]).result)
+	}
+	if err := writeFile(q.options.FilePath, results); err != nil {
+		logrus.WithError(err).Warningf("Failed to persist queued %s reports", q.name)
+	}
+}
+
+func writeFile(path string, results any) error {
+	data, err := json.Marshal(results)
+	if err != nil {
+		return errors.Wrap(err)
+	}
+	// Write to a temporary file and rename it over the queue file, so a crash never leaves a partially written file.
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return errors.Wrap(err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return errors.Wrap(err)
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err)
+	}
+	return errors.Wrap(os.Rename(tmp.Name(), path))
+}
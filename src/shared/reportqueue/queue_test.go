@@ -0,0 +1,149 @@
+package reportqueue
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/suite"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+type result struct {
+	Source string `json:"source"`
+	Count  int    `json:"count"`
+}
+
+func resultKey(r result) string {
+	return r.Source
+}
+
+func mergeResults(older result, newer result) result {
+	return result{Source: newer.Source, Count: older.Count + newer.Count}
+}
+
+type recordingSender struct {
+	lock    sync.Mutex
+	batches [][]result
+	err     error
+}
+
+func (s *recordingSender) setErr(err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.err = err
+}
+
+func (s *recordingSender) send(_ context.Context, results []result) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.batches = append(s.batches, results)
+	return nil
+}
+
+type QueueTestSuite struct {
+	suite.Suite
+	sender *recordingSender
+}
+
+func (s *QueueTestSuite) SetupTest() {
+	s.sender = &recordingSender{}
+}
+
+func (s *QueueTestSuite) newQueue(options Options) *Queue[string, result] {
+	return New[string, result]("test", s.sender.send, resultKey, mergeResults, options)
+}
+
+func (s *QueueTestSuite) TestMergesResultsOfSameSource() {
+	q := s.newQueue(Options{MaxSize: 10})
+	q.Enqueue([]result{{Source: "a", Count: 1}, {Source: "b", Count: 1}}, nil)
+	q.Enqueue([]result{{Source: "a", Count: 2}}, nil)
+	s.Require().Equal(2, q.Len())
+
+	s.Require().NoError(q.Flush(context.Background()))
+	s.Require().Equal([][]result{{{Source: "b", Count: 1}, {Source: "a", Count: 3}}}, s.sender.batches)
+	s.Require().Zero(q.Len())
+}
+
+func (s *QueueTestSuite) TestDropsOldestWhenFull() {
+	q := s.newQueue(Options{MaxSize: 2})
+	q.Enqueue([]result{{Source: "a", Count: 1}, {Source: "b", Count: 1}}, nil)
+	q.Enqueue([]result{{Source: "c", Count: 1}}, nil)
+
+	s.Require().NoError(q.Flush(context.Background()))
+	s.Require().Equal([][]result{{{Source: "b", Count: 1}, {Source: "c", Count: 1}}}, s.sender.batches)
+}
+
+func (s *QueueTestSuite) TestRequeuesFailedResultsBeforeNewerOnes() {
+	q := s.newQueue(Options{MaxSize: 10})
+	sent := 0
+	q.Enqueue([]result{{Source: "a", Count: 1}}, func() { sent++ })
+
+	s.sender.setErr(errors.New("mapper unavailable"))
+	s.Require().Error(q.Flush(context.Background()))
+	s.Require().Equal(0, sent)
+
+	q.Enqueue([]result{{Source: "b", Count: 1}}, nil)
+	s.sender.setErr(nil)
+	s.Require().NoError(q.Flush(context.Background()))
+	s.Require().Equal(1, sent)
+	s.Require().Equal([][]result{{{Source: "a", Count: 1}, {Source: "b", Count: 1}}}, s.sender.batches)
+}
+
+func (s *QueueTestSuite) TestCallbackOfEmptyReport() {
+	q := s.newQueue(Options{MaxSize: 10})
+	sent := false
+	q.Enqueue(nil, func() { sent = true })
+
+	s.Require().NoError(q.Flush(context.Background()))
+	s.Require().True(sent)
+	s.Require().Empty(s.sender.batches)
+}
+
+func (s *QueueTestSuite) TestPersistsQueuedResults() {
+	path := filepath.Join(s.T().TempDir(), "test.json")
+	s.sender.setErr(errors.New("mapper unavailable"))
+	q := s.newQueue(Options{MaxSize: 10, FilePath: path})
+	q.Enqueue([]result{{Source: "a", Count: 1}}, nil)
+	s.Require().Error(q.Flush(context.Background()))
+
+	s.sender.setErr(nil)
+	restarted := s.newQueue(Options{MaxSize: 10, FilePath: path})
+	s.Require().Equal(1, restarted.Len())
+	s.Require().NoError(restarted.Flush(context.Background()))
+	s.Require().Equal([][]result{{{Source: "a", Count: 1}}}, s.sender.batches)
+
+	s.Require().Zero(s.newQueue(Options{MaxSize: 10, FilePath: path}).Len())
+}
+
+func (s *QueueTestSuite) TestRunForeverRetriesWithBackoff() {
+	s.sender.setErr(errors.New("mapper unavailable"))
+	q := s.newQueue(Options{MaxSize: 10, InitialBackoff: 10 * time.Millisecond, MaxBackoff: 20 * time.Millisecond})
+	s.Require().Equal(10*time.Millisecond, q.nextBackoff(0))
+	s.Require().Equal(20*time.Millisecond, q.nextBackoff(10*time.Millisecond))
+	s.Require().Equal(20*time.Millisecond, q.nextBackoff(20*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sent := make(chan struct{})
+	q.Enqueue([]result{{Source: "a", Count: 1}}, func() { close(sent) })
+	go q.RunForever(ctx)
+
+	time.Sleep(30 * time.Millisecond)
+	s.Require().Equal(1, q.Len())
+	s.sender.setErr(nil)
+
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		s.Fail("queued results were not sent")
+	}
+}
+
+func TestQueueTestSuite(t *testing.T) {
+	suite.Run(t, new(QueueTestSuite))
+}
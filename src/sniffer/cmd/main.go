@@ -16,17 +16,58 @@ import (
 	"github.com/otterize/network-mapper/src/shared/version"
 	"golang.org/x/sync/errgroup"
 	"net/http"
+	"os"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 	"time"
 
 	"github.com/labstack/echo-contrib/echoprometheus"
 	sharedconfig "github.com/otterize/network-mapper/src/shared/config"
+	"github.com/otterize/network-mapper/src/sniffer/pkg/collectors"
+	"github.com/otterize/network-mapper/src/sniffer/pkg/config"
+	"github.com/otterize/network-mapper/src/sniffer/pkg/ipresolver"
 	"github.com/otterize/network-mapper/src/sniffer/pkg/sniffer"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 )
 
+// getReplayPcapFiles returns the capture files to replay instead of sniffing live traffic, given either as arguments of
+// the replay command or by config.
+func getReplayPcapFiles() ([]string, bool) {
+	if len(os.Args) > 1 && os.Args[1] == "replay" {
+		return os.Args[2:], true
+	}
+	files := viper.GetStringSlice(config.ReplayPcapFilesKey)
+	return files, len(files) > 0
+}
+
+func runReplay(ctx context.Context, files []string, kafkaPorts []int, httpPorts []int) error {
+	if len(files) == 0 {
+		return errors.New("no capture files to replay")
+	}
+
+	var reporter sniffer.MapperReporter
+	switch output := viper.GetString(config.ReplayOutputKey); output {
+	case config.ReplayOutputMapper:
+		reporter = mapperclient.New(viper.GetString(sharedconfig.MapperApiUrlKey))
+	case config.ReplayOutputStdout:
+		reporter = sniffer.NewJSONReporter(os.Stdout)
+	default:
+		return errors.Errorf("unknown replay output '%s', expected '%s' or '%s'", output, config.ReplayOutputMapper, config.ReplayOutputStdout)
+	}
+
+	var resolver ipresolver.IPResolver
+	if path := viper.GetString(config.ReplayHostsMappingFileKey); path != "" {
+		staticResolver, err := ipresolver.NewStaticIPResolverFromFile(path)
+		if err != nil {
+			return errors.Wrap(err)
+		}
+		resolver = staticResolver
+	}
+
+	return errors.Wrap(sniffer.NewReplaySniffer(reporter, resolver, kafkaPorts, httpPorts).Replay(ctx, files))
+}
+
 func main() {
 	logrus.SetLevel(logrus.InfoLevel)
 	if viper.GetBool(sharedconfig.DebugKey) {
@@ -35,6 +76,24 @@ func main() {
 	logrus.SetFormatter(&logrus.JSONFormatter{
 		TimestampFormat: time.RFC3339,
 	})
+	kafkaPorts, err := collectors.ParsePorts(viper.GetStringSlice(config.KafkaPortsKey))
+	if err != nil {
+		logrus.WithError(err).Panic("could not parse Kafka ports")
+	}
+	httpPorts, err := collectors.ParsePorts(viper.GetStringSlice(config.HTTPPortsKey))
+	if err != nil {
+		logrus.WithError(err).Panic("could not parse HTTP ports")
+	}
+
+	if replayPcapFiles, replay := getReplayPcapFiles(); replay {
+		err := runReplay(signals.SetupSignalHandler(), replayPcapFiles, kafkaPorts, httpPorts)
+		if err != nil {
+			logrus.WithError(err).Panic("Failed to replay capture files")
+		}
+		logrus.Info("Replay finished")
+		return
+	}
+
 	errgrp, errGroupCtx := errgroup.WithContext(signals.SetupSignalHandler())
 	clusterUID := clusterutils.GetOrCreateClusterUID(errGroupCtx)
 	componentinfo.SetGlobalContextId(telemetrysender.Anonymize(clusterUID))
@@ -86,13 +145,13 @@ func main() {
 	errgrp.Go(func() error {
 		logrus.Debug("Started sniffer")
 		defer errorreporter.AutoNotify()
-		snifferInstance := sniffer.NewSniffer(mapperClient)
+		snifferInstance := sniffer.NewSniffer(mapperClient, kafkaPorts, httpPorts)
 		return snifferInstance.RunForever(errGroupCtx)
 	})
 	<-errGroupCtx.Done()
 	timeoutCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	err := healthServer.Shutdown(timeoutCtx)
+	err = healthServer.Shutdown(timeoutCtx)
 	if err != nil {
 		logrus.WithError(err).Panic("Error when shutting down")
 	}
@@ -1,10 +1,18 @@
 package collectors
 
 import (
+	"fmt"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/otterize/intents-operator/src/shared/errors"
 	"github.com/otterize/network-mapper/src/mapperclient"
+	"github.com/otterize/network-mapper/src/sniffer/pkg/ipresolver"
 	"github.com/otterize/nilable"
 	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
+	"net"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -14,6 +22,7 @@ type UniqueRequest struct {
 	destHostnameOrIP string // IP or hostname
 	destIP           string
 	destPort         nilable.Nilable[int]
+	protocol         nilable.Nilable[mapperclient.TransportProtocol]
 }
 
 type TimeAndTTL struct {
@@ -27,16 +36,83 @@ type capturesMap map[UniqueRequest]TimeAndTTL
 
 type SourcePortsSet map[int]struct{}
 
+// The transport protocols requests are reported with. Requests that are not of a specific transport protocol, such as
+// DNS lookups, are reported with no protocol.
+var (
+	protocolTCP = nilable.From(mapperclient.TransportProtocolTcp)
+	protocolUDP = nilable.From(mapperclient.TransportProtocolUdp)
+	noProtocol  = nilable.Nilable[mapperclient.TransportProtocol]{}
+)
+
+// pendingCapture is a request from a source IP that was resolved to srcHostname. Resolver caches could be outdated, so
+// it is only reported once the same resolving result is verified after the resolver's next refresh.
+type pendingCapture struct {
+	srcIp            string
+	srcHostname      string
+	destHostnameOrIP string
+	destIP           string
+	time             time.Time
+	ttl              nilable.Nilable[int]
+	destPort         *int
+	srcPort          *int
+	protocol         nilable.Nilable[mapperclient.TransportProtocol]
+}
+
 type NetworkCollector struct {
 	capturedRequests capturesMap
+	pending          []pendingCapture
 }
 
 func (c *NetworkCollector) resetData() {
 	c.capturedRequests = make(capturesMap)
 }
 
-func (c *NetworkCollector) addCapturedRequest(srcIp string, srcHost string, destNameOrIP string, destIP string, seenAt time.Time, ttl nilable.Nilable[int], destPort *int, srcPort *int) {
-	req := UniqueRequest{srcIp, srcHost, destNameOrIP, destIP, nilable.FromPtr(destPort)}
+// normalizeIP returns the canonical string form of an IPv4 or IPv6 address, so that the same address is always
+// reported the same way (e.g. IPv4-mapped IPv6 addresses are reported as IPv4, and IPv6 addresses are compressed).
+// Strings that are not IP addresses (such as hostnames) are returned as-is.
+func normalizeIP(addr string) string {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return addr
+	}
+	return ip.String()
+}
+
+// ParsePorts parses the ports a collector captures requests to, such as Kafka broker ports.
+func ParsePorts(ports []string) ([]int, error) {
+	parsed := make([]int, 0, len(ports))
+	for _, port := range ports {
+		p, err := strconv.Atoi(port)
+		if err != nil || p <= 0 || p > 65535 {
+			return nil, errors.Errorf("invalid port %s", port)
+		}
+		parsed = append(parsed, p)
+	}
+	return parsed, nil
+}
+
+// tcpDstPortsFilter returns a BPF filter matching TCP packets sent to any of ports.
+func tcpDstPortsFilter(ports []int) string {
+	filters := lo.Map(ports, func(port int, _ int) string {
+		return fmt.Sprintf("dst port %d", port)
+	})
+	return fmt.Sprintf("tcp and (%s)", strings.Join(filters, " or "))
+}
+
+// detectIPs returns the source and destination IPs of the packet's network layer, for both IPv4 and IPv6 packets.
+func detectIPs(packet gopacket.Packet) (net.IP, net.IP, bool) {
+	switch ip := packet.NetworkLayer().(type) {
+	case *layers.IPv4:
+		return ip.SrcIP, ip.DstIP, true
+	case *layers.IPv6:
+		return ip.SrcIP, ip.DstIP, true
+	default:
+		return nil, nil, false
+	}
+}
+
+func (c *NetworkCollector) addCapturedRequest(srcIp string, srcHost string, destNameOrIP string, destIP string, seenAt time.Time, ttl nilable.Nilable[int], destPort *int, srcPort *int, protocol nilable.Nilable[mapperclient.TransportProtocol]) {
+	req := UniqueRequest{normalizeIP(srcIp), srcHost, normalizeIP(destNameOrIP), normalizeIP(destIP), nilable.FromPtr(destPort), protocol}
 	existingRequest, requestFound := c.capturedRequests[req]
 	if requestFound {
 		existingSet := existingRequest.srcPorts
@@ -57,6 +133,28 @@ func (c *NetworkCollector) addCapturedRequest(srcIp string, srcHost string, dest
 	c.capturedRequests[req] = TimeAndTTL{seenAt, ttl, lo.ToPtr(newSet)}
 }
 
+func (c *NetworkCollector) addPendingCapture(p pendingCapture) {
+	c.pending = append(c.pending, p)
+}
+
+// verifyPendingCaptures reports the pending captures whose source IPs still resolve to the same hostnames, and should be
+// called once resolver was refreshed.
+func (c *NetworkCollector) verifyPendingCaptures(resolver ipresolver.IPResolver) {
+	for _, p := range c.pending {
+		hostname, ok := resolver.ResolveIP(p.srcIp)
+		if !ok {
+			logrus.Debugf("Could not to resolve %s, skipping packet", p.srcIp)
+			continue
+		}
+		if p.srcHostname != hostname {
+			logrus.Debugf("IP %s was resolved to %s, but now resolves to %s. skipping packet", p.srcIp, p.srcHostname, hostname)
+			continue
+		}
+		c.addCapturedRequest(p.srcIp, hostname, p.destHostnameOrIP, p.destIP, p.time, p.ttl, p.destPort, p.srcPort, p.protocol)
+	}
+	c.pending = nil
+}
+
 func (c *NetworkCollector) CollectResults() []mapperclient.RecordedDestinationsForSrc {
 	type srcInfo struct {
 		Ip       string
@@ -78,6 +176,7 @@ func (c *NetworkCollector) CollectResults() []mapperclient.RecordedDestinationsF
 			LastSeen:        timeAndTTL.lastSeen,
 			TTL:             timeAndTTL.ttl,
 			SrcPorts:        lo.Keys(*timeAndTTL.srcPorts),
+			Protocol:        reqInfo.protocol,
 		}
 		srcToDests[src] = append(srcToDests[src], destination)
 	}
@@ -0,0 +1,21 @@
+package collectors
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParsePorts(t *testing.T) {
+	ports, err := ParsePorts([]string{"9092", "9093"})
+	require.NoError(t, err)
+	require.Equal(t, []int{9092, 9093}, ports)
+
+	_, err = ParsePorts([]string{"kafka"})
+	require.Error(t, err)
+	_, err = ParsePorts([]string{"70000"})
+	require.Error(t, err)
+}
+
+func TestTCPDstPortsFilter(t *testing.T) {
+	require.Equal(t, "tcp and (dst port 9092 or dst port 9093)", tcpDstPortsFilter([]int{9092, 9093}))
+}
@@ -18,19 +18,9 @@ import (
 	"time"
 )
 
-type pendingCapture struct {
-	srcIp            string
-	srcHostname      string
-	destHostnameOrIP string
-	destIPFromDNS    string // The destination IP, if it is known at the time of capture.
-	time             time.Time
-	ttl              nilable.Nilable[int]
-}
-
 type DNSSniffer struct {
 	NetworkCollector
 	resolver       ipresolver.IPResolver
-	pending        []pendingCapture
 	lastRefresh    time.Time
 	isRunningOnAWS bool
 }
@@ -39,7 +29,6 @@ func NewDNSSniffer(resolver ipresolver.IPResolver, isRunningOnAWS bool) *DNSSnif
 	s := DNSSniffer{
 		NetworkCollector: NetworkCollector{},
 		resolver:         resolver,
-		pending:          make([]pendingCapture, 0),
 		lastRefresh:      time.Now().Add(-viper.GetDuration(config.HostsMappingRefreshIntervalKey)), // Should refresh immediately
 		isRunningOnAWS:   isRunningOnAWS,
 	}
@@ -123,10 +112,9 @@ func (s *DNSSniffer) HandlePacket(packet gopacket.Packet) {
 	}
 
 	captureTime := detectCaptureTime(packet)
-	ipLayer := packet.Layer(layers.LayerTypeIPv4)
+	_, dstIP, ipFound := detectIPs(packet)
 	dnsLayer := packet.Layer(layers.LayerTypeDNS)
-	if dnsLayer != nil && ipLayer != nil {
-		ip, _ := ipLayer.(*layers.IPv4)
+	if dnsLayer != nil && ipFound {
 		dns, _ := dnsLayer.(*layers.DNS)
 		if dns.OpCode == layers.DNSOpCodeQuery && dns.ResponseCode == layers.DNSResponseCodeNoErr {
 			cnameToA := getCNameTranslation(dns)
@@ -143,21 +131,22 @@ func (s *DNSSniffer) HandlePacket(packet gopacket.Packet) {
 				}
 
 				if !s.isRunningOnAWS {
-					s.addCapturedRequest(ip.DstIP.String(), "", hostName, answer.IP.String(), captureTime, nilable.From(int(answer.TTL)), nil, nil)
+					s.addCapturedRequest(dstIP.String(), "", hostName, answer.IP.String(), captureTime, nilable.From(int(answer.TTL)), nil, nil, noProtocol)
 					continue
 				}
-				hostname, ok := s.resolver.ResolveIP(ip.DstIP.String())
+				hostname, ok := s.resolver.ResolveIP(dstIP.String())
 				if !ok {
-					logrus.Debugf("Can't resolve IP addr %s, skipping", ip.DstIP.String())
+					logrus.Debugf("Can't resolve IP addr %s, skipping", dstIP.String())
 				} else {
 					// Resolver cache could be outdated, verify same resolving result after next poll
-					s.pending = append(s.pending, pendingCapture{
-						srcIp:            ip.DstIP.String(),
+					s.addPendingCapture(pendingCapture{
+						srcIp:            dstIP.String(),
 						srcHostname:      hostname,
 						destHostnameOrIP: hostName,
-						destIPFromDNS:    answer.IP.String(),
+						destIP:           answer.IP.String(),
 						time:             captureTime,
 						ttl:              nilable.From(int(answer.TTL)),
+						protocol:         noProtocol,
 					})
 				}
 			}
@@ -193,19 +182,7 @@ func (s *DNSSniffer) RefreshHostsMapping() error {
 		return errors.Wrap(err)
 	}
 
-	for _, p := range s.pending {
-		hostname, ok := s.resolver.ResolveIP(p.srcIp)
-		if !ok {
-			logrus.WithError(err).Debugf("Could not to resolve %s, skipping packet", p.srcIp)
-			continue
-		}
-		if p.srcHostname != hostname {
-			logrus.Debugf("IP %s was resolved to %s, but now resolves to %s. skipping packet", p.srcIp, p.srcHostname, hostname)
-			continue
-		}
-		s.addCapturedRequest(p.srcIp, hostname, p.destHostnameOrIP, p.destIPFromDNS, p.time, p.ttl, nil, nil)
-	}
-	s.pending = make([]pendingCapture, 0)
+	s.verifyPendingCaptures(s.resolver)
 	return nil
 }
 
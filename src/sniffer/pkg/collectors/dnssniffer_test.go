@@ -78,6 +78,35 @@ func (s *SnifferTestSuite) TestHandlePacketWithCNAME() {
 	}, sniffer.CollectResults())
 }
 
+func (s *SnifferTestSuite) TestHandlePacketIPv6() {
+	sniffer := NewDNSSniffer(&ipresolver.MockIPResolver{}, false)
+
+	rawDnsResponse, err := hex.DecodeString("6000000000561140fd00001000960000000000000000000afd00001002440001000000000000000500359c4000568f0a12348000000100010000000003617069086f74746572697a6503636f6d00001c000103617069086f74746572697a6503636f6d00001c00010000001e0010260647000000000000000000681084e5")
+	if err != nil {
+		s.Require().NoError(err)
+	}
+	packet := gopacket.NewPacket(rawDnsResponse, layers.LayerTypeIPv6, gopacket.Default)
+	timestamp := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	packet.Metadata().CaptureInfo.Timestamp = timestamp
+	sniffer.HandlePacket(packet)
+	_ = sniffer.RefreshHostsMapping()
+
+	s.Require().Equal([]mapperclient.RecordedDestinationsForSrc{
+		{
+			SrcIp: "fd00:10:244:1::5",
+			Destinations: []mapperclient.Destination{
+				{
+					Destination:   "api.otterize.com",
+					DestinationIP: nilable.From("2606:4700::6810:84e5"),
+					LastSeen:      timestamp,
+					TTL:           nilable.From(30),
+					SrcPorts:      []int{},
+				},
+			},
+		},
+	}, sniffer.CollectResults())
+}
+
 func TestDNSSnifferSuite(t *testing.T) {
 	suite.Run(t, new(SnifferTestSuite))
 }
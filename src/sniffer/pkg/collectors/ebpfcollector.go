@@ -0,0 +1,152 @@
+package collectors
+
+import (
+	"fmt"
+	"github.com/otterize/network-mapper/src/mapperclient"
+	sharedconfig "github.com/otterize/network-mapper/src/shared/config"
+	"github.com/otterize/network-mapper/src/sniffer/pkg/config"
+	"github.com/otterize/network-mapper/src/sniffer/pkg/ebpftracer"
+	"github.com/otterize/network-mapper/src/sniffer/pkg/utils"
+	"github.com/otterize/nilable"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"net"
+	"time"
+)
+
+const dnsPort = 53
+
+// tracedSrc is a source as reported to the mapper.
+type tracedSrc struct {
+	ip       string
+	hostname string
+}
+
+type tracedProcess struct {
+	containerID string
+	hostname    string
+	ips         []net.IP
+}
+
+// EBPFCollector reports the connections traced by the eBPF tracer. Unlike packets captured with libpcap, the events
+// are traced at the socket of the process, so the connecting process' hostname is known without resolving its IP.
+type EBPFCollector struct {
+	NetworkCollector
+	// processes caches the container, hostname and IPs of processes by their PID. Processes are not cached by their
+	// cgroup ID, as on cgroup v1 and hybrid hosts the traced cgroup ID is the root cgroup's for every process.
+	processes map[uint32]tracedProcess
+	// containerIDs holds the container each reported source connected from, which the mapper resolves the source pod by.
+	containerIDs map[tracedSrc]string
+}
+
+func NewEBPFCollector() *EBPFCollector {
+	c := EBPFCollector{
+		NetworkCollector: NetworkCollector{},
+		processes:        make(map[uint32]tracedProcess),
+		containerIDs:     make(map[tracedSrc]string),
+	}
+	c.resetData()
+	return &c
+}
+
+func (c *EBPFCollector) lookupProcess(event ebpftracer.Event) tracedProcess {
+	if process, ok := c.processes[event.PID]; ok {
+		return process
+	}
+
+	process := tracedProcess{}
+	pDir := fmt.Sprintf("%s/%d", viper.GetString(config.HostProcDirKey), event.PID)
+	containerID, _, err := utils.ExtractProcessContainerID(pDir)
+	if err != nil {
+		logrus.WithError(err).Debugf("Could not resolve container of process %d", event.PID)
+	}
+	process.containerID = containerID
+	hostname, err := utils.ExtractProcessHostname(pDir)
+	if err != nil {
+		// the process may have already exited, in which case the connection is reported without a hostname
+		logrus.WithError(err).Debugf("Could not resolve hostname of process %d", event.PID)
+	}
+	process.hostname = hostname
+	ips, err := utils.ExtractProcessIPAddrs(pDir)
+	if err != nil {
+		logrus.WithError(err).Debugf("Could not resolve IP addresses of process %d", event.PID)
+	}
+	for _, ip := range ips {
+		process.ips = append(process.ips, net.ParseIP(ip))
+	}
+
+	c.processes[event.PID] = process
+	return process
+}
+
+// localIP returns the address the event was sent from. UDP datagrams sent from unbound sockets have no local address
+// at the time they are traced, so the process' address of the same family is used instead.
+func (c *EBPFCollector) localIP(event ebpftracer.Event, process tracedProcess) (net.IP, bool) {
+	if !event.LocalIP.IsUnspecified() {
+		return event.LocalIP, true
+	}
+	isIPv4 := event.RemoteIP.To4() != nil
+	return lo.Find(process.ips, func(ip net.IP) bool {
+		return (ip.To4() != nil) == isIPv4
+	})
+}
+
+func (c *EBPFCollector) HandleEvent(event ebpftracer.Event) {
+	if !viper.GetBool(sharedconfig.EnableTCPKey) {
+		return
+	}
+	if event.LocalIP.IsLoopback() || event.RemoteIP.IsLoopback() || event.RemoteIP.IsUnspecified() {
+		// ignore localhost connections as they are irrelevant to the mapping
+		return
+	}
+	if event.Type == ebpftracer.EventTypeUDPSend && event.RemotePort == dnsPort {
+		// DNS queries are reported by the DNS sniffer, along with the names they resolve
+		return
+	}
+
+	seenAt := time.Now()
+	switch event.Type {
+	case ebpftracer.EventTypeConnect, ebpftracer.EventTypeUDPSend:
+		process := c.lookupProcess(event)
+		localIP, ok := c.localIP(event, process)
+		if !ok {
+			logrus.Debugf("Could not resolve source IP of process %d, skipping event", event.PID)
+			return
+		}
+		dstIP := event.RemoteIP.String()
+		logrus.Debugf("Traced connection from %s (%s, container %s, cgroup %d) to %s:%d", localIP, process.hostname, process.containerID, event.CgroupID, dstIP, event.RemotePort)
+		if process.containerID != "" {
+			c.containerIDs[tracedSrc{ip: normalizeIP(localIP.String()), hostname: process.hostname}] = process.containerID
+		}
+		// Unbound UDP sockets are assigned a port while sending their first datagram, after it was traced
+		var srcPort *int
+		if event.LocalPort != 0 {
+			srcPort = lo.ToPtr(event.LocalPort)
+		}
+		protocol := protocolTCP
+		if event.Type == ebpftracer.EventTypeUDPSend {
+			protocol = protocolUDP
+		}
+		c.addCapturedRequest(localIP.String(), process.hostname, dstIP, dstIP, seenAt, nilable.Nilable[int]{}, lo.ToPtr(event.RemotePort), srcPort, protocol)
+	case ebpftracer.EventTypeAccept:
+		// The connecting process may be outside the node, so, as with captured packets, the mapper resolves the source by IP.
+		srcIP := event.RemoteIP.String()
+		dstIP := event.LocalIP.String()
+		logrus.Debugf("Traced connection accepted from %s to %s:%d", srcIP, dstIP, event.LocalPort)
+		c.addCapturedRequest(srcIP, "", dstIP, dstIP, seenAt, nilable.Nilable[int]{}, lo.ToPtr(event.LocalPort), lo.ToPtr(event.RemotePort), protocolTCP)
+	}
+}
+
+func (c *EBPFCollector) CollectResults() []mapperclient.RecordedDestinationsForSrc {
+	// Processes may have exited since they were cached, and their PIDs reused.
+	c.processes = make(map[uint32]tracedProcess)
+	results := c.NetworkCollector.CollectResults()
+	for i, result := range results {
+		if containerID, ok := c.containerIDs[tracedSrc{ip: result.SrcIp, hostname: result.SrcHostname}]; ok {
+			results[i].SrcContainerId = nilable.From(containerID)
+		}
+	}
+	c.containerIDs = make(map[tracedSrc]string)
+	return results
+}
@@ -0,0 +1,140 @@
+package collectors
+
+import (
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/otterize/network-mapper/src/mapperclient"
+	sharedconfig "github.com/otterize/network-mapper/src/shared/config"
+	"github.com/otterize/network-mapper/src/sniffer/pkg/config"
+	"github.com/otterize/network-mapper/src/sniffer/pkg/ebpftracer"
+	"github.com/otterize/nilable"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/exp/slices"
+	"gotest.tools/v3/assert"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+const mockContainerID = "3f8c2a9b1d7e4f6a5b0c9d8e7f6a5b4c3d2e1f0a9b8c7d6e5f4a3b2c1d0e9f8a"
+
+const mockEBPFFibTrieFile = `Main:
+  +-- 0.0.0.0/0 3 0 5
+     +-- 10.244.0.0/24 2 0 2
+           |-- 10.244.0.5
+              /32 host LOCAL
+`
+
+const mockCgroupFile = "0::/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod1.slice/cri-containerd-" + mockContainerID + ".scope\n"
+
+type EBPFCollectorTestSuite struct {
+	suite.Suite
+}
+
+func (s *EBPFCollectorTestSuite) SetupTest() {
+	viper.Set(sharedconfig.EnableTCPKey, true)
+}
+
+func (s *EBPFCollectorTestSuite) mockProcess(procDir string, pid string) {
+	s.Require().NoError(os.MkdirAll(procDir+"/"+pid+"/net", 0o700))
+	s.Require().NoError(os.WriteFile(procDir+"/"+pid+"/environ", []byte("HOSTNAME=client\x00"), 0o444))
+	s.Require().NoError(os.WriteFile(procDir+"/"+pid+"/cgroup", []byte(mockCgroupFile), 0o444))
+	s.Require().NoError(os.WriteFile(procDir+"/"+pid+"/net/fib_trie", []byte(mockEBPFFibTrieFile), 0o444))
+}
+
+func (s *EBPFCollectorTestSuite) TestHandleRecordedEvents() {
+	mockProcDir := s.T().TempDir()
+	s.mockProcess(mockProcDir, "100")
+	s.mockProcess(mockProcDir, "101")
+	viper.Set(config.HostProcDirKey, mockProcDir)
+
+	fixtures, err := os.Open("testdata/ebpf_events.hex")
+	s.Require().NoError(err)
+	defer fixtures.Close()
+	events, err := ebpftracer.ReadEventFixtures(fixtures)
+	s.Require().NoError(err)
+	s.Require().Len(events, 6)
+
+	collector := NewEBPFCollector()
+	for _, event := range events {
+		collector.HandleEvent(event)
+	}
+	s.Require().Equal(mockContainerID, collector.processes[100].containerID)
+	s.Require().Len(collector.processes, 2)
+
+	results := collector.CollectResults()
+	for _, result := range results {
+		slices.SortFunc(result.Destinations, func(a, b mapperclient.Destination) int {
+			return strings.Compare(a.Destination, b.Destination)
+		})
+	}
+	slices.SortFunc(results, func(a, b mapperclient.RecordedDestinationsForSrc) int {
+		return strings.Compare(a.SrcIp, b.SrcIp)
+	})
+	expectedResults := []mapperclient.RecordedDestinationsForSrc{
+		{
+			SrcIp:          "10.244.0.5",
+			SrcHostname:    "client",
+			SrcContainerId: nilable.From(mockContainerID),
+			Destinations: []mapperclient.Destination{
+				{
+					Destination:     "10.96.0.20",
+					DestinationIP:   nilable.From("10.96.0.20"),
+					DestinationPort: nilable.From(8080),
+					SrcPorts:        []int{40000},
+					Protocol:        nilable.From(mapperclient.TransportProtocolTcp),
+				},
+				{
+					Destination:     "10.96.0.30",
+					DestinationIP:   nilable.From("10.96.0.30"),
+					DestinationPort: nilable.From(8125),
+					SrcPorts:        []int{},
+					Protocol:        nilable.From(mapperclient.TransportProtocolUdp),
+				},
+			},
+		},
+		{
+			SrcIp: "10.244.0.9",
+			Destinations: []mapperclient.Destination{
+				{
+					Destination:     "10.244.0.5",
+					DestinationIP:   nilable.From("10.244.0.5"),
+					DestinationPort: nilable.From(9090),
+					SrcPorts:        []int{51000},
+					Protocol:        nilable.From(mapperclient.TransportProtocolTcp),
+				},
+			},
+		},
+		{
+			SrcIp:          "fd00::5",
+			SrcHostname:    "client",
+			SrcContainerId: nilable.From(mockContainerID),
+			Destinations: []mapperclient.Destination{
+				{
+					Destination:     "fd00::20",
+					DestinationIP:   nilable.From("fd00::20"),
+					DestinationPort: nilable.From(443),
+					SrcPorts:        []int{43000},
+					Protocol:        nilable.From(mapperclient.TransportProtocolTcp),
+				},
+			},
+		},
+	}
+	assert.DeepEqual(s.T(), expectedResults, results, cmpopts.IgnoreTypes(time.Time{}))
+	s.Require().Empty(collector.processes)
+	s.Require().Empty(collector.containerIDs)
+}
+
+func (s *EBPFCollectorTestSuite) TestDisabledTCP() {
+	viper.Set(sharedconfig.EnableTCPKey, false)
+	defer viper.Set(sharedconfig.EnableTCPKey, true)
+
+	collector := NewEBPFCollector()
+	collector.HandleEvent(ebpftracer.Event{Type: ebpftracer.EventTypeAccept, LocalIP: []byte{10, 244, 0, 5}, LocalPort: 9090, RemoteIP: []byte{10, 244, 0, 9}, RemotePort: 51000})
+	s.Require().Empty(collector.CollectResults())
+}
+
+func TestEBPFCollectorSuite(t *testing.T) {
+	suite.Run(t, new(EBPFCollectorTestSuite))
+}
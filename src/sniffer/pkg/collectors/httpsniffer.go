@@ -0,0 +1,190 @@
+package collectors
+
+import (
+	"bytes"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcap"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"github.com/otterize/network-mapper/src/mapperclient"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Request lines longer than this are dropped, and match the limit of common servers such as nginx.
+	maxHTTPRequestLineLength = 8 * 1024
+	// Request lines that did not complete within this duration are dropped.
+	httpRequestLineTimeout = 10 * time.Second
+)
+
+var httpMethods = map[string]mapperclient.HttpMethod{
+	"GET":     mapperclient.HttpMethodGet,
+	"POST":    mapperclient.HttpMethodPost,
+	"PUT":     mapperclient.HttpMethodPut,
+	"DELETE":  mapperclient.HttpMethodDelete,
+	"OPTIONS": mapperclient.HttpMethodOptions,
+	"TRACE":   mapperclient.HttpMethodTrace,
+	"PATCH":   mapperclient.HttpMethodPatch,
+}
+
+type tcpFlow struct {
+	srcIP   string
+	srcPort int
+	dstIP   string
+	dstPort int
+}
+
+// partialRequestLine is the start of a request line whose end was not captured yet.
+type partialRequestLine struct {
+	line      []byte
+	nextSeq   uint32
+	startedAt time.Time
+}
+
+type httpRequest struct {
+	srcIP   string
+	dstIP   string
+	dstPort int
+	method  mapperclient.HttpMethod
+	path    string
+}
+
+// HTTPSniffer captures the request lines of plaintext HTTP/1.x requests sent to servers listening on ports, to report
+// the paths and methods clients use. Request lines split over several TCP segments are reassembled.
+type HTTPSniffer struct {
+	ports    []int
+	partial  map[tcpFlow]*partialRequestLine
+	requests map[httpRequest]time.Time
+}
+
+func NewHTTPSniffer(ports []int) *HTTPSniffer {
+	s := HTTPSniffer{ports: ports, partial: make(map[tcpFlow]*partialRequestLine)}
+	s.resetData()
+	return &s
+}
+
+func (s *HTTPSniffer) resetData() {
+	s.requests = make(map[httpRequest]time.Time)
+}
+
+func (s *HTTPSniffer) Enabled() bool {
+	return len(s.ports) > 0
+}
+
+func (s *HTTPSniffer) CreateHTTPPacketStream() (chan gopacket.Packet, error) {
+	handle, err := pcap.OpenLive("any", 0, true, pcap.BlockForever)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	err = handle.SetDirection(pcap.DirectionIn)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	err = handle.SetBPFFilter(tcpDstPortsFilter(s.ports))
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+
+	packetSource := gopacket.NewPacketSource(handle, handle.LinkType())
+	return packetSource.Packets(), nil
+}
+
+func (s *HTTPSniffer) HandlePacket(packet gopacket.Packet) {
+	tcpLayer, ok := packet.Layer(layers.LayerTypeTCP).(*layers.TCP)
+	if !ok || len(tcpLayer.Payload) == 0 || !lo.Contains(s.ports, int(tcpLayer.DstPort)) {
+		return
+	}
+	srcIP, dstIP, ok := detectIPs(packet)
+	if !ok {
+		return
+	}
+	captureTime := detectCaptureTime(packet)
+	flow := tcpFlow{srcIP: normalizeIP(srcIP.String()), srcPort: int(tcpLayer.SrcPort), dstIP: normalizeIP(dstIP.String()), dstPort: int(tcpLayer.DstPort)}
+
+	var line []byte
+	if partial, ok := s.partial[flow]; ok && partial.nextSeq == tcpLayer.Seq {
+		line = append(partial.line, tcpLayer.Payload...)
+	} else if startsWithHTTPMethod(tcpLayer.Payload) {
+		line = tcpLayer.Payload
+	} else {
+		// The middle of a request, such as its headers or body, or a segment following one that was not captured.
+		delete(s.partial, flow)
+		return
+	}
+
+	end := bytes.IndexByte(line, '\n')
+	if end == -1 {
+		if len(line) > maxHTTPRequestLineLength {
+			delete(s.partial, flow)
+			return
+		}
+		s.partial[flow] = &partialRequestLine{line: bytes.Clone(line), nextSeq: tcpLayer.Seq + uint32(len(tcpLayer.Payload)), startedAt: time.Now()}
+		return
+	}
+	delete(s.partial, flow)
+
+	method, path, ok := parseHTTPRequestLine(string(bytes.TrimSuffix(line[:end], []byte("\r"))))
+	if !ok {
+		return
+	}
+	logrus.Debugf("HTTP request from %s to %s: %s %s", flow.srcIP, net.JoinHostPort(flow.dstIP, strconv.Itoa(flow.dstPort)), method, path)
+	s.requests[httpRequest{srcIP: flow.srcIP, dstIP: flow.dstIP, dstPort: flow.dstPort, method: method, path: path}] = captureTime
+}
+
+func startsWithHTTPMethod(payload []byte) bool {
+	method, _, found := bytes.Cut(payload[:min(len(payload), len("OPTIONS "))], []byte(" "))
+	if !found {
+		return false
+	}
+	_, ok := httpMethods[string(method)]
+	return ok
+}
+
+// parseHTTPRequestLine parses the method and path of an HTTP/1.x request line, such as 'GET /orders?id=1 HTTP/1.1'.
+func parseHTTPRequestLine(line string) (mapperclient.HttpMethod, string, bool) {
+	parts := strings.Split(line, " ")
+	if len(parts) != 3 || !strings.HasPrefix(parts[2], "HTTP/1.") {
+		return "", "", false
+	}
+	method, ok := httpMethods[parts[0]]
+	if !ok {
+		return "", "", false
+	}
+
+	// Requests sent to proxies have an absolute URL rather than a path.
+	target, err := url.ParseRequestURI(parts[1])
+	if err != nil {
+		return "", "", false
+	}
+	if target.Path == "" {
+		return method, "/", true
+	}
+	return method, target.Path, true
+}
+
+func (s *HTTPSniffer) CollectResults() []mapperclient.HTTPRequestResult {
+	results := lo.MapToSlice(s.requests, func(request httpRequest, lastSeen time.Time) mapperclient.HTTPRequestResult {
+		return mapperclient.HTTPRequestResult{
+			SrcIp:    request.srcIP,
+			DstIp:    request.dstIP,
+			DstPort:  request.dstPort,
+			Method:   request.method,
+			Path:     request.path,
+			LastSeen: lastSeen,
+		}
+	})
+	s.resetData()
+
+	for flow, partial := range s.partial {
+		if time.Since(partial.startedAt) > httpRequestLineTimeout {
+			delete(s.partial, flow)
+		}
+	}
+	return results
+}
@@ -0,0 +1,79 @@
+package collectors
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/otterize/network-mapper/src/mapperclient"
+	"github.com/stretchr/testify/require"
+	"net"
+	"testing"
+	"time"
+)
+
+var httpTestTimestamp = time.Date(2024, 5, 2, 9, 12, 31, 0, time.UTC)
+
+func tcpSegment(t *testing.T, dstPort int, seq uint32, payload string) gopacket.Packet {
+	ip := &layers.IPv4{Version: 4, TTL: 64, Protocol: layers.IPProtocolTCP, SrcIP: net.ParseIP("10.244.0.27"), DstIP: net.ParseIP("10.96.12.5")}
+	tcp := &layers.TCP{SrcPort: 54321, DstPort: layers.TCPPort(dstPort), Seq: seq, ACK: true, PSH: true, Window: 65535}
+	require.NoError(t, tcp.SetNetworkLayerForChecksum(ip))
+	buf := gopacket.NewSerializeBuffer()
+	require.NoError(t, gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}, ip, tcp, gopacket.Payload(payload)))
+
+	packet := gopacket.NewPacket(buf.Bytes(), layers.LayerTypeIPv4, gopacket.Default)
+	packet.Metadata().CaptureInfo.Timestamp = httpTestTimestamp
+	return packet
+}
+
+func httpResult(method mapperclient.HttpMethod, path string) mapperclient.HTTPRequestResult {
+	return mapperclient.HTTPRequestResult{
+		SrcIp:    "10.244.0.27",
+		DstIp:    "10.96.12.5",
+		DstPort:  8080,
+		Method:   method,
+		Path:     path,
+		LastSeen: httpTestTimestamp,
+	}
+}
+
+func TestHTTPSniffer_TestHandlePacket(t *testing.T) {
+	sniffer := NewHTTPSniffer([]int{8080})
+	sniffer.HandlePacket(tcpSegment(t, 8080, 1000, "GET /orders?id=1 HTTP/1.1\r\nHost: orders\r\n\r\n"))
+	sniffer.HandlePacket(tcpSegment(t, 8080, 2000, "POST http://orders:8080/orders/new HTTP/1.1\r\nHost: orders\r\n\r\n"))
+	sniffer.HandlePacket(tcpSegment(t, 8080, 3000, "DELETE http://orders:8080 HTTP/1.0\r\n\r\n"))
+
+	require.ElementsMatch(t, []mapperclient.HTTPRequestResult{
+		httpResult(mapperclient.HttpMethodGet, "/orders"),
+		httpResult(mapperclient.HttpMethodPost, "/orders/new"),
+		httpResult(mapperclient.HttpMethodDelete, "/"),
+	}, sniffer.CollectResults())
+	require.Empty(t, sniffer.CollectResults())
+}
+
+func TestHTTPSniffer_TestReassemblesRequestLine(t *testing.T) {
+	sniffer := NewHTTPSniffer([]int{8080})
+	first := "PUT /orders/very/long"
+	sniffer.HandlePacket(tcpSegment(t, 8080, 1000, first))
+	require.Empty(t, sniffer.CollectResults())
+
+	sniffer.HandlePacket(tcpSegment(t, 8080, 1000+uint32(len(first)), "/path HTTP/1.1\r\nHost: orders\r\n\r\n"))
+	require.Equal(t, []mapperclient.HTTPRequestResult{httpResult(mapperclient.HttpMethodPut, "/orders/very/long/path")}, sniffer.CollectResults())
+}
+
+func TestHTTPSniffer_TestDropsNonContiguousSegments(t *testing.T) {
+	sniffer := NewHTTPSniffer([]int{8080})
+	sniffer.HandlePacket(tcpSegment(t, 8080, 1000, "GET /orders"))
+	// A segment was lost, so the request line can not be reassembled.
+	sniffer.HandlePacket(tcpSegment(t, 8080, 1500, "/path HTTP/1.1\r\n\r\n"))
+
+	require.Empty(t, sniffer.CollectResults())
+}
+
+func TestHTTPSniffer_TestIgnoresOtherTraffic(t *testing.T) {
+	sniffer := NewHTTPSniffer([]int{8080})
+	sniffer.HandlePacket(tcpSegment(t, 8080, 1000, "PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n"))
+	sniffer.HandlePacket(tcpSegment(t, 8080, 2000, "{\"order\": 1}"))
+	sniffer.HandlePacket(tcpSegment(t, 8080, 3000, "GET /orders HTTP/2\r\n\r\n"))
+	sniffer.HandlePacket(tcpSegment(t, 9090, 4000, "GET /metrics HTTP/1.1\r\n\r\n"))
+
+	require.Empty(t, sniffer.CollectResults())
+}
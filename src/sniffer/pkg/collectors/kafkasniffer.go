@@ -0,0 +1,107 @@
+package collectors
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcap"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"github.com/otterize/network-mapper/src/mapperclient"
+	"github.com/otterize/network-mapper/src/sniffer/pkg/kafkaprotocol"
+	"github.com/otterize/nilable"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// The operations reported for each request, named as in the broker's authorizer logs.
+var kafkaAPIKeyToOperation = map[kafkaprotocol.APIKey]string{
+	kafkaprotocol.APIKeyProduce:  "Write",
+	kafkaprotocol.APIKeyFetch:    "Read",
+	kafkaprotocol.APIKeyMetadata: "Describe",
+}
+
+type kafkaTopicAccess struct {
+	srcIP     string
+	dstIP     string
+	topic     string
+	operation string
+}
+
+// KafkaSniffer decodes the requests clients send to Kafka brokers listening on ports, to report the topics they access.
+type KafkaSniffer struct {
+	ports    []int
+	accesses map[kafkaTopicAccess]time.Time
+}
+
+func NewKafkaSniffer(ports []int) *KafkaSniffer {
+	s := KafkaSniffer{ports: ports}
+	s.resetData()
+	return &s
+}
+
+func (s *KafkaSniffer) resetData() {
+	s.accesses = make(map[kafkaTopicAccess]time.Time)
+}
+
+func (s *KafkaSniffer) Enabled() bool {
+	return len(s.ports) > 0
+}
+
+func (s *KafkaSniffer) CreateKafkaPacketStream() (chan gopacket.Packet, error) {
+	handle, err := pcap.OpenLive("any", 0, true, pcap.BlockForever)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	err = handle.SetDirection(pcap.DirectionIn)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	err = handle.SetBPFFilter(tcpDstPortsFilter(s.ports))
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+
+	packetSource := gopacket.NewPacketSource(handle, handle.LinkType())
+	return packetSource.Packets(), nil
+}
+
+func (s *KafkaSniffer) HandlePacket(packet gopacket.Packet) {
+	tcpLayer, ok := packet.Layer(layers.LayerTypeTCP).(*layers.TCP)
+	if !ok || len(tcpLayer.Payload) == 0 || !lo.Contains(s.ports, int(tcpLayer.DstPort)) {
+		return
+	}
+	srcIP, dstIP, ok := detectIPs(packet)
+	if !ok {
+		return
+	}
+	captureTime := detectCaptureTime(packet)
+
+	for _, request := range kafkaprotocol.ParseRequests(tcpLayer.Payload) {
+		logrus.Debugf("Kafka request %d v%d from %s (client ID %s) to %s: %v", request.APIKey, request.APIVersion, srcIP, request.ClientID, dstIP, request.Topics)
+		for _, topic := range request.Topics {
+			access := kafkaTopicAccess{
+				srcIP:     normalizeIP(srcIP.String()),
+				dstIP:     normalizeIP(dstIP.String()),
+				topic:     topic,
+				operation: kafkaAPIKeyToOperation[request.APIKey],
+			}
+			s.accesses[access] = captureTime
+		}
+	}
+}
+
+func (s *KafkaSniffer) CollectResults() []mapperclient.KafkaMapperResult {
+	results := lo.MapToSlice(s.accesses, func(access kafkaTopicAccess, lastSeen time.Time) mapperclient.KafkaMapperResult {
+		return mapperclient.KafkaMapperResult{
+			SrcIp:        access.srcIP,
+			ServerIp:     nilable.From(access.dstIP),
+			Topic:        access.topic,
+			Operation:    access.operation,
+			LastSeen:     lastSeen,
+			ResourceType: nilable.From(mapperclient.KafkaResourceTypeTopic),
+			PatternType:  nilable.From(mapperclient.KafkaPatternTypeLiteral),
+		}
+	})
+	s.resetData()
+	return results
+}
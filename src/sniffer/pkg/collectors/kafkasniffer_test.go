@@ -0,0 +1,66 @@
+package collectors
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/otterize/network-mapper/src/mapperclient"
+	"github.com/otterize/nilable"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func handlePcap(t *testing.T, sniffer *KafkaSniffer, name string) {
+	f, err := os.Open(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	defer f.Close()
+
+	r, err := pcapgo.NewReader(f)
+	require.NoError(t, err)
+	for packet := range gopacket.NewPacketSource(r, r.LinkType()).Packets() {
+		sniffer.HandlePacket(packet)
+	}
+}
+
+func kafkaResult(topic string, operation string, lastSeen time.Time) mapperclient.KafkaMapperResult {
+	return mapperclient.KafkaMapperResult{
+		SrcIp:        "10.244.0.27",
+		ServerIp:     nilable.From("10.244.0.31"),
+		Topic:        topic,
+		Operation:    operation,
+		LastSeen:     lastSeen,
+		ResourceType: nilable.From(mapperclient.KafkaResourceTypeTopic),
+		PatternType:  nilable.From(mapperclient.KafkaPatternTypeLiteral),
+	}
+}
+
+func TestKafkaSniffer_TestHandlePcapV0_10(t *testing.T) {
+	sniffer := NewKafkaSniffer([]int{9092})
+	handlePcap(t, sniffer, "kafka_v0_10.pcap")
+
+	require.ElementsMatch(t, []mapperclient.KafkaMapperResult{
+		kafkaResult("orders", "Write", time.Date(2024, 5, 2, 9, 12, 31, 1000000, time.UTC)),
+		kafkaResult("payments", "Read", time.Date(2024, 5, 2, 9, 12, 31, 7000000, time.UTC)),
+	}, sniffer.CollectResults())
+	require.Empty(t, sniffer.CollectResults())
+}
+
+func TestKafkaSniffer_TestHandlePcapV2_8(t *testing.T) {
+	sniffer := NewKafkaSniffer([]int{9092})
+	handlePcap(t, sniffer, "kafka_v2_8.pcap")
+
+	require.ElementsMatch(t, []mapperclient.KafkaMapperResult{
+		kafkaResult("orders", "Write", time.Date(2024, 5, 2, 9, 12, 31, 3000000, time.UTC)),
+		kafkaResult("payments", "Read", time.Date(2024, 5, 2, 9, 12, 31, 10000000, time.UTC)),
+		kafkaResult("payments", "Describe", time.Date(2024, 5, 2, 9, 12, 31, 11000000, time.UTC)),
+	}, sniffer.CollectResults())
+}
+
+func TestKafkaSniffer_TestIgnoresOtherPorts(t *testing.T) {
+	sniffer := NewKafkaSniffer([]int{9093})
+	handlePcap(t, sniffer, "kafka_v2_8.pcap")
+
+	require.Empty(t, sniffer.CollectResults())
+}
@@ -53,11 +53,52 @@ func (s *SocketScanner) scanTcpFile(hostname string, path string) {
 		// Only report sockets from the client-side by checking if the local port for this socket is the same port as a listen socket.
 		if _, isServersideSocket := listenPorts[sock.LocalAddr.Port]; !isServersideSocket {
 			// The hostname we have here is the hostname for the client.
-			s.addCapturedRequest(sock.LocalAddr.IP.String(), hostname, sock.RemoteAddr.IP.String(), sock.RemoteAddr.IP.String(), time.Now(), nilable.Nilable[int]{}, lo.ToPtr(int(sock.LocalAddr.Port)), lo.ToPtr(int(sock.RemoteAddr.Port)))
+			s.addCapturedRequest(sock.LocalAddr.IP.String(), hostname, sock.RemoteAddr.IP.String(), sock.RemoteAddr.IP.String(), time.Now(), nilable.Nilable[int]{}, lo.ToPtr(int(sock.LocalAddr.Port)), lo.ToPtr(int(sock.RemoteAddr.Port)), protocolTCP)
 		}
 	}
 }
 
+func (s *SocketScanner) scanUdpFile(hostname string, path string) {
+	if !viper.GetBool(sharedconfig.EnableSocketScannerKey) {
+		return
+	}
+	socks, err := procnet.SocksFromPath(path)
+	if err != nil {
+		// it's likely that some files will be deleted during our iteration, so we ignore errors reading the file.
+		return
+	}
+
+	// UDP has no LISTEN state. Servers receive on unconnected sockets, which have no remote address, and appear in any
+	// order, so they are collected before looking at connected sockets.
+	serverPorts := make(map[uint16]bool)
+	for _, sock := range socks {
+		if sock.RemoteAddr.IP.IsUnspecified() {
+			serverPorts[sock.LocalAddr.Port] = true
+		}
+	}
+
+	for _, sock := range socks {
+		// connect()ed UDP sockets are reported by the kernel as ESTABLISHED.
+		if sock.State != procnet.Established {
+			continue
+		}
+		if sock.LocalAddr.IP.IsLoopback() || sock.RemoteAddr.IP.IsLoopback() {
+			// ignore localhost connections as they are irrelevant to the mapping
+			continue
+		}
+		if sock.RemoteAddr.Port == dnsPort {
+			// DNS queries are reported by the DNS sniffer, along with the names they resolve
+			continue
+		}
+		// Only report sockets from the client-side, as with TCP sockets. Servers that connect a socket per client, such
+		// as QUIC servers, do so from the port they receive on.
+		if _, isServersideSocket := serverPorts[sock.LocalAddr.Port]; isServersideSocket {
+			continue
+		}
+		s.addCapturedRequest(sock.LocalAddr.IP.String(), hostname, sock.RemoteAddr.IP.String(), sock.RemoteAddr.IP.String(), time.Now(), nilable.Nilable[int]{}, lo.ToPtr(int(sock.RemoteAddr.Port)), lo.ToPtr(int(sock.LocalAddr.Port)), protocolUDP)
+	}
+}
+
 func (s *SocketScanner) ScanProcDir() error {
 	return utils.ScanProcDirProcesses(func(_ int64, pDir string) {
 		hostname, err := utils.ExtractProcessHostname(pDir)
@@ -66,5 +107,7 @@ func (s *SocketScanner) ScanProcDir() error {
 		}
 		s.scanTcpFile(hostname, fmt.Sprintf("%s/net/tcp", pDir))
 		s.scanTcpFile(hostname, fmt.Sprintf("%s/net/tcp6", pDir))
+		s.scanUdpFile(hostname, fmt.Sprintf("%s/net/udp", pDir))
+		s.scanUdpFile(hostname, fmt.Sprintf("%s/net/udp6", pDir))
 	})
 }
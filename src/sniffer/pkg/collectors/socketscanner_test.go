@@ -11,6 +11,7 @@ import (
 	"golang.org/x/exp/slices"
 	"gotest.tools/v3/assert"
 	"os"
+	"strings"
 	"testing"
 	"time"
 )
@@ -112,6 +113,7 @@ func (s *SocketScannerTestSuite) TestScanProcDir() {
 					DestinationIP:   nilable.From("10.98.14.179"),
 					DestinationPort: nilable.From(35236),
 					SrcPorts:        []int{80},
+					Protocol:        nilable.From(mapperclient.TransportProtocolTcp),
 				},
 			},
 		},
@@ -124,6 +126,7 @@ func (s *SocketScannerTestSuite) TestScanProcDir() {
 					DestinationIP:   nilable.From("207.168.35.14"),
 					DestinationPort: nilable.From(53438),
 					SrcPorts:        []int{80},
+					Protocol:        nilable.From(mapperclient.TransportProtocolTcp),
 				},
 			},
 		},
@@ -140,6 +143,53 @@ func (s *SocketScannerTestSuite) TestScanProcDir() {
 	assert.DeepEqual(s.T(), expectedResults, results, cmpopts.IgnoreTypes(time.Time{}))
 }
 
+func (s *SocketScannerTestSuite) TestScanProcDirUDP() {
+	mockProcDir := s.T().TempDir()
+	s.Require().NoError(os.MkdirAll(mockProcDir+"/100/net", 0o700))
+	s.Require().NoError(os.WriteFile(mockProcDir+"/100/net/udp", []byte(mockUdpFileContent), 0o444))
+	s.Require().NoError(os.WriteFile(mockProcDir+"/100/net/udp6", []byte(mockUdp6FileContent), 0o444))
+	s.Require().NoError(os.WriteFile(mockProcDir+"/100/environ", []byte(mockEnvironFileContent), 0o444))
+
+	viper.Set(config.HostProcDirKey, mockProcDir)
+
+	scanner := NewSocketScanner()
+	s.Require().NoError(scanner.ScanProcDir())
+
+	results := scanner.CollectResults()
+	expectedResults := []mapperclient.RecordedDestinationsForSrc{
+		{
+			SrcIp:       "10.244.120.89",
+			SrcHostname: "thisverypod",
+			Destinations: []mapperclient.Destination{
+				{
+					Destination:     "10.96.0.30",
+					DestinationIP:   nilable.From("10.96.0.30"),
+					DestinationPort: nilable.From(8125),
+					SrcPorts:        []int{58865},
+					Protocol:        nilable.From(mapperclient.TransportProtocolUdp),
+				},
+			},
+		},
+		{
+			SrcIp:       "fd00::5",
+			SrcHostname: "thisverypod",
+			Destinations: []mapperclient.Destination{
+				{
+					Destination:     "fd00::30",
+					DestinationIP:   nilable.From("fd00::30"),
+					DestinationPort: nilable.From(4433),
+					SrcPorts:        []int{40001},
+					Protocol:        nilable.From(mapperclient.TransportProtocolUdp),
+				},
+			},
+		},
+	}
+	slices.SortFunc(results, func(a, b mapperclient.RecordedDestinationsForSrc) int {
+		return strings.Compare(a.SrcIp, b.SrcIp)
+	})
+	assert.DeepEqual(s.T(), expectedResults, results, cmpopts.IgnoreTypes(time.Time{}))
+}
+
 func TestSocketScannerSuite(t *testing.T) {
 	suite.Run(t, new(SocketScannerTestSuite))
 }
@@ -193,3 +243,20 @@ const mockTcp6FileContent = `  sl  local_address                         remote_
    4: 0000000000000000FFFF0000D326A8C0:1F90 0000000000000000FFFF00000E23A8B0:CA08 01 00000000:00000000 03:00000A41 00000000     0        0 0 3 0000000000000000
    5: 0000000000000000FFFF0000D326A8C1:D0BE 0000000000000000FFFF00000E23A8CF:0050 01 00000000:00000000 03:00000A41 00000000     0        0 0 3 0000000000000000`
 const mockEnvironFileContent = "PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin\x00HOSTNAME=thisverypod\x00TERM=xterm\x00HOME=/root\x00"
+
+// Unconnected sockets have no remote address and are in CLOSE (07) state, while connect()ed sockets are ESTABLISHED.
+// 10.244.120.89:58865 -> 10.96.0.30:8125 ESTABLISHED - should be returned successfully because client-side socket
+// 0.0.0.0:443 unconnected - a server socket
+// 10.244.120.89:443 -> 203.0.113.7:50000 ESTABLISHED - should be dropped as it is connected from the server's port
+// 10.244.120.89:40000 -> 10.96.0.10:53 ESTABLISHED - should be dropped as DNS is reported by the DNS sniffer
+// 127.0.0.1:40000 -> 127.0.0.1:8125 ESTABLISHED - should be dropped as localhost
+const mockUdpFileContent = `   sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode ref pointer drops
+   12: 5978F40A:E5F1 1E00600A:1FBD 01 00000000:00000000 00:00000000 00000000     0        0 2448849674 2 0000000000000000 0
+   34: 5978F40A:01BB 077100CB:C350 01 00000000:00000000 00:00000000 00000000     0        0 2448849675 2 0000000000000000 0
+   56: 5978F40A:9C40 0A00600A:0035 01 00000000:00000000 00:00000000 00000000     0        0 2448849676 2 0000000000000000 0
+   78: 0100007F:9C40 0100007F:1FBD 01 00000000:00000000 00:00000000 00000000     0        0 2448849677 2 0000000000000000 0
+  187: 00000000:01BB 00000000:0000 07 00000000:00000000 00:00000000 00000000     0        0 2448849678 2 0000000000000000 0`
+
+// [fd00::5]:40001 -> [fd00::30]:4433 ESTABLISHED - should be returned successfully because client-side socket
+const mockUdp6FileContent = `  sl  local_address                         remote_address                        st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode ref pointer drops
+   20: 000000FD000000000000000005000000:9C41 000000FD000000000000000030000000:1151 01 00000000:00000000 00:00000000 00000000     0        0 2448849679 2 0000000000000000 0`
@@ -11,32 +11,22 @@ import (
 	"github.com/otterize/nilable"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
+	"net"
+	"strconv"
 	"time"
 )
 
 type TCPSniffer struct {
 	NetworkCollector
 	resolver       ipresolver.IPResolver
-	pending        []pendingTCPCapture
 	lastRefresh    time.Time
 	isRunningOnAWS bool
 }
 
-type pendingTCPCapture struct {
-	srcIp       string
-	srcHostname string
-	destIp      string
-	destPort    int
-	srcPort     int
-	time        time.Time
-	ttl         nilable.Nilable[int]
-}
-
 func NewTCPSniffer(resolver ipresolver.IPResolver, isRunningOnAWS bool) *TCPSniffer {
 	s := TCPSniffer{
 		NetworkCollector: NetworkCollector{},
 		resolver:         resolver,
-		pending:          make([]pendingTCPCapture, 0),
 		lastRefresh:      time.Now().Add(-viper.GetDuration(config.HostsMappingRefreshIntervalKey)), // Should refresh immediately
 		isRunningOnAWS:   isRunningOnAWS,
 	}
@@ -49,13 +39,22 @@ func setCaptureIncomingTCPSYN(handle *pcap.Handle) error {
 	if err != nil {
 		return err
 	}
-	err = handle.SetBPFFilter("tcp and tcp[tcpflags] == tcp-syn")
+	// libpcap's tcp[] accessor only supports IPv4, so IPv6 SYNs are matched by offset in the fixed IPv6 header
+	// (next header is TCP, and the TCP flags byte which follows the 40-byte IPv6 header has only SYN set).
+	err = handle.SetBPFFilter("(tcp and tcp[tcpflags] == tcp-syn) or (ip6 and ip6[6] == 6 and ip6[53] == 0x02)")
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// IsTCPSYN returns whether the packet opens a TCP connection, matching the packets the live capture filter selects, for
+// packets read from capture files.
+func IsTCPSYN(packet gopacket.Packet) bool {
+	tcp, ok := packet.Layer(layers.LayerTypeTCP).(*layers.TCP)
+	return ok && tcp.SYN && !tcp.ACK
+}
+
 func (s *TCPSniffer) CreateTCPPacketStream() (chan gopacket.Packet, error) {
 	handle, err := pcap.OpenLive("any", 0, true, pcap.BlockForever)
 	if err != nil {
@@ -77,18 +76,12 @@ func (s *TCPSniffer) HandlePacket(packet gopacket.Packet) {
 	}
 	captureTime := detectCaptureTime(packet)
 
-	ipLayer := packet.Layer(layers.LayerTypeIPv4)
-	if ipLayer == nil {
-		return
-	}
-
-	ip, ok := ipLayer.(*layers.IPv4)
+	packetSrcIP, packetDstIP, ok := detectIPs(packet)
 	if !ok {
-		logrus.Debugf("Failed to parse IP layer")
 		return
 	}
 
-	srcPort, dstPort, portsFound, err := s.getSrcAndDestPort(packet, ip)
+	srcPort, dstPort, portsFound, err := s.getSrcAndDestPort(packet)
 	if err != nil {
 		logrus.Debugf("Failed to parse TCP/UDP port: %s", err)
 		return
@@ -98,11 +91,11 @@ func (s *TCPSniffer) HandlePacket(packet gopacket.Packet) {
 		return
 	}
 
-	logrus.Debugf("TCP SYN: %s to %s:%d", ip.SrcIP, ip.DstIP, dstPort)
-	srcIP := ip.SrcIP.String()
-	dstIP := ip.DstIP.String()
+	logrus.Debugf("TCP SYN: %s to %s", packetSrcIP, net.JoinHostPort(packetDstIP.String(), strconv.Itoa(dstPort)))
+	srcIP := packetSrcIP.String()
+	dstIP := packetDstIP.String()
 	if !s.isRunningOnAWS {
-		s.addCapturedRequest(srcIP, "", dstIP, dstIP, captureTime, nilable.FromPtr[int](nil), &dstPort, &srcPort)
+		s.addCapturedRequest(srcIP, "", dstIP, dstIP, captureTime, nilable.FromPtr[int](nil), &dstPort, &srcPort, protocolTCP)
 		return
 	}
 
@@ -114,25 +107,31 @@ func (s *TCPSniffer) HandlePacket(packet gopacket.Packet) {
 		if ok {
 			destNameOrIP = destHostname
 		}
-		s.addCapturedRequest(srcIP, "", destNameOrIP, dstIP, captureTime, nilable.FromPtr[int](nil), &dstPort, &srcPort)
+		s.addCapturedRequest(srcIP, "", destNameOrIP, dstIP, captureTime, nilable.FromPtr[int](nil), &dstPort, &srcPort, protocolTCP)
 		return
 	}
 
 	logrus.Debugf("Captured TCP SYN from %s to %s", srcIP, dstIP)
 
 	// Resolver cache could be outdated, verify same resolving result after next poll
-	s.pending = append(s.pending, pendingTCPCapture{
-		srcIp:       srcIP,
-		srcHostname: localHostname,
-		destIp:      dstIP,
-		destPort:    dstPort,
-		time:        captureTime,
-		srcPort:     srcPort,
+	s.addPendingCapture(pendingCapture{
+		srcIp:            srcIP,
+		srcHostname:      localHostname,
+		destHostnameOrIP: dstIP,
+		destIP:           dstIP,
+		time:             captureTime,
+		destPort:         &dstPort,
+		srcPort:          &srcPort,
+		protocol:         protocolTCP,
 	})
 }
 
-func (s *TCPSniffer) getSrcAndDestPort(packet gopacket.Packet, ip *layers.IPv4) (int, int, bool, error) {
-	layerType := ip.NextLayerType()
+func (s *TCPSniffer) getSrcAndDestPort(packet gopacket.Packet) (int, int, bool, error) {
+	// Use the decoded transport layer rather than the IP header's next layer type, as IPv6 may carry extension headers.
+	var layerType gopacket.LayerType
+	if transportLayer := packet.TransportLayer(); transportLayer != nil {
+		layerType = transportLayer.LayerType()
+	}
 	var portName string
 	var destPort int
 	var srcPort int
@@ -155,7 +154,7 @@ func (s *TCPSniffer) getSrcAndDestPort(packet gopacket.Packet, ip *layers.IPv4)
 		return 0, 0, false, errors.New("Unknown transport layer")
 	}
 
-	logrus.Debugf("Detected dest ip and port %s: %s", ip.DstIP.String(), portName)
+	logrus.Debugf("Detected dest ip and port %s: %s", packet.NetworkLayer().NetworkFlow().Dst(), portName)
 	return srcPort, destPort, true, nil
 }
 
@@ -168,19 +167,7 @@ func (s *TCPSniffer) RefreshHostsMapping() error {
 		return errors.Wrap(err)
 	}
 
-	for _, p := range s.pending {
-		hostname, ok := s.resolver.ResolveIP(p.srcIp)
-		if !ok {
-			logrus.WithError(err).Debugf("Could not to resolve %s, skipping packet", p.srcIp)
-			continue
-		}
-		if p.srcHostname != hostname {
-			logrus.Debugf("IP %s was resolved to %s, but now resolves to %s. skipping packet", p.srcIp, p.srcHostname, hostname)
-			continue
-		}
-		s.addCapturedRequest(p.srcIp, hostname, p.destIp, p.destIp, p.time, p.ttl, &p.destPort, &p.srcPort)
-	}
-	s.pending = make([]pendingTCPCapture, 0)
+	s.verifyPendingCaptures(s.resolver)
 	return nil
 }
 
@@ -42,6 +42,7 @@ func TestTCPSniffer_TestHandlePacketAWS(t *testing.T) {
 					DestinationIP:   nilable.From("10.244.120.78"),
 					DestinationPort: nilable.From(8000),
 					SrcPorts:        []int{55613},
+					Protocol:        nilable.From(mapperclient.TransportProtocolTcp),
 					LastSeen:        timestamp,
 				},
 			},
@@ -75,6 +76,41 @@ func TestTCPSniffer_TestHandlePacketNonAWS(t *testing.T) {
 					DestinationIP:   nilable.From("10.244.120.78"),
 					DestinationPort: nilable.From(8000),
 					SrcPorts:        []int{55613},
+					Protocol:        nilable.From(mapperclient.TransportProtocolTcp),
+					LastSeen:        timestamp,
+				},
+			},
+		},
+	}, sniffer.CollectResults())
+}
+
+func TestTCPSniffer_TestHandlePacketIPv6(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockResolver := ipresolver.NewMockIPResolver(controller)
+
+	sniffer := NewTCPSniffer(mockResolver, false)
+
+	tcpSYN, err := hex.DecodeString("6000000000140640fd000010024400010000000000000005fd000010024400020000000000000007d93d1f4000000001000000005002fd20bb8a0000")
+	if err != nil {
+		require.NoError(t, err)
+	}
+	packet := gopacket.NewPacket(tcpSYN, layers.LayerTypeIPv6, gopacket.Default)
+	timestamp := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	packet.Metadata().CaptureInfo.Timestamp = timestamp
+	sniffer.HandlePacket(packet)
+	require.NoError(t, sniffer.RefreshHostsMapping())
+
+	require.Equal(t, []mapperclient.RecordedDestinationsForSrc{
+		{
+			SrcIp:       "fd00:10:244:1::5",
+			SrcHostname: "",
+			Destinations: []mapperclient.Destination{
+				{
+					Destination:     "fd00:10:244:2::7",
+					DestinationIP:   nilable.From("fd00:10:244:2::7"),
+					DestinationPort: nilable.From(8000),
+					SrcPorts:        []int{55613},
+					Protocol:        nilable.From(mapperclient.TransportProtocolTcp),
 					LastSeen:        timestamp,
 				},
 			},
@@ -0,0 +1,211 @@
+//go:build ignore
+
+// Generates the Kafka pcap fixtures used by the Kafka sniffer tests, by recording the requests a sarama client sends
+// to a sarama mock broker and writing them as TCP segments between a client and a broker pod.
+//
+//	go run ./pkg/collectors/testdata/generate_kafka_pcaps.go -out pkg/collectors/testdata
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"flag"
+	"fmt"
+	"github.com/IBM/sarama"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	clientIP   = "10.244.0.27"
+	brokerIP   = "10.244.0.31"
+	clientPort = 54321
+	brokerPort = 9092
+	mss        = 1400
+)
+
+type reporter struct{}
+
+func (reporter) Error(args ...interface{})                 { log.Print(args...) }
+func (reporter) Errorf(format string, args ...interface{}) { log.Printf(format, args...) }
+func (reporter) Fatal(args ...interface{})                 { log.Fatal(args...) }
+func (reporter) Fatalf(format string, args ...interface{}) { log.Fatalf(format, args...) }
+func (reporter) Helper()                                   {}
+
+// recordingListener records the bytes the broker reads from each of its connections, which are the requests clients
+// send.
+type recordingListener struct {
+	net.Listener
+	lock  sync.Mutex
+	conns []*recordingConn
+}
+
+type recordingConn struct {
+	net.Conn
+	lock     sync.Mutex
+	requests bytes.Buffer
+}
+
+func (l *recordingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	recording := &recordingConn{Conn: conn}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.conns = append(l.conns, recording)
+	return recording, nil
+}
+
+func (c *recordingConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.requests.Write(b[:n])
+	return n, err
+}
+
+func (l *recordingListener) requests() []byte {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	var requests bytes.Buffer
+	for _, conn := range l.conns {
+		conn.lock.Lock()
+		requests.Write(conn.requests.Bytes())
+		conn.lock.Unlock()
+	}
+	return requests.Bytes()
+}
+
+func newBroker(listener *recordingListener) *sarama.MockBroker {
+	broker := sarama.NewMockBrokerListener(reporter{}, 1, listener)
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(reporter{}).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader("orders", 0, broker.BrokerID()).
+			SetLeader("payments", 0, broker.BrokerID()),
+		"ApiVersionsRequest": sarama.NewMockApiVersionsResponse(reporter{}),
+		"ProduceRequest":     sarama.NewMockProduceResponse(reporter{}),
+		"OffsetRequest": sarama.NewMockOffsetResponse(reporter{}).
+			SetOffset("payments", 0, sarama.OffsetOldest, 0).
+			SetOffset("payments", 0, sarama.OffsetNewest, 1),
+		"FetchRequest": sarama.NewMockFetchResponse(reporter{}, 1).
+			SetMessage("payments", 0, 0, sarama.StringEncoder("payment")),
+	})
+	return broker
+}
+
+func record(version sarama.KafkaVersion) []byte {
+	tcpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		log.Fatal(err)
+	}
+	listener := &recordingListener{Listener: tcpListener}
+	broker := newBroker(listener)
+	defer broker.Close()
+
+	config := sarama.NewConfig()
+	config.Version = version
+	config.ClientID = "checkout"
+	config.Producer.Return.Successes = true
+
+	producer, err := sarama.NewSyncProducer([]string{broker.Addr()}, config)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, _, err := producer.SendMessage(&sarama.ProducerMessage{Topic: "orders", Value: sarama.StringEncoder(bytes.Repeat([]byte("order"), 500))}); err != nil {
+		log.Fatal(err)
+	}
+	_ = producer.Close()
+
+	consumer, err := sarama.NewConsumer([]string{broker.Addr()}, config)
+	if err != nil {
+		log.Fatal(err)
+	}
+	partitionConsumer, err := consumer.ConsumePartition("payments", 0, sarama.OffsetOldest)
+	if err != nil {
+		log.Fatal(err)
+	}
+	select {
+	case <-partitionConsumer.Messages():
+	case <-time.After(5 * time.Second):
+		log.Fatal("timed out consuming")
+	}
+	_ = partitionConsumer.Close()
+	_ = consumer.Close()
+
+	return listener.requests()
+}
+
+// splitRequests splits a stream of requests sent over several connections into single requests.
+func splitRequests(stream []byte) [][]byte {
+	requests := make([][]byte, 0)
+	for len(stream) >= 4 {
+		size := int(binary.BigEndian.Uint32(stream))
+		requests = append(requests, stream[:4+size])
+		stream = stream[4+size:]
+	}
+	return requests
+}
+
+func writePcap(path string, requests [][]byte) {
+	f, err := os.Create(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	w := pcapgo.NewWriter(f)
+	if err := w.WriteFileHeader(65536, layers.LinkTypeEthernet); err != nil {
+		log.Fatal(err)
+	}
+
+	timestamp := time.Date(2024, 5, 2, 9, 12, 31, 0, time.UTC)
+	seq := uint32(1000)
+	for _, request := range requests {
+		for len(request) > 0 {
+			segment := request[:min(mss, len(request))]
+			request = request[len(segment):]
+
+			eth := &layers.Ethernet{SrcMAC: net.HardwareAddr{0, 0, 0, 0, 0, 1}, DstMAC: net.HardwareAddr{0, 0, 0, 0, 0, 2}, EthernetType: layers.EthernetTypeIPv4}
+			ip := &layers.IPv4{Version: 4, TTL: 64, Protocol: layers.IPProtocolTCP, SrcIP: net.ParseIP(clientIP), DstIP: net.ParseIP(brokerIP)}
+			tcp := &layers.TCP{SrcPort: clientPort, DstPort: brokerPort, Seq: seq, ACK: true, PSH: len(request) == 0, Window: 65535}
+			if err := tcp.SetNetworkLayerForChecksum(ip); err != nil {
+				log.Fatal(err)
+			}
+			buf := gopacket.NewSerializeBuffer()
+			if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}, eth, ip, tcp, gopacket.Payload(segment)); err != nil {
+				log.Fatal(err)
+			}
+			data := buf.Bytes()
+			if err := w.WritePacket(gopacket.CaptureInfo{Timestamp: timestamp, CaptureLength: len(data), Length: len(data)}, data); err != nil {
+				log.Fatal(err)
+			}
+			seq += uint32(len(segment))
+			timestamp = timestamp.Add(time.Millisecond)
+		}
+	}
+}
+
+func main() {
+	out := flag.String("out", ".", "directory to write the fixtures to")
+	flag.Parse()
+
+	for name, version := range map[string]sarama.KafkaVersion{
+		"kafka_v0_10.pcap": sarama.V0_10_2_0,
+		"kafka_v2_8.pcap":  sarama.V2_8_0_0,
+	} {
+		requests := splitRequests(record(version))
+		for _, request := range requests {
+			fmt.Printf("%s: API key %d v%d, %d bytes\n", name, binary.BigEndian.Uint16(request[4:]), binary.BigEndian.Uint16(request[6:]), len(request))
+		}
+		writePcap(filepath.Join(*out, name), requests)
+	}
+}
@@ -0,0 +1,208 @@
+package collectors
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcap"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	sharedconfig "github.com/otterize/network-mapper/src/shared/config"
+	"github.com/otterize/network-mapper/src/sniffer/pkg/ipresolver"
+	"github.com/otterize/nilable"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"golang.org/x/crypto/cryptobyte"
+	"net"
+	"strconv"
+	"strings"
+)
+
+const (
+	tlsContentTypeHandshake     = 0x16
+	tlsHandshakeTypeClientHello = 0x01
+	tlsExtensionServerName      = 0x0000
+	tlsServerNameTypeHostName   = 0x00
+)
+
+// tlsClientHelloFilter matches TCP segments that start with a TLS handshake record holding a ClientHello. As with TCP
+// SYNs, IPv6 segments are matched by offset in the fixed IPv6 header, as libpcap's tcp[] accessor only supports IPv4.
+const tlsClientHelloFilter = "(tcp and tcp[((tcp[12:1] & 0xf0) >> 2):1] == 0x16 and tcp[((tcp[12:1] & 0xf0) >> 2) + 5:1] == 0x01) or " +
+	"(ip6 and ip6[6] == 6 and ip6[40 + ((ip6[52:1] & 0xf0) >> 2):1] == 0x16 and ip6[45 + ((ip6[52:1] & 0xf0) >> 2):1] == 0x01)"
+
+// TLSSniffer captures the server names (SNI) clients send in the ClientHello of outbound TLS connections, so external
+// traffic is attributed to hostnames even when the DNS lookup of the hostname was not captured. It shares its resolver
+// with the TCP sniffer, which refreshes it.
+type TLSSniffer struct {
+	NetworkCollector
+	resolver       ipresolver.IPResolver
+	isRunningOnAWS bool
+}
+
+func NewTLSSniffer(resolver ipresolver.IPResolver, isRunningOnAWS bool) *TLSSniffer {
+	s := TLSSniffer{
+		NetworkCollector: NetworkCollector{},
+		resolver:         resolver,
+		isRunningOnAWS:   isRunningOnAWS,
+	}
+	s.resetData()
+	return &s
+}
+
+func (s *TLSSniffer) CreateTLSPacketStream() (chan gopacket.Packet, error) {
+	handle, err := pcap.OpenLive("any", 0, true, pcap.BlockForever)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	err = handle.SetDirection(pcap.DirectionIn)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	err = handle.SetBPFFilter(tlsClientHelloFilter)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+
+	packetSource := gopacket.NewPacketSource(handle, handle.LinkType())
+	return packetSource.Packets(), nil
+}
+
+func (s *TLSSniffer) HandlePacket(packet gopacket.Packet) {
+	if !viper.GetBool(sharedconfig.EnableTLSKey) {
+		return
+	}
+	tcpLayer, ok := packet.Layer(layers.LayerTypeTCP).(*layers.TCP)
+	if !ok {
+		return
+	}
+	serverName, ok := parseClientHelloServerName(tcpLayer.Payload)
+	if !ok {
+		return
+	}
+	packetSrcIP, packetDstIP, ok := detectIPs(packet)
+	if !ok {
+		return
+	}
+
+	captureTime := detectCaptureTime(packet)
+	srcIP := packetSrcIP.String()
+	dstIP := packetDstIP.String()
+	srcPort := int(tcpLayer.SrcPort)
+	dstPort := int(tcpLayer.DstPort)
+	logrus.Debugf("TLS ClientHello: %s to %s (%s)", srcIP, net.JoinHostPort(dstIP, strconv.Itoa(dstPort)), serverName)
+
+	if !s.isRunningOnAWS {
+		s.addCapturedRequest(srcIP, "", serverName, dstIP, captureTime, nilable.FromPtr[int](nil), &dstPort, &srcPort, protocolTCP)
+		return
+	}
+
+	hostname, ok := s.resolver.ResolveIP(srcIP)
+	if !ok {
+		logrus.Debugf("Can't resolve IP addr %s, skipping", srcIP)
+		return
+	}
+
+	// Resolver cache could be outdated, verify same resolving result after next poll
+	s.addPendingCapture(pendingCapture{
+		srcIp:            srcIP,
+		srcHostname:      hostname,
+		destHostnameOrIP: serverName,
+		destIP:           dstIP,
+		time:             captureTime,
+		destPort:         &dstPort,
+		srcPort:          &srcPort,
+		protocol:         protocolTCP,
+	})
+}
+
+// parseClientHelloServerName returns the host name in the server name extension of the TLS ClientHello payload starts
+// with. A ClientHello larger than a TCP segment is not reassembled, so its extensions are read up to the end of payload
+// and the server name is only found if its extension is in the first segment, which is where most clients send it.
+func parseClientHelloServerName(payload []byte) (string, bool) {
+	s := cryptobyte.String(payload)
+	var contentType uint8
+	var recordVersion, recordLength uint16
+	if !s.ReadUint8(&contentType) || contentType != tlsContentTypeHandshake ||
+		!s.ReadUint16(&recordVersion) || recordVersion>>8 != 3 ||
+		!s.ReadUint16(&recordLength) {
+		return "", false
+	}
+	s = truncateTo(s, int(recordLength))
+
+	var handshakeType uint8
+	var handshakeLength uint32
+	if !s.ReadUint8(&handshakeType) || handshakeType != tlsHandshakeTypeClientHello || !s.ReadUint24(&handshakeLength) {
+		return "", false
+	}
+	s = truncateTo(s, int(handshakeLength))
+
+	var sessionID, cipherSuites, compressionMethods cryptobyte.String
+	var extensionsLength uint16
+	if !s.Skip(2+32) || // legacy_version, random
+		!s.ReadUint8LengthPrefixed(&sessionID) ||
+		!s.ReadUint16LengthPrefixed(&cipherSuites) ||
+		!s.ReadUint8LengthPrefixed(&compressionMethods) ||
+		!s.ReadUint16(&extensionsLength) {
+		return "", false
+	}
+	s = truncateTo(s, int(extensionsLength))
+
+	for !s.Empty() {
+		var extensionType uint16
+		var extension cryptobyte.String
+		if !s.ReadUint16(&extensionType) || !s.ReadUint16LengthPrefixed(&extension) {
+			return "", false
+		}
+		if extensionType != tlsExtensionServerName {
+			continue
+		}
+
+		var serverNames cryptobyte.String
+		if !extension.ReadUint16LengthPrefixed(&serverNames) {
+			return "", false
+		}
+		for !serverNames.Empty() {
+			var nameType uint8
+			var name cryptobyte.String
+			if !serverNames.ReadUint8(&nameType) || !serverNames.ReadUint16LengthPrefixed(&name) {
+				return "", false
+			}
+			if nameType == tlsServerNameTypeHostName {
+				return normalizeServerName(string(name))
+			}
+		}
+		return "", false
+	}
+	return "", false
+}
+
+// truncateTo returns the first length bytes of s, or all of s if the field continues past the end of the captured
+// segment.
+func truncateTo(s cryptobyte.String, length int) cryptobyte.String {
+	if length < len(s) {
+		return s[:length]
+	}
+	return s
+}
+
+// normalizeServerName lowercases a server name, and rejects names that are not DNS hostnames. IP literals are not
+// permitted as server names, but are sent by some clients.
+func normalizeServerName(name string) (string, bool) {
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	if name == "" || net.ParseIP(name) != nil {
+		return "", false
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' || c == '.' || c == '_') {
+			return "", false
+		}
+	}
+	return name, true
+}
+
+// VerifyPendingCaptures reports the pending captures whose source IPs still resolve to the same hostnames, and should be
+// called after the TCP sniffer refreshed the shared resolver.
+func (s *TLSSniffer) VerifyPendingCaptures() {
+	if !s.isRunningOnAWS {
+		return
+	}
+	s.verifyPendingCaptures(s.resolver)
+}
@@ -0,0 +1,119 @@
+package collectors
+
+import (
+	"crypto/tls"
+	"github.com/otterize/network-mapper/src/mapperclient"
+	sharedconfig "github.com/otterize/network-mapper/src/shared/config"
+	"github.com/otterize/network-mapper/src/sniffer/pkg/ipresolver"
+	"github.com/otterize/nilable"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net"
+	"testing"
+)
+
+// clientHello returns the first TLS record a Go client sends when connecting to serverName.
+func clientHello(t *testing.T, serverName string) []byte {
+	client, server := net.Pipe()
+	defer server.Close()
+	go func() {
+		_ = tls.Client(client, &tls.Config{ServerName: serverName}).Handshake()
+		_ = client.Close()
+	}()
+
+	buf := make([]byte, 16*1024)
+	n, err := server.Read(buf)
+	require.NoError(t, err)
+	return buf[:n]
+}
+
+func enableTLSSniffer(t *testing.T) {
+	viper.Set(sharedconfig.EnableTLSKey, true)
+	t.Cleanup(func() { viper.Set(sharedconfig.EnableTLSKey, sharedconfig.EnableTLSSnifferDefault) })
+}
+
+func TestParseClientHelloServerName(t *testing.T) {
+	hello := clientHello(t, "API.Example.com")
+	serverName, ok := parseClientHelloServerName(hello)
+	require.True(t, ok)
+	require.Equal(t, "api.example.com", serverName)
+
+	// The server name extension is found when the rest of a ClientHello spanning several segments was not captured
+	serverName, ok = parseClientHelloServerName(hello[:len(hello)/2])
+	require.True(t, ok)
+	require.Equal(t, "api.example.com", serverName)
+
+	// Clients do not send IP literals as server names
+	_, ok = parseClientHelloServerName(clientHello(t, "203.0.113.10"))
+	require.False(t, ok)
+
+	_, ok = parseClientHelloServerName([]byte("GET / HTTP/1.1\r\n\r\n"))
+	require.False(t, ok)
+	_, ok = parseClientHelloServerName(hello[:20])
+	require.False(t, ok)
+}
+
+func TestTLSSniffer_TestHandlePacketAWS(t *testing.T) {
+	enableTLSSniffer(t)
+	controller := gomock.NewController(t)
+	mockResolver := ipresolver.NewMockIPResolver(controller)
+	// The resolver is refreshed by the TCP sniffer, so the TLS sniffer only resolves IPs with it
+	mockResolver.EXPECT().ResolveIP("10.244.0.27").Return("client-1", true).Times(2) // once for the initial check, and then another for verification
+
+	sniffer := NewTLSSniffer(mockResolver, true)
+	sniffer.HandlePacket(tcpSegment(t, 443, 1000, string(clientHello(t, "api.example.com"))))
+	require.Empty(t, sniffer.CollectResults())
+	sniffer.VerifyPendingCaptures()
+
+	require.Equal(t, []mapperclient.RecordedDestinationsForSrc{
+		{
+			SrcIp:       "10.244.0.27",
+			SrcHostname: "client-1",
+			Destinations: []mapperclient.Destination{
+				{
+					Destination:     "api.example.com",
+					DestinationIP:   nilable.From("10.96.12.5"),
+					DestinationPort: nilable.From(443),
+					SrcPorts:        []int{54321},
+					Protocol:        nilable.From(mapperclient.TransportProtocolTcp),
+					LastSeen:        httpTestTimestamp,
+				},
+			},
+		},
+	}, sniffer.CollectResults())
+}
+
+func TestTLSSniffer_TestHandlePacketNonAWS(t *testing.T) {
+	enableTLSSniffer(t)
+	controller := gomock.NewController(t)
+	mockResolver := ipresolver.NewMockIPResolver(controller)
+
+	sniffer := NewTLSSniffer(mockResolver, false)
+	sniffer.HandlePacket(tcpSegment(t, 8443, 1000, string(clientHello(t, "api.example.com"))))
+	// Other TLS records and plaintext are ignored
+	sniffer.HandlePacket(tcpSegment(t, 8443, 2000, "\x17\x03\x03\x00\x05hello"))
+	sniffer.HandlePacket(tcpSegment(t, 8080, 3000, "GET / HTTP/1.1\r\n\r\n"))
+
+	require.Equal(t, []mapperclient.RecordedDestinationsForSrc{
+		{
+			SrcIp: "10.244.0.27",
+			Destinations: []mapperclient.Destination{
+				{
+					Destination:     "api.example.com",
+					DestinationIP:   nilable.From("10.96.12.5"),
+					DestinationPort: nilable.From(8443),
+					SrcPorts:        []int{54321},
+					Protocol:        nilable.From(mapperclient.TransportProtocolTcp),
+					LastSeen:        httpTestTimestamp,
+				},
+			},
+		},
+	}, sniffer.CollectResults())
+}
+
+func TestTLSSniffer_TestDisabledByDefault(t *testing.T) {
+	sniffer := NewTLSSniffer(nil, false)
+	sniffer.HandlePacket(tcpSegment(t, 443, 1000, string(clientHello(t, "api.example.com"))))
+	require.Empty(t, sniffer.CollectResults())
+}
@@ -18,6 +18,19 @@ const (
 	HostsMappingRefreshIntervalDefault = 500 * time.Millisecond
 	UseExtendedProcfsResolutionKey     = "use-extended-procfs-resolution"
 	UseExtendedProcfsResolutionDefault = false
+	KafkaPortsKey                      = "kafka-ports"               // Broker ports to decode Kafka requests on, disabled when empty
+	HTTPPortsKey                       = "http-ports"                // Server ports to capture plaintext HTTP requests on, disabled when empty
+	ReplayPcapFilesKey                 = "replay-pcap-files"         // Capture files to replay instead of sniffing live traffic
+	ReplayHostsMappingFileKey          = "replay-hosts-mapping-file" // IP to hostname mapping, in the format of /etc/hosts, to resolve replayed IPs with
+	ReplayOutputKey                    = "replay-output"
+	ReplayOutputMapper                 = "mapper"
+	ReplayOutputStdout                 = "stdout"
+	ReplayOutputDefault                = ReplayOutputMapper
+	ConnectionTracerKey                = "connection-tracer" // How TCP connections are captured, falling back to pcap if eBPF is unavailable
+	ConnectionTracerPcap               = "pcap"
+	ConnectionTracerEBPF               = "ebpf"
+	ConnectionTracerDefault            = ConnectionTracerPcap
+	EBPFRecordEventsPathKey            = "ebpf-record-events-path" // File to record traced eBPF events to, as test fixtures
 )
 
 func init() {
@@ -27,4 +40,11 @@ func init() {
 	viper.SetDefault(HostProcDirKey, HostProcDirDefault)
 	viper.SetDefault(HostsMappingRefreshIntervalKey, HostsMappingRefreshIntervalDefault)
 	viper.SetDefault(UseExtendedProcfsResolutionKey, UseExtendedProcfsResolutionDefault)
+	viper.SetDefault(KafkaPortsKey, []string{})
+	viper.SetDefault(HTTPPortsKey, []string{})
+	viper.SetDefault(ReplayPcapFilesKey, []string{})
+	viper.SetDefault(ReplayHostsMappingFileKey, "")
+	viper.SetDefault(ReplayOutputKey, ReplayOutputDefault)
+	viper.SetDefault(ConnectionTracerKey, ConnectionTracerDefault)
+	viper.SetDefault(EBPFRecordEventsPathKey, "")
 }
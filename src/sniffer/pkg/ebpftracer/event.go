@@ -0,0 +1,168 @@
+package ebpftracer
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"io"
+	"net"
+	"strings"
+)
+
+type EventType uint8
+
+const (
+	EventTypeConnect EventType = 1 // A TCP connection opened by a local process
+	EventTypeAccept  EventType = 2 // A TCP connection accepted by a local process
+	EventTypeUDPSend EventType = 3 // A UDP datagram sent by a local process
+)
+
+const (
+	afInet  = 2
+	afInet6 = 10
+)
+
+/*
+The layout of events written by the eBPF programs to the ring buffer, in the host's byte order except for the remote
+port, which is in network byte order as it is stored in the kernel's socket:
+
+	0  u64 cgroup ID of the process
+	8  u32 PID (thread group ID) of the process
+	12 u8  event type
+	13 u8  address family of the remote address
+	14 u16 address family of the local address
+	16 u16 local port
+	18 u16 remote port
+	20 [16]u8 local address, of which the first 4 bytes are used for IPv4
+	36 [16]u8 remote address, of which the first 4 bytes are used for IPv4
+*/
+const (
+	eventSize            = 56
+	eventOffCgroupID     = 0
+	eventOffPID          = 8
+	eventOffType         = 12
+	eventOffRemoteFamily = 13
+	eventOffLocalFamily  = 14
+	eventOffLocalPort    = 16
+	eventOffRemotePort   = 18
+	eventOffLocalAddr    = 20
+	eventOffRemoteAddr   = 36
+)
+
+// Event is a connection traced by the eBPF programs, from the point of view of the socket of the local process.
+type Event struct {
+	CgroupID   uint64
+	PID        uint32
+	Type       EventType
+	LocalIP    net.IP // Unspecified for UDP datagrams sent from sockets not bound to an address
+	LocalPort  int
+	RemoteIP   net.IP
+	RemotePort int
+}
+
+func parseAddr(family uint8, addr []byte) (net.IP, error) {
+	switch family {
+	case afInet:
+		return net.IP(append([]byte{}, addr[:net.IPv4len]...)).To16(), nil
+	case afInet6:
+		return net.IP(append([]byte{}, addr[:net.IPv6len]...)), nil
+	default:
+		return nil, errors.Errorf("unknown address family %d", family)
+	}
+}
+
+func ParseEvent(raw []byte) (Event, error) {
+	if len(raw) < eventSize {
+		return Event{}, errors.Errorf("event is %d bytes, expected %d", len(raw), eventSize)
+	}
+
+	localIP, err := parseAddr(uint8(binary.NativeEndian.Uint16(raw[eventOffLocalFamily:])), raw[eventOffLocalAddr:])
+	if err != nil {
+		return Event{}, errors.Wrap(err)
+	}
+	remoteIP, err := parseAddr(raw[eventOffRemoteFamily], raw[eventOffRemoteAddr:])
+	if err != nil {
+		return Event{}, errors.Wrap(err)
+	}
+
+	event := Event{
+		CgroupID:   binary.NativeEndian.Uint64(raw[eventOffCgroupID:]),
+		PID:        binary.NativeEndian.Uint32(raw[eventOffPID:]),
+		Type:       EventType(raw[eventOffType]),
+		LocalIP:    localIP,
+		LocalPort:  int(binary.NativeEndian.Uint16(raw[eventOffLocalPort:])),
+		RemoteIP:   remoteIP,
+		RemotePort: int(binary.BigEndian.Uint16(raw[eventOffRemotePort:])),
+	}
+	switch event.Type {
+	case EventTypeConnect, EventTypeAccept, EventTypeUDPSend:
+		return event, nil
+	default:
+		return Event{}, errors.Errorf("unknown event type %d", event.Type)
+	}
+}
+
+func marshalAddr(ip net.IP, raw []byte) uint8 {
+	if ip4 := ip.To4(); ip4 != nil {
+		copy(raw, ip4)
+		return afInet
+	}
+	copy(raw, ip.To16())
+	return afInet6
+}
+
+// MarshalBinary encodes the event as written by the eBPF programs, to record event fixtures.
+func (e Event) MarshalBinary() ([]byte, error) {
+	raw := make([]byte, eventSize)
+	binary.NativeEndian.PutUint64(raw[eventOffCgroupID:], e.CgroupID)
+	binary.NativeEndian.PutUint32(raw[eventOffPID:], e.PID)
+	raw[eventOffType] = uint8(e.Type)
+	raw[eventOffRemoteFamily] = marshalAddr(e.RemoteIP, raw[eventOffRemoteAddr:])
+	binary.NativeEndian.PutUint16(raw[eventOffLocalFamily:], uint16(marshalAddr(e.LocalIP, raw[eventOffLocalAddr:])))
+	binary.NativeEndian.PutUint16(raw[eventOffLocalPort:], uint16(e.LocalPort))
+	binary.BigEndian.PutUint16(raw[eventOffRemotePort:], uint16(e.RemotePort))
+	return raw, nil
+}
+
+// EventRecorder writes raw events as fixtures, one hex-encoded event per line.
+type EventRecorder struct {
+	w io.Writer
+}
+
+func NewEventRecorder(w io.Writer) *EventRecorder {
+	return &EventRecorder{w: w}
+}
+
+func (r *EventRecorder) Record(raw []byte) error {
+	_, err := fmt.Fprintln(r.w, hex.EncodeToString(raw))
+	return errors.Wrap(err)
+}
+
+// ReadEventFixtures reads events recorded by EventRecorder. Empty lines and lines starting with '#' are ignored.
+func ReadEventFixtures(r io.Reader) ([]Event, error) {
+	events := make([]Event, 0)
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		raw, err := hex.DecodeString(line)
+		if err != nil {
+			return nil, errors.Errorf("line %d: %w", lineNumber, err)
+		}
+		event, err := ParseEvent(raw)
+		if err != nil {
+			return nil, errors.Errorf("line %d: %w", lineNumber, err)
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err)
+	}
+	return events, nil
+}
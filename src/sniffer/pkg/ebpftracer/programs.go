@@ -0,0 +1,298 @@
+package ebpftracer
+
+import (
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/asm"
+	"github.com/cilium/ebpf/btf"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"runtime"
+)
+
+// The programs are assembled at runtime rather than compiled from C, so that the offsets of the kernel structures they
+// read are taken from the running kernel's BTF and no BPF toolchain is needed to build the sniffer.
+
+const (
+	// The event is built on the stack, followed by 8 bytes of scratch space for values read from the kernel.
+	stackOffEvent   = -eventSize
+	stackOffScratch = stackOffEvent - 8
+
+	// Identical events, such as datagrams sent to the same destination, are written to the ring buffer at most once
+	// per interval.
+	dedupIntervalNanoseconds = 1_000_000_000
+)
+
+// kernelOffsets are the offsets of the fields the programs read, relative to the start of their structure.
+type kernelOffsets struct {
+	sockFamily     int32
+	sockDaddr      int32
+	sockRcvSaddr   int32
+	sockDport      int32
+	sockNum        int32
+	sockV6Daddr    int32
+	sockV6RcvSaddr int32
+	msghdrName     int32
+}
+
+// ptRegsOffsets are the offsets of the registers holding function arguments and return values in struct pt_regs.
+type ptRegsOffsets struct {
+	param1 int16
+	param2 int16
+	ret    int16
+}
+
+func getPtRegsOffsets() (ptRegsOffsets, error) {
+	switch runtime.GOARCH {
+	case "amd64":
+		return ptRegsOffsets{param1: 112, param2: 104, ret: 80}, nil // di, si, ax
+	case "arm64":
+		return ptRegsOffsets{param1: 0, param2: 8, ret: 0}, nil // regs[0], regs[1], regs[0]
+	default:
+		return ptRegsOffsets{}, errors.Errorf("eBPF tracing is not supported on %s", runtime.GOARCH)
+	}
+}
+
+// memberOffset returns the offset of a member of a struct or union, including members of anonymous structs and unions
+// nested in it.
+func memberOffset(t btf.Type, name string) (uint32, bool) {
+	var members []btf.Member
+	switch composite := btf.UnderlyingType(t).(type) {
+	case *btf.Struct:
+		members = composite.Members
+	case *btf.Union:
+		members = composite.Members
+	default:
+		return 0, false
+	}
+
+	for _, member := range members {
+		if member.Name == name {
+			return member.Offset.Bytes(), true
+		}
+		if member.Name == "" {
+			if offset, ok := memberOffset(member.Type, name); ok {
+				return member.Offset.Bytes() + offset, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func structMemberOffsets(spec *btf.Spec, structName string, base uint32, names map[string]*int32) error {
+	var s *btf.Struct
+	if err := spec.TypeByName(structName, &s); err != nil {
+		return errors.Wrap(err)
+	}
+	for name, offset := range names {
+		memberOff, ok := memberOffset(s, name)
+		if !ok {
+			return errors.Errorf("kernel struct %s has no member %s", structName, name)
+		}
+		*offset = int32(base + memberOff)
+	}
+	return nil
+}
+
+func loadKernelOffsets() (kernelOffsets, error) {
+	spec, err := btf.LoadKernelSpec()
+	if err != nil {
+		return kernelOffsets{}, errors.Errorf("failed loading kernel BTF: %w", err)
+	}
+
+	var sock *btf.Struct
+	if err := spec.TypeByName("sock", &sock); err != nil {
+		return kernelOffsets{}, errors.Wrap(err)
+	}
+	sockCommon, ok := memberOffset(sock, "__sk_common")
+	if !ok {
+		return kernelOffsets{}, errors.New("kernel struct sock has no member __sk_common")
+	}
+
+	offsets := kernelOffsets{}
+	err = structMemberOffsets(spec, "sock_common", sockCommon, map[string]*int32{
+		"skc_family":       &offsets.sockFamily,
+		"skc_daddr":        &offsets.sockDaddr,
+		"skc_rcv_saddr":    &offsets.sockRcvSaddr,
+		"skc_dport":        &offsets.sockDport,
+		"skc_num":          &offsets.sockNum,
+		"skc_v6_daddr":     &offsets.sockV6Daddr,
+		"skc_v6_rcv_saddr": &offsets.sockV6RcvSaddr,
+	})
+	if err != nil {
+		return kernelOffsets{}, errors.Wrap(err)
+	}
+	err = structMemberOffsets(spec, "msghdr", 0, map[string]*int32{"msg_name": &offsets.msghdrName})
+	if err != nil {
+		return kernelOffsets{}, errors.Wrap(err)
+	}
+	return offsets, nil
+}
+
+// programBuilder assembles a program that builds an event from a socket and writes it to the events ring buffer.
+type programBuilder struct {
+	offsets kernelOffsets
+	events  *ebpf.Map
+	seen    *ebpf.Map
+	insns   asm.Instructions
+}
+
+func (b *programBuilder) emit(insns ...asm.Instruction) {
+	b.insns = append(b.insns, insns...)
+}
+
+// probeRead copies size bytes at src+srcOff in kernel memory to the stack at stackOff. Failed reads leave the
+// destination zeroed.
+func (b *programBuilder) probeRead(stackOff int32, size int32, src asm.Register, srcOff int32) {
+	b.emit(
+		asm.Mov.Reg(asm.R1, asm.RFP),
+		asm.Add.Imm(asm.R1, stackOff),
+		asm.Mov.Imm(asm.R2, size),
+		asm.Mov.Reg(asm.R3, src),
+		asm.Add.Imm(asm.R3, srcOff),
+		asm.FnProbeReadKernel.Call(),
+	)
+}
+
+func (b *programBuilder) zeroStack() {
+	for off := int16(stackOffScratch); off < 0; off += 8 {
+		b.emit(asm.StoreImm(asm.RFP, off, 0, asm.DWord))
+	}
+}
+
+// readSocket fills the addresses and ports of the socket in R6 into the event, and exits for sockets that are not
+// IPv4 or IPv6.
+func (b *programBuilder) readSocket() {
+	b.probeRead(stackOffEvent+eventOffLocalFamily, 2, asm.R6, b.offsets.sockFamily)
+	b.emit(
+		asm.LoadMem(asm.R1, asm.RFP, stackOffEvent+eventOffLocalFamily, asm.Half),
+		asm.StoreMem(asm.RFP, stackOffEvent+eventOffRemoteFamily, asm.R1, asm.Byte),
+		asm.JEq.Imm(asm.R1, afInet, "socket_ipv4"),
+		asm.JEq.Imm(asm.R1, afInet6, "socket_ipv6"),
+		asm.Ja.Label("exit"),
+	)
+
+	b.emit(asm.Mov.Imm(asm.R0, 0).WithSymbol("socket_ipv4"))
+	b.probeRead(stackOffEvent+eventOffLocalAddr, 4, asm.R6, b.offsets.sockRcvSaddr)
+	b.probeRead(stackOffEvent+eventOffRemoteAddr, 4, asm.R6, b.offsets.sockDaddr)
+	b.emit(asm.Ja.Label("socket_ports"))
+
+	b.emit(asm.Mov.Imm(asm.R0, 0).WithSymbol("socket_ipv6"))
+	b.probeRead(stackOffEvent+eventOffLocalAddr, 16, asm.R6, b.offsets.sockV6RcvSaddr)
+	b.probeRead(stackOffEvent+eventOffRemoteAddr, 16, asm.R6, b.offsets.sockV6Daddr)
+
+	b.emit(asm.Mov.Imm(asm.R0, 0).WithSymbol("socket_ports"))
+	b.probeRead(stackOffEvent+eventOffLocalPort, 2, asm.R6, b.offsets.sockNum)
+	b.probeRead(stackOffEvent+eventOffRemotePort, 2, asm.R6, b.offsets.sockDport)
+}
+
+// readMsgName replaces the remote address of the event with the destination address of the message in R7, which is
+// set for datagrams sent from unconnected sockets.
+func (b *programBuilder) readMsgName() {
+	b.probeRead(stackOffScratch, 8, asm.R7, b.offsets.msghdrName)
+	b.emit(
+		asm.LoadMem(asm.R8, asm.RFP, stackOffScratch, asm.DWord),
+		asm.JEq.Imm(asm.R8, 0, "output"),
+	)
+	b.probeRead(stackOffScratch, 2, asm.R8, 0)
+	b.emit(
+		asm.LoadMem(asm.R1, asm.RFP, stackOffScratch, asm.Half),
+		asm.JEq.Imm(asm.R1, afInet, "msg_name_ipv4"),
+		asm.JEq.Imm(asm.R1, afInet6, "msg_name_ipv6"),
+		asm.Ja.Label("output"),
+	)
+
+	// struct sockaddr_in: the port follows the family, and is followed by the address
+	b.emit(asm.StoreImm(asm.RFP, stackOffEvent+eventOffRemoteFamily, afInet, asm.Byte).WithSymbol("msg_name_ipv4"))
+	b.probeRead(stackOffEvent+eventOffRemotePort, 2, asm.R8, 2)
+	b.probeRead(stackOffEvent+eventOffRemoteAddr, 4, asm.R8, 4)
+	b.emit(asm.Ja.Label("output"))
+
+	// struct sockaddr_in6: the port follows the family, and the address follows the 4-byte flow info
+	b.emit(asm.StoreImm(asm.RFP, stackOffEvent+eventOffRemoteFamily, afInet6, asm.Byte).WithSymbol("msg_name_ipv6"))
+	b.probeRead(stackOffEvent+eventOffRemotePort, 2, asm.R8, 2)
+	b.probeRead(stackOffEvent+eventOffRemoteAddr, 16, asm.R8, 8)
+}
+
+// output attributes the event to the current process and writes it to the ring buffer, unless an identical event was
+// written within the dedup interval.
+func (b *programBuilder) output(eventType EventType) {
+	b.emit(
+		asm.FnGetCurrentCgroupId.Call().WithSymbol("output"),
+		asm.StoreMem(asm.RFP, stackOffEvent+eventOffCgroupID, asm.R0, asm.DWord),
+		asm.FnGetCurrentPidTgid.Call(),
+		asm.RSh.Imm(asm.R0, 32),
+		asm.StoreMem(asm.RFP, stackOffEvent+eventOffPID, asm.R0, asm.Word),
+		asm.StoreImm(asm.RFP, stackOffEvent+eventOffType, int64(eventType), asm.Byte),
+
+		asm.LoadMapPtr(asm.R1, b.seen.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, stackOffEvent),
+		asm.FnMapLookupElem.Call(),
+		asm.JEq.Imm(asm.R0, 0, "output_ringbuf"),
+		asm.LoadMem(asm.R9, asm.R0, 0, asm.DWord),
+		asm.FnKtimeGetNs.Call(),
+		asm.Sub.Reg(asm.R0, asm.R9),
+		asm.JLT.Imm(asm.R0, dedupIntervalNanoseconds, "exit"),
+
+		asm.FnKtimeGetNs.Call().WithSymbol("output_ringbuf"),
+		asm.StoreMem(asm.RFP, stackOffScratch, asm.R0, asm.DWord),
+		asm.LoadMapPtr(asm.R1, b.seen.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, stackOffEvent),
+		asm.Mov.Reg(asm.R3, asm.RFP),
+		asm.Add.Imm(asm.R3, stackOffScratch),
+		asm.Mov.Imm(asm.R4, 0), // BPF_ANY
+		asm.FnMapUpdateElem.Call(),
+
+		asm.LoadMapPtr(asm.R1, b.events.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, stackOffEvent),
+		asm.Mov.Imm(asm.R3, eventSize),
+		asm.Mov.Imm(asm.R4, 0),
+		asm.FnRingbufOutput.Call(),
+
+		asm.Mov.Imm(asm.R0, 0).WithSymbol("exit"),
+		asm.Return(),
+	)
+}
+
+func (b *programBuilder) load(name string) (*ebpf.Program, error) {
+	prog, err := ebpf.NewProgram(&ebpf.ProgramSpec{
+		Name:         name,
+		Type:         ebpf.Kprobe,
+		License:      "GPL",
+		Instructions: b.insns,
+	})
+	if err != nil {
+		return nil, errors.Errorf("failed loading eBPF program %s: %w", name, err)
+	}
+	return prog, nil
+}
+
+// buildSocketProgram builds a program for a function whose socket is its first argument, or its return value for
+// kretprobes.
+func buildSocketProgram(b *programBuilder, regs ptRegsOffsets, eventType EventType, fromReturnValue bool) {
+	b.zeroStack()
+	if fromReturnValue {
+		b.emit(
+			asm.LoadMem(asm.R6, asm.R1, regs.ret, asm.DWord),
+			asm.JEq.Imm(asm.R6, 0, "exit"),
+		)
+	} else {
+		b.emit(asm.LoadMem(asm.R6, asm.R1, regs.param1, asm.DWord))
+	}
+	b.readSocket()
+	b.output(eventType)
+}
+
+// buildUDPSendProgram builds a program for udp_sendmsg and udpv6_sendmsg, whose arguments are the socket and the
+// message.
+func buildUDPSendProgram(b *programBuilder, regs ptRegsOffsets) {
+	b.zeroStack()
+	b.emit(
+		asm.LoadMem(asm.R6, asm.R1, regs.param1, asm.DWord),
+		asm.LoadMem(asm.R7, asm.R1, regs.param2, asm.DWord),
+	)
+	b.readSocket()
+	b.readMsgName()
+	b.output(EventTypeUDPSend)
+}
@@ -0,0 +1,163 @@
+package ebpftracer
+
+import (
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/link"
+	"github.com/cilium/ebpf/ringbuf"
+	"github.com/cilium/ebpf/rlimit"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"github.com/sirupsen/logrus"
+	"os"
+)
+
+const (
+	eventsRingBufferSize = 1 << 20
+	seenEventsMaxEntries = 16384
+)
+
+// Tracer traces the TCP connections and UDP datagrams of the node's processes with eBPF programs attached to the
+// kernel's tcp_connect, inet_csk_accept, udp_sendmsg and udpv6_sendmsg functions.
+type Tracer struct {
+	events   *ebpf.Map
+	seen     *ebpf.Map
+	programs []*ebpf.Program
+	links    []link.Link
+	reader   *ringbuf.Reader
+	recorder *EventRecorder
+}
+
+type probe struct {
+	symbol   string
+	build    func(b *programBuilder, regs ptRegsOffsets)
+	isReturn bool
+	// optional probes are skipped when their function does not exist, such as udpv6_sendmsg when IPv6 is disabled
+	optional bool
+}
+
+var probes = []probe{
+	{symbol: "tcp_connect", build: func(b *programBuilder, regs ptRegsOffsets) {
+		buildSocketProgram(b, regs, EventTypeConnect, false)
+	}},
+	{symbol: "inet_csk_accept", isReturn: true, build: func(b *programBuilder, regs ptRegsOffsets) {
+		buildSocketProgram(b, regs, EventTypeAccept, true)
+	}},
+	{symbol: "udp_sendmsg", build: buildUDPSendProgram},
+	{symbol: "udpv6_sendmsg", build: buildUDPSendProgram, optional: true},
+}
+
+// NewTracer loads and attaches the tracing programs. It fails on kernels without BTF, kprobes or BPF ring buffers
+// (Linux 5.8 and above), or without the privileges to load eBPF programs.
+func NewTracer() (tracer *Tracer, err error) {
+	regs, err := getPtRegsOffsets()
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	offsets, err := loadKernelOffsets()
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	if err := rlimit.RemoveMemlock(); err != nil {
+		return nil, errors.Wrap(err)
+	}
+
+	t := &Tracer{}
+	defer func() {
+		if err != nil {
+			t.Close()
+		}
+	}()
+
+	t.events, err = ebpf.NewMap(&ebpf.MapSpec{Name: "events", Type: ebpf.RingBuf, MaxEntries: eventsRingBufferSize})
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	t.seen, err = ebpf.NewMap(&ebpf.MapSpec{Name: "seen", Type: ebpf.LRUHash, KeySize: eventSize, ValueSize: 8, MaxEntries: seenEventsMaxEntries})
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+
+	for _, p := range probes {
+		b := &programBuilder{offsets: offsets, events: t.events, seen: t.seen}
+		p.build(b, regs)
+		prog, err := b.load(p.symbol)
+		if err != nil {
+			return nil, errors.Wrap(err)
+		}
+		t.programs = append(t.programs, prog)
+
+		var l link.Link
+		if p.isReturn {
+			l, err = link.Kretprobe(p.symbol, prog, nil)
+		} else {
+			l, err = link.Kprobe(p.symbol, prog, nil)
+		}
+		if err != nil && p.optional && errors.Is(err, os.ErrNotExist) {
+			logrus.WithError(err).Debugf("Kernel function %s not found, not tracing it", p.symbol)
+			continue
+		}
+		if err != nil {
+			return nil, errors.Errorf("failed attaching to kernel function %s: %w", p.symbol, err)
+		}
+		t.links = append(t.links, l)
+	}
+
+	t.reader, err = ringbuf.NewReader(t.events)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	return t, nil
+}
+
+// RecordEvents writes each raw event read by the tracer to recorder, to record event fixtures.
+func (t *Tracer) RecordEvents(recorder *EventRecorder) {
+	t.recorder = recorder
+}
+
+// CreateEventStream reads events until the tracer is closed.
+func (t *Tracer) CreateEventStream() chan Event {
+	events := make(chan Event, eventsRingBufferSize/eventSize)
+	go func() {
+		defer close(events)
+		for {
+			record, err := t.reader.Read()
+			if errors.Is(err, ringbuf.ErrClosed) {
+				return
+			}
+			if err != nil {
+				logrus.WithError(err).Error("Failed reading eBPF event")
+				continue
+			}
+
+			if t.recorder != nil {
+				if err := t.recorder.Record(record.RawSample); err != nil {
+					logrus.WithError(err).Warning("Failed recording eBPF event")
+				}
+			}
+			event, err := ParseEvent(record.RawSample)
+			if err != nil {
+				logrus.WithError(err).Debug("Failed parsing eBPF event")
+				continue
+			}
+			events <- event
+		}
+	}()
+	return events
+}
+
+func (t *Tracer) Close() {
+	if t.reader != nil {
+		_ = t.reader.Close()
+	}
+	for _, l := range t.links {
+		_ = l.Close()
+	}
+	for _, prog := range t.programs {
+		_ = prog.Close()
+	}
+	if t.seen != nil {
+		_ = t.seen.Close()
+	}
+	if t.events != nil {
+		_ = t.events.Close()
+	}
+}
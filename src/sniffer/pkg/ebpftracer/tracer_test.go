@@ -0,0 +1,98 @@
+package ebpftracer
+
+import (
+	"bytes"
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/rlimit"
+	"github.com/stretchr/testify/suite"
+	"net"
+	"strings"
+	"testing"
+)
+
+type TracerTestSuite struct {
+	suite.Suite
+}
+
+func (s *TracerTestSuite) TestRecordedEventsRoundTrip() {
+	events := []Event{
+		{CgroupID: 1000, PID: 100, Type: EventTypeConnect, LocalIP: net.ParseIP("10.244.0.5"), LocalPort: 40000, RemoteIP: net.ParseIP("10.96.0.20"), RemotePort: 8080},
+		{CgroupID: 1000, PID: 100, Type: EventTypeUDPSend, LocalIP: net.IPv4zero, RemoteIP: net.ParseIP("10.96.0.30"), RemotePort: 8125},
+		{CgroupID: 1001, PID: 101, Type: EventTypeAccept, LocalIP: net.ParseIP("fd00::5"), LocalPort: 443, RemoteIP: net.ParseIP("fd00::20"), RemotePort: 51000},
+	}
+
+	var recorded bytes.Buffer
+	recorder := NewEventRecorder(&recorded)
+	for _, event := range events {
+		raw, err := event.MarshalBinary()
+		s.Require().NoError(err)
+		s.Require().Len(raw, eventSize)
+		s.Require().NoError(recorder.Record(raw))
+	}
+
+	parsed, err := ReadEventFixtures(strings.NewReader("# recorded events\n\n" + recorded.String()))
+	s.Require().NoError(err)
+	s.Require().Len(parsed, len(events))
+	for i := range events {
+		s.Require().Equal(events[i].CgroupID, parsed[i].CgroupID)
+		s.Require().Equal(events[i].PID, parsed[i].PID)
+		s.Require().Equal(events[i].Type, parsed[i].Type)
+		s.Require().True(events[i].LocalIP.Equal(parsed[i].LocalIP))
+		s.Require().Equal(events[i].LocalPort, parsed[i].LocalPort)
+		s.Require().True(events[i].RemoteIP.Equal(parsed[i].RemoteIP))
+		s.Require().Equal(events[i].RemotePort, parsed[i].RemotePort)
+	}
+}
+
+func (s *TracerTestSuite) TestInvalidEvents() {
+	_, err := ParseEvent(make([]byte, eventSize-1))
+	s.Require().Error(err)
+
+	// the address families are unset
+	_, err = ParseEvent(make([]byte, eventSize))
+	s.Require().Error(err)
+
+	raw, err := Event{Type: EventType(42), LocalIP: net.IPv4zero, RemoteIP: net.IPv4zero}.MarshalBinary()
+	s.Require().NoError(err)
+	_, err = ParseEvent(raw)
+	s.Require().Error(err)
+
+	_, err = ReadEventFixtures(strings.NewReader("not hex\n"))
+	s.Require().Error(err)
+}
+
+// TestProgramsLoad verifies the kernel's verifier accepts the tracing programs. It requires BTF and the privileges to
+// load eBPF programs, and is skipped without them.
+func (s *TracerTestSuite) TestProgramsLoad() {
+	regs, err := getPtRegsOffsets()
+	if err != nil {
+		s.T().Skipf("Unsupported architecture: %s", err)
+	}
+	offsets, err := loadKernelOffsets()
+	if err != nil {
+		s.T().Skipf("Kernel BTF unavailable: %s", err)
+	}
+	if err := rlimit.RemoveMemlock(); err != nil {
+		s.T().Skipf("Cannot remove memlock limit: %s", err)
+	}
+	events, err := ebpf.NewMap(&ebpf.MapSpec{Name: "events", Type: ebpf.RingBuf, MaxEntries: eventsRingBufferSize})
+	if err != nil {
+		s.T().Skipf("Cannot create eBPF maps: %s", err)
+	}
+	defer events.Close()
+	seen, err := ebpf.NewMap(&ebpf.MapSpec{Name: "seen", Type: ebpf.LRUHash, KeySize: eventSize, ValueSize: 8, MaxEntries: seenEventsMaxEntries})
+	s.Require().NoError(err)
+	defer seen.Close()
+
+	for _, p := range probes {
+		b := &programBuilder{offsets: offsets, events: events, seen: seen}
+		p.build(b, regs)
+		prog, err := b.load(p.symbol)
+		s.Require().NoError(err, p.symbol)
+		s.Require().NoError(prog.Close())
+	}
+}
+
+func TestTracerTestSuite(t *testing.T) {
+	suite.Run(t, new(TracerTestSuite))
+}
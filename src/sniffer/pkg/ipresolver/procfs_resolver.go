@@ -14,7 +14,7 @@ type ProcFSIPResolverEntry struct {
 
 type ProcFSIPResolver struct {
 	byAddr  map[string]*ProcFSIPResolverEntry
-	byPid   map[int64]*ProcFSIPResolverEntry
+	byPid   map[int64][]*ProcFSIPResolverEntry
 	monitor *ProcessMonitor
 }
 
@@ -22,7 +22,7 @@ func NewProcFSIPResolver() *ProcFSIPResolver {
 	r := ProcFSIPResolver{
 		monitor: nil,
 		byAddr:  make(map[string]*ProcFSIPResolverEntry),
-		byPid:   make(map[int64]*ProcFSIPResolverEntry),
+		byPid:   make(map[int64][]*ProcFSIPResolverEntry),
 	}
 	r.monitor = NewProcessMonitor(r.onProcessNew, r.onProcessExit, utils.ScanProcDirProcesses)
 
@@ -41,26 +41,35 @@ func (r *ProcFSIPResolver) Refresh() error {
 }
 
 func (r *ProcFSIPResolver) onProcessNew(pid int64, pDir string) (err error) {
-	var hostname, ipaddr string
+	var hostname string
+	var ipaddrs []string
 	hostname, err = utils.ExtractProcessHostname(pDir)
 	if err != nil {
 		logrus.Debugf("Failed to extract hostname for process %d: %v", pid, err)
 		return errors.Wrap(err)
 	}
 
-	ipaddr, err = utils.ExtractProcessIPAddr(pDir)
+	// A process in a dual-stack pod has both an IPv4 and an IPv6 address, each of which is mapped to its hostname
+	ipaddrs, err = utils.ExtractProcessIPAddrs(pDir)
 	if err != nil {
 		logrus.Debugf("Failed to extract IP address for process %d: %v", pid, err)
 		return errors.Wrap(err)
 	}
 
+	for _, ipaddr := range ipaddrs {
+		r.addProcessMapping(pid, ipaddr, hostname)
+	}
+	return nil
+}
+
+func (r *ProcFSIPResolver) addProcessMapping(pid int64, ipaddr string, hostname string) {
 	if entry, ok := r.byAddr[ipaddr]; ok {
 		if entry.Hostname == hostname {
 			// Already mapped to this hostname, add another process reference
-			r.byPid[pid] = entry
+			r.byPid[pid] = append(r.byPid[pid], entry)
 			entry.ProcessRefCount++
 			logrus.Debugf("Mapping %s:%s already exists, increased refcount to %d", ipaddr, hostname, entry.ProcessRefCount)
-			return nil
+			return
 		} else {
 			// Shouldn't happen - it could happen if an ip replaces its pod very fast and the current single scan sees the new process and not the older one
 			logrus.Warnf("IP mapping conflict: %s got new hostname %s, but already mapped to %s. Would use the newer hostname", ipaddr, hostname, entry.Hostname)
@@ -74,23 +83,24 @@ func (r *ProcFSIPResolver) onProcessNew(pid int64, pDir string) (err error) {
 		Hostname:        hostname,
 		ProcessRefCount: 1,
 	}
-	r.byPid[pid] = newEntry
+	r.byPid[pid] = append(r.byPid[pid], newEntry)
 	r.byAddr[ipaddr] = newEntry
-	return nil
 }
 
 func (r *ProcFSIPResolver) onProcessExit(pid int64, _ string) error {
-	if entry, ok := r.byPid[pid]; !ok {
+	if entries, ok := r.byPid[pid]; !ok {
 		// Shouldn't happen
 		logrus.Debugf("Unknown process %d exited", pid)
 		return nil
 	} else {
-		entry.ProcessRefCount--
-		if entry.ProcessRefCount == 0 {
-			// Should remove mapping, but validate this process actually holds the newest mapping
-			if r.byAddr[entry.IPAddr] == entry {
-				logrus.Debugf("Removing IP mapping %s:%s", entry.IPAddr, entry.Hostname)
-				delete(r.byAddr, entry.IPAddr)
+		for _, entry := range entries {
+			entry.ProcessRefCount--
+			if entry.ProcessRefCount == 0 {
+				// Should remove mapping, but validate this process actually holds the newest mapping
+				if r.byAddr[entry.IPAddr] == entry {
+					logrus.Debugf("Removing IP mapping %s:%s", entry.IPAddr, entry.Hostname)
+					delete(r.byAddr, entry.IPAddr)
+				}
 			}
 		}
 
@@ -0,0 +1,79 @@
+package ipresolver
+
+import (
+	"bufio"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"io"
+	"net"
+	"os"
+	"strings"
+)
+
+// StaticIPResolver resolves IPs from a fixed mapping, used in place of ProcFSIPResolver when replaying captures taken on
+// another host.
+type StaticIPResolver struct {
+	byAddr map[string]string
+}
+
+func NewStaticIPResolver(mapping map[string]string) *StaticIPResolver {
+	byAddr := make(map[string]string, len(mapping))
+	for ipaddr, hostname := range mapping {
+		byAddr[normalizeIP(ipaddr)] = hostname
+	}
+	return &StaticIPResolver{byAddr: byAddr}
+}
+
+// NewStaticIPResolverFromFile reads a mapping in the format of /etc/hosts, with an IP followed by its hostname on each
+// line. Additional names on a line and comments starting with '#' are ignored.
+func NewStaticIPResolverFromFile(path string) (*StaticIPResolver, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	defer f.Close()
+
+	mapping, err := parseHostsMapping(f)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse hosts mapping file '%s': %w", path, err)
+	}
+	return NewStaticIPResolver(mapping), nil
+}
+
+func parseHostsMapping(r io.Reader) (map[string]string, error) {
+	mapping := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 2 || net.ParseIP(fields[0]) == nil {
+			return nil, errors.Errorf("line %d: expected an IP followed by a hostname", lineNumber)
+		}
+		mapping[fields[0]] = fields[1]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err)
+	}
+	return mapping, nil
+}
+
+func normalizeIP(ipaddr string) string {
+	ip := net.ParseIP(ipaddr)
+	if ip == nil {
+		return ipaddr
+	}
+	return ip.String()
+}
+
+func (r *StaticIPResolver) ResolveIP(ipaddr string) (hostname string, ok bool) {
+	hostname, ok = r.byAddr[normalizeIP(ipaddr)]
+	return hostname, ok
+}
+
+func (r *StaticIPResolver) Refresh() error {
+	return nil
+}
@@ -0,0 +1,46 @@
+package ipresolver
+
+import (
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStaticIPResolverFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts")
+	content := `# Pods captured on node-1
+10.244.0.5	client-7d9f8c-abcde
+10.244.0.12 orders-5c6b7d-fghij orders.shop.svc.cluster.local
+
+fd00::0012 orders-ipv6 # dual-stack address
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	resolver, err := NewStaticIPResolverFromFile(path)
+	require.NoError(t, err)
+	require.NoError(t, resolver.Refresh())
+
+	hostname, ok := resolver.ResolveIP("10.244.0.5")
+	require.True(t, ok)
+	require.Equal(t, "client-7d9f8c-abcde", hostname)
+
+	hostname, ok = resolver.ResolveIP("10.244.0.12")
+	require.True(t, ok)
+	require.Equal(t, "orders-5c6b7d-fghij", hostname)
+
+	hostname, ok = resolver.ResolveIP("fd00::12")
+	require.True(t, ok)
+	require.Equal(t, "orders-ipv6", hostname)
+
+	_, ok = resolver.ResolveIP("10.244.0.99")
+	require.False(t, ok)
+}
+
+func TestStaticIPResolverFromFileInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts")
+	require.NoError(t, os.WriteFile(path, []byte("client-7d9f8c-abcde 10.244.0.5\n"), 0600))
+
+	_, err := NewStaticIPResolverFromFile(path)
+	require.Error(t, err)
+}
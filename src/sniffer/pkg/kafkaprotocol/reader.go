@@ -0,0 +1,114 @@
+package kafkaprotocol
+
+import (
+	"encoding/binary"
+)
+
+// reader decodes Kafka protocol primitives. Reading past the end of the buffer sets truncated, after which all reads
+// return zero values, so callers only need to check truncated once they are done.
+type reader struct {
+	buf       []byte
+	flexible  bool
+	truncated bool
+}
+
+func (r *reader) take(n int) []byte {
+	if r.truncated || n < 0 || n > len(r.buf) {
+		r.truncated = true
+		return nil
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *reader) skip(n int) {
+	r.take(n)
+}
+
+func (r *reader) int8() int8 {
+	b := r.take(1)
+	if b == nil {
+		return 0
+	}
+	return int8(b[0])
+}
+
+func (r *reader) int16() int16 {
+	b := r.take(2)
+	if b == nil {
+		return 0
+	}
+	return int16(binary.BigEndian.Uint16(b))
+}
+
+func (r *reader) int32() int32 {
+	b := r.take(4)
+	if b == nil {
+		return 0
+	}
+	return int32(binary.BigEndian.Uint32(b))
+}
+
+func (r *reader) uvarint() uint64 {
+	if r.truncated {
+		return 0
+	}
+	v, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		r.truncated = true
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+// length reads the length of a string, bytes or array field, which is -1 for null. Flexible versions encode lengths
+// as unsigned varints of the length plus one.
+func (r *reader) length() int {
+	if !r.flexible {
+		return int(r.int32())
+	}
+	return int(r.uvarint()) - 1
+}
+
+func (r *reader) stringLength() int {
+	if !r.flexible {
+		return int(r.int16())
+	}
+	return int(r.uvarint()) - 1
+}
+
+// nullableString reads a string, returning false if it is null.
+func (r *reader) nullableString() (string, bool) {
+	n := r.stringLength()
+	if n < 0 {
+		return "", false
+	}
+	return string(r.take(n)), true
+}
+
+func (r *reader) string() string {
+	s, _ := r.nullableString()
+	return s
+}
+
+func (r *reader) skipBytes() {
+	if n := r.length(); n > 0 {
+		r.skip(n)
+	}
+}
+
+func (r *reader) arrayLength() int {
+	return r.length()
+}
+
+func (r *reader) skipTaggedFields() {
+	if !r.flexible {
+		return
+	}
+	for count := r.uvarint(); count > 0 && !r.truncated; count-- {
+		r.uvarint() // tag
+		r.skip(int(r.uvarint()))
+	}
+}
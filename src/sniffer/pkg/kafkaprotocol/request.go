@@ -0,0 +1,208 @@
+package kafkaprotocol
+
+import (
+	"encoding/binary"
+	"regexp"
+)
+
+type APIKey int16
+
+const (
+	APIKeyProduce  APIKey = 0
+	APIKeyFetch    APIKey = 1
+	APIKeyMetadata APIKey = 3
+)
+
+const (
+	// Requests larger than this are assumed to be misdetected, and match the broker's default socket.request.max.bytes.
+	maxRequestSize = 100 * 1024 * 1024
+	// Bounds used to tell the start of a request apart from the middle of one, for API keys that are not decoded.
+	maxAPIKey     = 100
+	maxAPIVersion = 20
+	// The size, API key, API version and correlation ID, followed by the client ID length.
+	minRequestHeaderSize = 4 + 2 + 2 + 4 + 2
+	// The replica ID of Fetch requests sent by consumers, rather than by follower brokers.
+	consumerReplicaID = -1
+)
+
+// Produce v13 and Fetch v13 identify topics by ID rather than by name, so they are not decoded.
+var maxDecodedVersions = map[APIKey]int16{
+	APIKeyProduce:  12,
+	APIKeyFetch:    12,
+	APIKeyMetadata: 12,
+}
+
+// The first version of each API using the flexible encoding, which has compact lengths and tagged fields.
+var firstFlexibleVersions = map[APIKey]int16{
+	APIKeyProduce:  9,
+	APIKeyFetch:    12,
+	APIKeyMetadata: 9,
+}
+
+var legalTopicName = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,249}$`)
+
+// Request is a Kafka request, with the names of the topics it refers to.
+type Request struct {
+	APIKey        APIKey
+	APIVersion    int16
+	CorrelationID int32
+	ClientID      string
+	Topics        []string
+}
+
+// ParseRequests decodes the Produce, Fetch and Metadata requests starting at the beginning of payload, which is the
+// payload of a TCP segment sent to a broker. Clients pipeline requests, so a segment may hold several of them, and large
+// requests span several segments - topics are decoded as far as the segment goes. Segments that do not start with a
+// request are ignored.
+func ParseRequests(payload []byte) []Request {
+	requests := make([]Request, 0)
+	for len(payload) >= minRequestHeaderSize {
+		size := int(int32(binary.BigEndian.Uint32(payload)))
+		if size < minRequestHeaderSize-4 || size > maxRequestSize {
+			break
+		}
+		end := min(len(payload), 4+size)
+		request, ok := parseRequest(payload[4:end])
+		if !ok {
+			break
+		}
+		if isDecoded(request) {
+			requests = append(requests, request)
+		}
+		payload = payload[end:]
+	}
+	return requests
+}
+
+func isDecoded(request Request) bool {
+	maxVersion, ok := maxDecodedVersions[request.APIKey]
+	return ok && request.APIVersion <= maxVersion
+}
+
+func parseRequest(buf []byte) (Request, bool) {
+	r := &reader{buf: buf}
+	request := Request{
+		APIKey:        APIKey(r.int16()),
+		APIVersion:    r.int16(),
+		CorrelationID: r.int32(),
+	}
+	if request.APIKey < 0 || request.APIKey > maxAPIKey || request.APIVersion < 0 || request.APIVersion > maxAPIVersion || request.CorrelationID < 0 {
+		return Request{}, false
+	}
+	// The client ID is never compact, even in flexible request headers.
+	request.ClientID, _ = r.nullableString()
+	if r.truncated {
+		return Request{}, false
+	}
+
+	if !isDecoded(request) {
+		// Requests that are not decoded are skipped, to decode the requests following them.
+		return request, true
+	}
+
+	r.flexible = request.APIVersion >= firstFlexibleVersions[request.APIKey]
+	r.skipTaggedFields()
+
+	var topics []string
+	switch request.APIKey {
+	case APIKeyProduce:
+		topics = parseProduceTopics(r, request.APIVersion)
+	case APIKeyFetch:
+		topics = parseFetchTopics(r, request.APIVersion)
+	case APIKeyMetadata:
+		topics = parseMetadataTopics(r, request.APIVersion)
+	}
+
+	for _, topic := range topics {
+		if !legalTopicName.MatchString(topic) {
+			return Request{}, false
+		}
+	}
+	request.Topics = topics
+	return request, true
+}
+
+// readTopics reads an array of topics, each made of a name followed by fields skipped by skipRest. It returns the
+// names read before the buffer ran out.
+func readTopics(r *reader, readName func() (string, bool), skipRest func()) []string {
+	topics := make([]string, 0)
+	for count := r.arrayLength(); count > 0; count-- {
+		name, ok := readName()
+		if r.truncated {
+			break
+		}
+		if ok {
+			topics = append(topics, name)
+		}
+		skipRest()
+		if r.truncated {
+			break
+		}
+		r.skipTaggedFields()
+	}
+	return topics
+}
+
+func parseProduceTopics(r *reader, version int16) []string {
+	if version >= 3 {
+		r.nullableString() // transactional_id
+	}
+	r.skip(2) // acks
+	r.skip(4) // timeout_ms
+
+	return readTopics(r, func() (string, bool) { return r.string(), true }, func() {
+		for count := r.arrayLength(); count > 0 && !r.truncated; count-- {
+			r.skip(4) // index
+			r.skipBytes()
+			r.skipTaggedFields()
+		}
+	})
+}
+
+func parseFetchTopics(r *reader, version int16) []string {
+	if r.int32() != consumerReplicaID {
+		// Replication between brokers is not a client of the broker.
+		return nil
+	}
+	r.skip(4) // max_wait_ms
+	r.skip(4) // min_bytes
+	if version >= 3 {
+		r.skip(4) // max_bytes
+	}
+	if version >= 4 {
+		r.skip(1) // isolation_level
+	}
+	if version >= 7 {
+		r.skip(4) // session_id
+		r.skip(4) // session_epoch
+	}
+
+	partitionSize := 4 + 8 + 4 // partition, fetch_offset, partition_max_bytes
+	if version >= 5 {
+		partitionSize += 8 // log_start_offset
+	}
+	if version >= 9 {
+		partitionSize += 4 // current_leader_epoch
+	}
+	if version >= 12 {
+		partitionSize += 4 // last_fetched_epoch
+	}
+
+	return readTopics(r, func() (string, bool) { return r.string(), true }, func() {
+		for count := r.arrayLength(); count > 0 && !r.truncated; count-- {
+			r.skip(partitionSize)
+			r.skipTaggedFields()
+		}
+	})
+}
+
+func parseMetadataTopics(r *reader, version int16) []string {
+	// A null array of topics (or an empty one, before version 1) requests all topics, which are not specific to the
+	// client.
+	return readTopics(r, func() (string, bool) {
+		if version >= 10 {
+			r.skip(16) // topic_id
+		}
+		return r.nullableString()
+	}, func() {})
+}
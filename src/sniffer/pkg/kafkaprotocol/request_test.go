@@ -0,0 +1,169 @@
+package kafkaprotocol
+
+import (
+	"encoding/binary"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+// encoder builds requests for the tests, using the same encoding as reader.
+type encoder struct {
+	buf      []byte
+	flexible bool
+}
+
+func (e *encoder) int8(v int8)   { e.buf = append(e.buf, byte(v)) }
+func (e *encoder) int16(v int16) { e.buf = binary.BigEndian.AppendUint16(e.buf, uint16(v)) }
+func (e *encoder) int32(v int32) { e.buf = binary.BigEndian.AppendUint32(e.buf, uint32(v)) }
+func (e *encoder) int64(v int64) { e.buf = binary.BigEndian.AppendUint64(e.buf, uint64(v)) }
+
+func (e *encoder) length(n int) {
+	if e.flexible {
+		e.buf = binary.AppendUvarint(e.buf, uint64(n+1))
+		return
+	}
+	e.int32(int32(n))
+}
+
+func (e *encoder) string(s string) {
+	if e.flexible {
+		e.buf = binary.AppendUvarint(e.buf, uint64(len(s)+1))
+	} else {
+		e.int16(int16(len(s)))
+	}
+	e.buf = append(e.buf, s...)
+}
+
+func (e *encoder) bytes(b []byte) {
+	e.length(len(b))
+	e.buf = append(e.buf, b...)
+}
+
+func (e *encoder) taggedFields() {
+	if e.flexible {
+		e.buf = append(e.buf, 0)
+	}
+}
+
+func header(apiKey APIKey, version int16, clientID string, flexible bool) *encoder {
+	e := &encoder{}
+	e.int16(int16(apiKey))
+	e.int16(version)
+	e.int32(7)
+	e.string(clientID)
+	e.flexible = flexible
+	e.taggedFields()
+	return e
+}
+
+func (e *encoder) request() []byte {
+	return append(binary.BigEndian.AppendUint32(nil, uint32(len(e.buf))), e.buf...)
+}
+
+func produceRequest(version int16, flexible bool, topics ...string) []byte {
+	e := header(APIKeyProduce, version, "checkout", flexible)
+	e.string("")   // transactional_id
+	e.int16(-1)    // acks
+	e.int32(30000) // timeout_ms
+	e.length(len(topics))
+	for _, topic := range topics {
+		e.string(topic)
+		e.length(1)
+		e.int32(0)
+		e.bytes(make([]byte, 100))
+		e.taggedFields()
+		e.taggedFields()
+	}
+	e.taggedFields()
+	return e.request()
+}
+
+func fetchRequest(version int16, replicaID int32, topics ...string) []byte {
+	e := header(APIKeyFetch, version, "checkout", version >= 12)
+	e.int32(replicaID)
+	e.int32(500)  // max_wait_ms
+	e.int32(1)    // min_bytes
+	e.int32(1024) // max_bytes
+	e.int8(0)     // isolation_level
+	e.int32(0)    // session_id
+	e.int32(-1)   // session_epoch
+	e.length(len(topics))
+	for _, topic := range topics {
+		e.string(topic)
+		e.length(2)
+		for partition := int32(0); partition < 2; partition++ {
+			e.int32(partition)
+			e.int32(0)  // current_leader_epoch
+			e.int64(0)  // fetch_offset
+			e.int32(-1) // last_fetched_epoch
+			e.int64(0)  // log_start_offset
+			e.int32(1024)
+			e.taggedFields()
+		}
+		e.taggedFields()
+	}
+	return e.request()
+}
+
+type RequestTestSuite struct {
+	suite.Suite
+}
+
+func (s *RequestTestSuite) TestProduce() {
+	requests := ParseRequests(produceRequest(8, false, "orders", "payments"))
+	s.Require().Equal([]Request{{APIKey: APIKeyProduce, APIVersion: 8, CorrelationID: 7, ClientID: "checkout", Topics: []string{"orders", "payments"}}}, requests)
+}
+
+func (s *RequestTestSuite) TestFlexibleProduce() {
+	requests := ParseRequests(produceRequest(9, true, "orders", "payments"))
+	s.Require().Len(requests, 1)
+	s.Require().Equal([]string{"orders", "payments"}, requests[0].Topics)
+}
+
+func (s *RequestTestSuite) TestFlexibleFetch() {
+	requests := ParseRequests(fetchRequest(12, consumerReplicaID, "orders", "payments"))
+	s.Require().Len(requests, 1)
+	s.Require().Equal([]string{"orders", "payments"}, requests[0].Topics)
+}
+
+func (s *RequestTestSuite) TestFollowerFetch() {
+	requests := ParseRequests(fetchRequest(12, 2, "orders"))
+	s.Require().Len(requests, 1)
+	s.Require().Empty(requests[0].Topics)
+}
+
+func (s *RequestTestSuite) TestTruncatedRequest() {
+	request := produceRequest(8, false, "orders", "payments")
+	// Cut in the middle of the records of the first topic.
+	requests := ParseRequests(request[:60])
+	s.Require().Len(requests, 1)
+	s.Require().Equal([]string{"orders"}, requests[0].Topics)
+}
+
+func (s *RequestTestSuite) TestPipelinedRequests() {
+	apiVersions := header(18, 3, "checkout", true).request()
+	payload := append(apiVersions, produceRequest(8, false, "orders")...)
+	payload = append(payload, fetchRequest(11, consumerReplicaID, "payments")...)
+
+	requests := ParseRequests(payload)
+	s.Require().Len(requests, 2)
+	s.Require().Equal(APIKeyProduce, requests[0].APIKey)
+	s.Require().Equal([]string{"orders"}, requests[0].Topics)
+	s.Require().Equal(APIKeyFetch, requests[1].APIKey)
+	s.Require().Equal([]string{"payments"}, requests[1].Topics)
+}
+
+func (s *RequestTestSuite) TestTopicIDVersionsAreSkipped() {
+	requests := ParseRequests(fetchRequest(13, consumerReplicaID, "orders"))
+	s.Require().Empty(requests)
+}
+
+func (s *RequestTestSuite) TestNotARequest() {
+	s.Require().Empty(ParseRequests([]byte("GET / HTTP/1.1\r\nHost: kafka\r\n\r\n")))
+	s.Require().Empty(ParseRequests(produceRequest(8, false, "not a topic!")))
+	s.Require().Empty(ParseRequests([]byte{0, 0}))
+}
+
+func TestRequestTestSuite(t *testing.T) {
+	suite.Run(t, new(RequestTestSuite))
+}
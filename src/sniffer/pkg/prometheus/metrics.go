@@ -14,6 +14,18 @@ var (
 		Name: "dns_reported_connections",
 		Help: "The total number of DNS-based reported connections",
 	})
+	tlsCaptureReports = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "tls_reported_connections",
+		Help: "The total number of TLS SNI-based reported connections",
+	})
+	kafkaCaptureReports = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "kafka_reported_topics",
+		Help: "The total number of Kafka protocol-based reported topics",
+	})
+	httpCaptureReports = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_reported_requests",
+		Help: "The total number of HTTP-based reported requests",
+	})
 )
 
 func IncrementSocketScanReports(count int) {
@@ -23,3 +35,15 @@ func IncrementSocketScanReports(count int) {
 func IncrementDNSCaptureReports(count int) {
 	dnsCaptureReports.Add(float64(count))
 }
+
+func IncrementTLSCaptureReports(count int) {
+	tlsCaptureReports.Add(float64(count))
+}
+
+func IncrementKafkaCaptureReports(count int) {
+	kafkaCaptureReports.Add(float64(count))
+}
+
+func IncrementHTTPCaptureReports(count int) {
+	httpCaptureReports.Add(float64(count))
+}
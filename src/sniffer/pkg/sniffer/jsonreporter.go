@@ -0,0 +1,65 @@
+package sniffer
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"github.com/otterize/network-mapper/src/mapperclient"
+	"io"
+	"sync"
+)
+
+const (
+	JSONReportTypeDNS        = "dns"
+	JSONReportTypeTCP        = "tcp"
+	JSONReportTypeTLS        = "tls"
+	JSONReportTypeSocketScan = "socket-scan"
+	JSONReportTypeKafka      = "kafka"
+	JSONReportTypeHTTP       = "http"
+)
+
+type JSONReport struct {
+	Type    string `json:"type"`
+	Results any    `json:"results"`
+}
+
+// JSONReporter writes each report as a JSON object on its own line rather than sending it to the mapper, to inspect
+// the results of replayed captures.
+type JSONReporter struct {
+	lock    sync.Mutex
+	encoder *json.Encoder
+}
+
+func NewJSONReporter(w io.Writer) *JSONReporter {
+	return &JSONReporter{encoder: json.NewEncoder(w)}
+}
+
+func (r *JSONReporter) write(reportType string, results any) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return errors.Wrap(r.encoder.Encode(JSONReport{Type: reportType, Results: results}))
+}
+
+func (r *JSONReporter) ReportCaptureResults(_ context.Context, results mapperclient.CaptureResults) error {
+	return r.write(JSONReportTypeDNS, results.Results)
+}
+
+func (r *JSONReporter) ReportTCPCaptureResults(_ context.Context, results mapperclient.CaptureTCPResults) error {
+	return r.write(JSONReportTypeTCP, results.Results)
+}
+
+func (r *JSONReporter) ReportTLSCaptureResults(_ context.Context, results mapperclient.CaptureTLSResults) error {
+	return r.write(JSONReportTypeTLS, results.Results)
+}
+
+func (r *JSONReporter) ReportSocketScanResults(_ context.Context, results mapperclient.SocketScanResults) error {
+	return r.write(JSONReportTypeSocketScan, results.Results)
+}
+
+func (r *JSONReporter) ReportKafkaMapperResults(_ context.Context, results mapperclient.KafkaMapperResults) error {
+	return r.write(JSONReportTypeKafka, results.Results)
+}
+
+func (r *JSONReporter) ReportHTTPRequestResults(_ context.Context, results mapperclient.HTTPRequestResults) error {
+	return r.write(JSONReportTypeHTTP, results.Results)
+}
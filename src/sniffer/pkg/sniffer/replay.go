@@ -0,0 +1,142 @@
+package sniffer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/otterize/intents-operator/src/shared/errors"
+	"github.com/otterize/network-mapper/src/shared/reportqueue"
+	"github.com/otterize/network-mapper/src/sniffer/pkg/collectors"
+	"github.com/otterize/network-mapper/src/sniffer/pkg/ipresolver"
+	"github.com/sirupsen/logrus"
+	"io"
+	"os"
+)
+
+// pcapngMagic is the block type of the section header block every pcapng file starts with.
+var pcapngMagic = []byte{0x0a, 0x0d, 0x0d, 0x0a}
+
+// NewReplaySniffer creates a sniffer that handles packets read from capture files rather than live traffic. Captured
+// IPs are resolved to hostnames with resolver, or are reported without hostnames if it is nil.
+func NewReplaySniffer(mapperClient MapperReporter, resolver ipresolver.IPResolver, kafkaPorts []int, httpPorts []int) *Sniffer {
+	// Hostnames are resolved the same way they are when running on AWS, where the IPs of captured packets are verified
+	// against the resolver on the following refresh.
+	resolveHostnames := resolver != nil
+
+	s := &Sniffer{
+		dnsSniffer:    collectors.NewDNSSniffer(resolver, resolveHostnames),
+		tcpSniffer:    collectors.NewTCPSniffer(resolver, resolveHostnames),
+		tlsSniffer:    collectors.NewTLSSniffer(resolver, resolveHostnames),
+		kafkaSniffer:  collectors.NewKafkaSniffer(kafkaPorts),
+		httpSniffer:   collectors.NewHTTPSniffer(httpPorts),
+		socketScanner: collectors.NewSocketScanner(),
+		ebpfCollector: collectors.NewEBPFCollector(),
+		mapperClient:  mapperClient,
+	}
+	// Replayed results are reported once, and are not persisted alongside the results of live captures.
+	s.initReportQueues(func(name string) reportqueue.Options {
+		options := reportQueueOptions(name)
+		options.FilePath = ""
+		return options
+	})
+	return s
+}
+
+// Replay handles the packets of each of the .pcap or .pcapng files at paths in turn, and reports the results once all
+// files were read.
+func (s *Sniffer) Replay(ctx context.Context, paths []string) error {
+	for _, path := range paths {
+		if err := s.replayFile(ctx, path); err != nil {
+			return errors.Wrap(err)
+		}
+	}
+
+	if err := s.dnsSniffer.RefreshHostsMapping(); err != nil {
+		return errors.Wrap(err)
+	}
+	if err := s.tcpSniffer.RefreshHostsMapping(); err != nil {
+		return errors.Wrap(err)
+	}
+	s.tlsSniffer.VerifyPendingCaptures()
+	s.report()
+	for _, queue := range s.reportQueues() {
+		if err := queue.Flush(ctx); err != nil {
+			return errors.Wrap(err)
+		}
+	}
+	return nil
+}
+
+func (s *Sniffer) replayFile(ctx context.Context, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Wrap(err)
+	}
+	defer f.Close()
+
+	packetSource, err := newCaptureFilePacketSource(f)
+	if err != nil {
+		return errors.Errorf("failed to read capture file '%s': %w", path, err)
+	}
+
+	packetCount := 0
+	for {
+		if ctx.Err() != nil {
+			return errors.Wrap(ctx.Err())
+		}
+		// Packets are read one at a time rather than from the packet source's channel, which retries on read errors
+		// rather than returning them.
+		packet, err := packetSource.NextPacket()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return errors.Errorf("failed to read packet %d of capture file '%s': %w", packetCount+1, path, err)
+		}
+		s.handleReplayedPacket(packet)
+		packetCount++
+	}
+
+	logrus.Infof("Replayed %d packets from %s", packetCount, path)
+	return nil
+}
+
+// handleReplayedPacket passes a packet to each of the collectors it would have been captured by when sniffing live
+// traffic, where the packets each collector receives are selected by its capture filter.
+func (s *Sniffer) handleReplayedPacket(packet gopacket.Packet) {
+	s.dnsSniffer.HandlePacket(packet)
+	if collectors.IsTCPSYN(packet) {
+		s.tcpSniffer.HandlePacket(packet)
+	}
+	s.tlsSniffer.HandlePacket(packet)
+	if s.kafkaSniffer.Enabled() {
+		s.kafkaSniffer.HandlePacket(packet)
+	}
+	if s.httpSniffer.Enabled() {
+		s.httpSniffer.HandlePacket(packet)
+	}
+}
+
+func newCaptureFilePacketSource(r io.Reader) (*gopacket.PacketSource, error) {
+	reader := bufio.NewReader(r)
+	magic, err := reader.Peek(len(pcapngMagic))
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+
+	if bytes.Equal(magic, pcapngMagic) {
+		ngReader, err := pcapgo.NewNgReader(reader, pcapgo.DefaultNgReaderOptions)
+		if err != nil {
+			return nil, errors.Wrap(err)
+		}
+		return gopacket.NewPacketSource(ngReader, ngReader.LinkType()), nil
+	}
+
+	pcapReader, err := pcapgo.NewReader(reader)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	return gopacket.NewPacketSource(pcapReader, pcapReader.LinkType()), nil
+}
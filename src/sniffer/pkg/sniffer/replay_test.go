@@ -0,0 +1,140 @@
+package sniffer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/otterize/network-mapper/src/mapperclient"
+	"github.com/otterize/network-mapper/src/sniffer/pkg/ipresolver"
+	"github.com/otterize/nilable"
+	"github.com/stretchr/testify/suite"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type jsonReportLine struct {
+	Type    string          `json:"type"`
+	Results json.RawMessage `json:"results"`
+}
+
+type ReplayTestSuite struct {
+	suite.Suite
+	output *bytes.Buffer
+}
+
+func (s *ReplayTestSuite) SetupTest() {
+	s.output = &bytes.Buffer{}
+}
+
+func (s *ReplayTestSuite) reports() map[string]json.RawMessage {
+	reports := make(map[string]json.RawMessage)
+	decoder := json.NewDecoder(s.output)
+	for decoder.More() {
+		var line jsonReportLine
+		s.Require().NoError(decoder.Decode(&line))
+		reports[line.Type] = line.Results
+	}
+	return reports
+}
+
+func (s *ReplayTestSuite) writePcapng(packets ...[]byte) string {
+	path := filepath.Join(s.T().TempDir(), "capture.pcapng")
+	f, err := os.Create(path)
+	s.Require().NoError(err)
+	defer f.Close()
+
+	w, err := pcapgo.NewNgWriter(f, layers.LinkTypeEthernet)
+	s.Require().NoError(err)
+	for i, data := range packets {
+		ci := gopacket.CaptureInfo{
+			Timestamp:     time.Date(2024, 6, 10, 12, 0, i, 0, time.UTC),
+			CaptureLength: len(data),
+			Length:        len(data),
+		}
+		s.Require().NoError(w.WritePacket(ci, data))
+	}
+	s.Require().NoError(w.Flush())
+	return path
+}
+
+func (s *ReplayTestSuite) tcpPacket(srcIP string, srcPort int, dstIP string, dstPort int, syn bool, ack bool) []byte {
+	eth := &layers.Ethernet{
+		SrcMAC:       net.HardwareAddr{0, 0, 0, 0, 0, 1},
+		DstMAC:       net.HardwareAddr{0, 0, 0, 0, 0, 2},
+		EthernetType: layers.EthernetTypeIPv4,
+	}
+	ip := &layers.IPv4{Version: 4, TTL: 64, Protocol: layers.IPProtocolTCP, SrcIP: net.ParseIP(srcIP), DstIP: net.ParseIP(dstIP)}
+	tcp := &layers.TCP{SrcPort: layers.TCPPort(srcPort), DstPort: layers.TCPPort(dstPort), SYN: syn, ACK: ack, Window: 64240}
+	s.Require().NoError(tcp.SetNetworkLayerForChecksum(ip))
+
+	buf := gopacket.NewSerializeBuffer()
+	s.Require().NoError(gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}, eth, ip, tcp))
+	return buf.Bytes()
+}
+
+func (s *ReplayTestSuite) TestReplayPcapngWithHostsMapping() {
+	path := s.writePcapng(
+		s.tcpPacket("10.0.2.48", 55613, "10.244.120.78", 8000, true, false),
+		// The server's reply, which the live capture filter does not select
+		s.tcpPacket("10.244.120.78", 8000, "10.0.2.48", 55613, true, true),
+	)
+	resolver := ipresolver.NewStaticIPResolver(map[string]string{"10.0.2.48": "client-1"})
+
+	sniffer := NewReplaySniffer(NewJSONReporter(s.output), resolver, nil, nil)
+	s.Require().NoError(sniffer.Replay(context.Background(), []string{path}))
+
+	reports := s.reports()
+	s.Require().Contains(reports, JSONReportTypeTCP)
+	var results []mapperclient.RecordedDestinationsForSrc
+	s.Require().NoError(json.Unmarshal(reports[JSONReportTypeTCP], &results))
+	s.Require().Equal([]mapperclient.RecordedDestinationsForSrc{
+		{
+			SrcIp:       "10.0.2.48",
+			SrcHostname: "client-1",
+			Destinations: []mapperclient.Destination{
+				{
+					Destination:     "10.244.120.78",
+					DestinationIP:   nilable.From("10.244.120.78"),
+					DestinationPort: nilable.From(8000),
+					SrcPorts:        []int{55613},
+					Protocol:        nilable.From(mapperclient.TransportProtocolTcp),
+					LastSeen:        time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+	}, results)
+}
+
+func (s *ReplayTestSuite) TestReplayPcapKafka() {
+	path := filepath.Join("..", "collectors", "testdata", "kafka_v2_8.pcap")
+
+	sniffer := NewReplaySniffer(NewJSONReporter(s.output), nil, []int{9092}, nil)
+	s.Require().NoError(sniffer.Replay(context.Background(), []string{path}))
+
+	reports := s.reports()
+	s.Require().Contains(reports, JSONReportTypeKafka)
+	var results []mapperclient.KafkaMapperResult
+	s.Require().NoError(json.Unmarshal(reports[JSONReportTypeKafka], &results))
+	s.Require().Len(results, 3)
+	for _, result := range results {
+		s.Require().Equal("10.244.0.27", result.SrcIp)
+	}
+}
+
+func (s *ReplayTestSuite) TestReplayInvalidFile() {
+	path := filepath.Join(s.T().TempDir(), "capture.pcap")
+	s.Require().NoError(os.WriteFile(path, []byte("not a capture file"), 0600))
+
+	sniffer := NewReplaySniffer(NewJSONReporter(s.output), nil, nil, nil)
+	s.Require().Error(sniffer.Replay(context.Background(), []string{path}))
+}
+
+func TestReplayTestSuite(t *testing.T) {
+	suite.Run(t, new(ReplayTestSuite))
+}
@@ -2,106 +2,230 @@ package sniffer
 
 import (
 	"context"
+	"github.com/google/gopacket"
 	"github.com/otterize/intents-operator/src/shared/errors"
 	"github.com/otterize/network-mapper/src/mapperclient"
+	sharedconfig "github.com/otterize/network-mapper/src/shared/config"
 	"github.com/otterize/network-mapper/src/shared/isrunningonaws"
+	"github.com/otterize/network-mapper/src/shared/reportqueue"
 	"github.com/otterize/network-mapper/src/sniffer/pkg/collectors"
 	"github.com/otterize/network-mapper/src/sniffer/pkg/config"
+	"github.com/otterize/network-mapper/src/sniffer/pkg/ebpftracer"
 	"github.com/otterize/network-mapper/src/sniffer/pkg/ipresolver"
 	"github.com/otterize/network-mapper/src/sniffer/pkg/prometheus"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
+	"os"
 	"time"
 )
 
+// MapperReporter reports the sniffer's results, either to the mapper or, when replaying captures, to stdout.
+type MapperReporter interface {
+	ReportCaptureResults(ctx context.Context, results mapperclient.CaptureResults) error
+	ReportTCPCaptureResults(ctx context.Context, results mapperclient.CaptureTCPResults) error
+	ReportTLSCaptureResults(ctx context.Context, results mapperclient.CaptureTLSResults) error
+	ReportSocketScanResults(ctx context.Context, results mapperclient.SocketScanResults) error
+	ReportKafkaMapperResults(ctx context.Context, results mapperclient.KafkaMapperResults) error
+	ReportHTTPRequestResults(ctx context.Context, results mapperclient.HTTPRequestResults) error
+}
+
+type destinationsReportQueue = reportqueue.Queue[mapperclient.RecordedSrc, mapperclient.RecordedDestinationsForSrc]
+
+type reportQueue interface {
+	RunForever(ctx context.Context)
+	Flush(ctx context.Context) error
+}
+
 type Sniffer struct {
-	dnsSniffer     *collectors.DNSSniffer
-	socketScanner  *collectors.SocketScanner
-	tcpSniffer     *collectors.TCPSniffer
-	lastReportTime time.Time
-	mapperClient   *mapperclient.Client
+	dnsSniffer        *collectors.DNSSniffer
+	socketScanner     *collectors.SocketScanner
+	tcpSniffer        *collectors.TCPSniffer
+	tlsSniffer        *collectors.TLSSniffer
+	kafkaSniffer      *collectors.KafkaSniffer
+	httpSniffer       *collectors.HTTPSniffer
+	ebpfCollector     *collectors.EBPFCollector
+	lastReportTime    time.Time
+	mapperClient      MapperReporter
+	dnsReports        *destinationsReportQueue
+	tcpReports        *destinationsReportQueue
+	tlsReports        *destinationsReportQueue
+	socketScanReports *destinationsReportQueue
+	kafkaReports      *reportqueue.Queue[mapperclient.KafkaMapperResult, mapperclient.KafkaMapperResult]
+	httpReports       *reportqueue.Queue[mapperclient.HTTPRequestResult, mapperclient.HTTPRequestResult]
 }
 
-func NewSniffer(mapperClient *mapperclient.Client) *Sniffer {
+func NewSniffer(mapperClient MapperReporter, kafkaPorts []int, httpPorts []int) *Sniffer {
 	procFSIPResolver := ipresolver.NewProcFSIPResolver()
 	isRunningOnAws := isrunningonaws.Check()
 
-	return &Sniffer{
+	s := &Sniffer{
 		dnsSniffer:    collectors.NewDNSSniffer(procFSIPResolver, isRunningOnAws),
 		tcpSniffer:    collectors.NewTCPSniffer(procFSIPResolver, isRunningOnAws),
+		tlsSniffer:    collectors.NewTLSSniffer(procFSIPResolver, isRunningOnAws),
+		kafkaSniffer:  collectors.NewKafkaSniffer(kafkaPorts),
+		httpSniffer:   collectors.NewHTTPSniffer(httpPorts),
 		socketScanner: collectors.NewSocketScanner(),
+		ebpfCollector: collectors.NewEBPFCollector(),
 		mapperClient:  mapperClient,
 	}
+	s.initReportQueues(reportQueueOptions)
+	return s
+}
+
+func reportQueueOptions(name string) reportqueue.Options {
+	options := reportqueue.OptionsFromConfig(name)
+	options.SendTimeout = viper.GetDuration(config.CallsTimeoutKey)
+	return options
 }
 
-func (s *Sniffer) reportCaptureResults(ctx context.Context) {
+// initReportQueues creates the queues results are held in until they are reported to the mapper, which retry reports
+// that failed, such as while the mapper is restarting.
+func (s *Sniffer) initReportQueues(options func(name string) reportqueue.Options) {
+	s.dnsReports = reportqueue.New("dns", s.sendCaptureResults,
+		mapperclient.RecordedDestinationsForSrcKey, mapperclient.MergeRecordedDestinationsForSrc, options("dns"))
+	s.tcpReports = reportqueue.New("tcp", s.sendTCPCaptureResults,
+		mapperclient.RecordedDestinationsForSrcKey, mapperclient.MergeRecordedDestinationsForSrc, options("tcp"))
+	s.tlsReports = reportqueue.New("tls", s.sendTLSCaptureResults,
+		mapperclient.RecordedDestinationsForSrcKey, mapperclient.MergeRecordedDestinationsForSrc, options("tls"))
+	s.socketScanReports = reportqueue.New("socket-scan", s.sendSocketScanResults,
+		mapperclient.RecordedDestinationsForSrcKey, mapperclient.MergeRecordedDestinationsForSrc, options("socket-scan"))
+	s.kafkaReports = reportqueue.New("kafka", s.sendKafkaResults,
+		mapperclient.KafkaMapperResultKey, mapperclient.MergeKafkaMapperResults, options("kafka"))
+	s.httpReports = reportqueue.New("http", s.sendHTTPResults,
+		mapperclient.HTTPRequestResultKey, mapperclient.MergeHTTPRequestResults, options("http"))
+}
+
+func (s *Sniffer) reportQueues() []reportQueue {
+	return []reportQueue{s.socketScanReports, s.dnsReports, s.tcpReports, s.tlsReports, s.kafkaReports, s.httpReports}
+}
+
+func (s *Sniffer) reportCaptureResults() {
 	results := s.dnsSniffer.CollectResults()
 	if len(results) == 0 {
 		logrus.Debugf("No captured sniffed requests to report")
 		return
 	}
-	logrus.Debugf("Reporting captured requests of %d clients to Mapper", len(results))
-
-	go func() {
-		timeoutCtx, cancelFunc := context.WithTimeout(ctx, viper.GetDuration(config.CallsTimeoutKey))
-		defer cancelFunc()
+	logrus.Debugf("Queueing captured requests of %d clients for reporting to Mapper", len(results))
+	s.dnsReports.Enqueue(results, nil)
+}
 
-		err := s.mapperClient.ReportCaptureResults(timeoutCtx, mapperclient.CaptureResults{Results: results})
-		if err != nil {
-			logrus.WithError(err).Error("Failed to report capture results")
-			return
-		}
-		logrus.Debugf("Reported captured requests of %d clients to Mapper", len(results))
-		prometheus.IncrementDNSCaptureReports(len(results))
-	}()
+func (s *Sniffer) sendCaptureResults(ctx context.Context, results []mapperclient.RecordedDestinationsForSrc) error {
+	err := s.mapperClient.ReportCaptureResults(ctx, mapperclient.CaptureResults{Results: results})
+	if err != nil {
+		return errors.Wrap(err)
+	}
+	logrus.Debugf("Reported captured requests of %d clients to Mapper", len(results))
+	prometheus.IncrementDNSCaptureReports(len(results))
+	return nil
 }
 
-func (s *Sniffer) reportTCPCaptureResults(ctx context.Context) {
-	results := s.tcpSniffer.CollectResults()
+func (s *Sniffer) reportTCPCaptureResults() {
+	// Only one of the TCP sniffer and the eBPF collector captures connections, depending on the connection tracer
+	results := append(s.tcpSniffer.CollectResults(), s.ebpfCollector.CollectResults()...)
 	if len(results) == 0 {
 		logrus.Debugf("No TCP captured sniffed requests to report")
 		return
 	}
-	logrus.Debugf("Reporting TCP captured requests of %d clients to Mapper", len(results))
+	logrus.Debugf("Queueing TCP captured requests of %d clients for reporting to Mapper", len(results))
+	s.tcpReports.Enqueue(results, nil)
+}
 
-	go func() {
-		timeoutCtx, cancelFunc := context.WithTimeout(ctx, viper.GetDuration(config.CallsTimeoutKey))
-		defer cancelFunc()
+func (s *Sniffer) sendTCPCaptureResults(ctx context.Context, results []mapperclient.RecordedDestinationsForSrc) error {
+	err := s.mapperClient.ReportTCPCaptureResults(ctx, mapperclient.CaptureTCPResults{Results: results})
+	if err != nil {
+		return errors.Wrap(err)
+	}
+	logrus.Debugf("Reported TCP captured requests of %d clients to Mapper", len(results))
+	return nil
+}
 
-		logrus.Debugf("Reporting TCP captured requests of %d clients to Mapper", len(results))
-		err := s.mapperClient.ReportTCPCaptureResults(timeoutCtx, mapperclient.CaptureTCPResults{Results: results})
-		if err != nil {
-			logrus.WithError(err).Error("Failed to report capture results")
-		}
-	}()
+func (s *Sniffer) reportTLSCaptureResults() {
+	results := s.tlsSniffer.CollectResults()
+	if len(results) == 0 {
+		logrus.Debugf("No TLS server names to report")
+		return
+	}
+	logrus.Debugf("Queueing TLS server names of %d clients for reporting to Mapper", len(results))
+	s.tlsReports.Enqueue(results, nil)
 }
 
-func (s *Sniffer) reportSocketScanResults(ctx context.Context) {
+func (s *Sniffer) sendTLSCaptureResults(ctx context.Context, results []mapperclient.RecordedDestinationsForSrc) error {
+	err := s.mapperClient.ReportTLSCaptureResults(ctx, mapperclient.CaptureTLSResults{Results: results})
+	if err != nil {
+		return errors.Wrap(err)
+	}
+	logrus.Debugf("Reported TLS server names of %d clients to Mapper", len(results))
+	prometheus.IncrementTLSCaptureReports(len(results))
+	return nil
+}
+
+func (s *Sniffer) reportSocketScanResults() {
 	results := s.socketScanner.CollectResults()
 	if len(results) == 0 {
 		logrus.Debugf("No socket scanned connections to report")
 		return
 	}
-	logrus.Debugf("Reporting scanned requests of %d clients to Mapper", len(results))
+	logrus.Debugf("Queueing scanned requests of %d clients for reporting to Mapper", len(results))
+	s.socketScanReports.Enqueue(results, nil)
+}
 
-	go func() {
-		timeoutCtx, cancelFunc := context.WithTimeout(ctx, viper.GetDuration(config.CallsTimeoutKey))
-		defer cancelFunc()
+func (s *Sniffer) sendSocketScanResults(ctx context.Context, results []mapperclient.RecordedDestinationsForSrc) error {
+	err := s.mapperClient.ReportSocketScanResults(ctx, mapperclient.SocketScanResults{Results: results})
+	if err != nil {
+		return errors.Wrap(err)
+	}
+	logrus.Debugf("Reported scanned requests of %d clients to Mapper", len(results))
+	prometheus.IncrementSocketScanReports(len(results))
+	return nil
+}
 
-		err := s.mapperClient.ReportSocketScanResults(timeoutCtx, mapperclient.SocketScanResults{Results: results})
-		if err != nil {
-			logrus.WithError(err).Error("Failed to report socket scan results")
-			return
-		}
-		logrus.Debugf("Reported scanned requests of %d clients to Mapper", len(results))
-		prometheus.IncrementSocketScanReports(len(results))
-	}()
+func (s *Sniffer) reportKafkaResults() {
+	results := s.kafkaSniffer.CollectResults()
+	if len(results) == 0 {
+		logrus.Debugf("No Kafka topic accesses to report")
+		return
+	}
+	logrus.Debugf("Queueing %d Kafka topic accesses for reporting to Mapper", len(results))
+	s.kafkaReports.Enqueue(results, nil)
 }
 
-func (s *Sniffer) report(ctx context.Context) {
-	s.reportSocketScanResults(ctx)
-	s.reportCaptureResults(ctx)
-	s.reportTCPCaptureResults(ctx)
+func (s *Sniffer) sendKafkaResults(ctx context.Context, results []mapperclient.KafkaMapperResult) error {
+	err := s.mapperClient.ReportKafkaMapperResults(ctx, mapperclient.KafkaMapperResults{Results: results})
+	if err != nil {
+		return errors.Wrap(err)
+	}
+	logrus.Debugf("Reported %d Kafka topic accesses to Mapper", len(results))
+	prometheus.IncrementKafkaCaptureReports(len(results))
+	return nil
+}
+
+func (s *Sniffer) reportHTTPResults() {
+	results := s.httpSniffer.CollectResults()
+	if len(results) == 0 {
+		logrus.Debugf("No HTTP requests to report")
+		return
+	}
+	logrus.Debugf("Queueing %d HTTP requests for reporting to Mapper", len(results))
+	s.httpReports.Enqueue(results, nil)
+}
+
+func (s *Sniffer) sendHTTPResults(ctx context.Context, results []mapperclient.HTTPRequestResult) error {
+	err := s.mapperClient.ReportHTTPRequestResults(ctx, mapperclient.HTTPRequestResults{Results: results})
+	if err != nil {
+		return errors.Wrap(err)
+	}
+	logrus.Debugf("Reported %d HTTP requests to Mapper", len(results))
+	prometheus.IncrementHTTPCaptureReports(len(results))
+	return nil
+}
+
+func (s *Sniffer) report() {
+	s.reportSocketScanResults()
+	s.reportCaptureResults()
+	s.reportTCPCaptureResults()
+	s.reportTLSCaptureResults()
+	s.reportKafkaResults()
+	s.reportHTTPResults()
 	s.lastReportTime = time.Now()
 }
 
@@ -110,15 +234,94 @@ func (s *Sniffer) getTimeTilNextReport() time.Duration {
 	return time.Until(nextReportTime)
 }
 
+// refreshTCPHostsMapping refreshes the resolver the TCP and TLS sniffers share, and verifies the pending captures of
+// both against it.
+func (s *Sniffer) refreshTCPHostsMapping() {
+	if err := s.tcpSniffer.RefreshHostsMapping(); err != nil {
+		logrus.WithError(err).Error("Failed to refresh ip->host resolving map for TCP")
+		return
+	}
+	s.tlsSniffer.VerifyPendingCaptures()
+}
+
+// createEBPFEventStream starts tracing connections with eBPF, until ctx is done.
+func (s *Sniffer) createEBPFEventStream(ctx context.Context) (chan ebpftracer.Event, error) {
+	tracer, err := ebpftracer.NewTracer()
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+
+	var recording *os.File
+	if path := viper.GetString(config.EBPFRecordEventsPathKey); path != "" {
+		recording, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			tracer.Close()
+			return nil, errors.Wrap(err)
+		}
+		tracer.RecordEvents(ebpftracer.NewEventRecorder(recording))
+	}
+
+	go func() {
+		<-ctx.Done()
+		tracer.Close()
+		if recording != nil {
+			_ = recording.Close()
+		}
+	}()
+	logrus.Info("Tracing connections with eBPF")
+	return tracer.CreateEventStream(), nil
+}
+
 func (s *Sniffer) RunForever(ctx context.Context) error {
+	for _, queue := range s.reportQueues() {
+		go queue.RunForever(ctx)
+	}
+
 	dnsPacketsChan, err := s.dnsSniffer.CreateDNSPacketStream()
 	if err != nil {
 		return errors.Wrap(err)
 	}
 
-	tcpPacketsChan, err := s.tcpSniffer.CreateTCPPacketStream()
-	if err != nil {
-		return errors.Wrap(err)
+	var ebpfEventsChan chan ebpftracer.Event
+	if viper.GetString(config.ConnectionTracerKey) == config.ConnectionTracerEBPF {
+		ebpfEventsChan, err = s.createEBPFEventStream(ctx)
+		if err != nil {
+			logrus.WithError(err).Warning("Failed to start eBPF connection tracer, falling back to capturing TCP SYN packets")
+		}
+	}
+
+	var tcpPacketsChan chan gopacket.Packet
+	if ebpfEventsChan == nil {
+		tcpPacketsChan, err = s.tcpSniffer.CreateTCPPacketStream()
+		if err != nil {
+			return errors.Wrap(err)
+		}
+	}
+
+	// Receiving from a nil channel blocks forever, so TLS, Kafka and HTTP packets are not handled when their sniffers
+	// are disabled.
+	var tlsPacketsChan chan gopacket.Packet
+	if viper.GetBool(sharedconfig.EnableTLSKey) {
+		tlsPacketsChan, err = s.tlsSniffer.CreateTLSPacketStream()
+		if err != nil {
+			return errors.Wrap(err)
+		}
+	}
+
+	var kafkaPacketsChan chan gopacket.Packet
+	if s.kafkaSniffer.Enabled() {
+		kafkaPacketsChan, err = s.kafkaSniffer.CreateKafkaPacketStream()
+		if err != nil {
+			return errors.Wrap(err)
+		}
+	}
+
+	var httpPacketsChan chan gopacket.Packet
+	if s.httpSniffer.Enabled() {
+		httpPacketsChan, err = s.httpSniffer.CreateHTTPPacketStream()
+		if err != nil {
+			return errors.Wrap(err)
+		}
 	}
 
 	for {
@@ -129,14 +332,24 @@ func (s *Sniffer) RunForever(ctx context.Context) error {
 			s.dnsSniffer.HandlePacket(packet)
 		case packet := <-tcpPacketsChan:
 			s.tcpSniffer.HandlePacket(packet)
+		case packet := <-tlsPacketsChan:
+			s.tlsSniffer.HandlePacket(packet)
+		case event, ok := <-ebpfEventsChan:
+			if !ok {
+				ebpfEventsChan = nil
+				continue
+			}
+			s.ebpfCollector.HandleEvent(event)
+		case packet := <-kafkaPacketsChan:
+			s.kafkaSniffer.HandlePacket(packet)
+		case packet := <-httpPacketsChan:
+			s.httpSniffer.HandlePacket(packet)
 		case <-time.After(s.dnsSniffer.GetTimeTilNextRefresh()):
 			if err := s.dnsSniffer.RefreshHostsMapping(); err != nil {
 				logrus.WithError(err).Error("Failed to refresh ip->host resolving map for DNS")
 			}
 		case <-time.After(s.tcpSniffer.GetTimeTilNextRefresh()):
-			if err := s.tcpSniffer.RefreshHostsMapping(); err != nil {
-				logrus.WithError(err).Error("Failed to refresh ip->host resolving map for TCP")
-			}
+			s.refreshTCPHostsMapping()
 		case <-time.After(s.getTimeTilNextReport()):
 			if err := s.socketScanner.ScanProcDir(); err != nil {
 				logrus.WithError(err).Error("Failed to scan proc dir for sockets")
@@ -145,11 +358,9 @@ func (s *Sniffer) RunForever(ctx context.Context) error {
 			if err := s.dnsSniffer.RefreshHostsMapping(); err != nil {
 				logrus.WithError(err).Error("Failed to refresh ip->host resolving map for DNS")
 			}
-			if err := s.tcpSniffer.RefreshHostsMapping(); err != nil {
-				logrus.WithError(err).Error("Failed to refresh ip->host resolving map for TCP")
-			}
-			// Actual server request is async, won't block packet handling
-			s.report(ctx)
+			s.refreshTCPHostsMapping()
+			// Results are queued and sent by the report queues, so reporting won't block packet handling
+			s.report()
 		}
 	}
 }
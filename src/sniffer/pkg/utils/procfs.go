@@ -1,10 +1,12 @@
 package utils
 
 import (
+	"encoding/hex"
 	"fmt"
 	"github.com/mpvl/unique"
 	"github.com/otterize/intents-operator/src/shared/errors"
 	"github.com/sirupsen/logrus"
+	"net"
 	"os"
 	"regexp"
 	"strconv"
@@ -87,6 +89,29 @@ func extractProcessHostnameUsingEnviron(pDir string) (string, error) {
 	return "", errors.Errorf("couldn't find hostname in %s/environ", pDir)
 }
 
+// ExtractProcessIPAddrs returns the process' IPv4 address and its global IPv6 address, whichever exist. It fails only if
+// neither was found, so that processes in IPv4-only, dual-stack and IPv6-only network namespaces are all supported.
+func ExtractProcessIPAddrs(pDir string) ([]string, error) {
+	ips := make([]string, 0)
+	ipv4Addr, ipv4Err := ExtractProcessIPAddr(pDir)
+	if ipv4Err == nil {
+		ips = append(ips, ipv4Addr)
+	}
+
+	ipv6Addr, found, err := ExtractProcessIPv6Addr(pDir)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	if found {
+		ips = append(ips, ipv6Addr)
+	}
+
+	if len(ips) == 0 {
+		return nil, errors.Wrap(ipv4Err)
+	}
+	return ips, nil
+}
+
 func ExtractProcessIPAddr(pDir string) (string, error) {
 	contentBytes, err := os.ReadFile(fmt.Sprintf("%s/net/fib_trie", pDir))
 	if err != nil {
@@ -118,3 +143,73 @@ func ExtractProcessIPAddr(pDir string) (string, error) {
 
 	return ips[0], nil
 }
+
+const (
+	ifInet6ScopeGlobal = "00"
+	ifInet6FieldsCount = 6
+)
+
+// ExtractProcessIPv6Addr returns the global-scope IPv6 address of the process' network namespace, as listed in
+// net/if_inet6. Missing files are not an error, as IPv6 may be disabled on the node.
+func ExtractProcessIPv6Addr(pDir string) (string, bool, error) {
+	contentBytes, err := os.ReadFile(fmt.Sprintf("%s/net/if_inet6", pDir))
+	if os.IsNotExist(err) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, errors.Wrap(err)
+	}
+
+	ips := make([]string, 0)
+	for _, line := range strings.Split(string(contentBytes), "\n") {
+		// Format: <address in hex> <if index> <prefix length> <scope> <flags> <device name>
+		fields := strings.Fields(line)
+		if len(fields) != ifInet6FieldsCount || fields[3] != ifInet6ScopeGlobal {
+			continue
+		}
+		addrBytes, err := hex.DecodeString(fields[0])
+		if err != nil || len(addrBytes) != net.IPv6len {
+			continue
+		}
+		ip := net.IP(addrBytes)
+		if ip.IsLoopback() || ip.IsLinkLocalUnicast() {
+			continue
+		}
+		ips = append(ips, ip.String())
+	}
+	unique.Strings(&ips)
+
+	if len(ips) == 0 {
+		return "", false, nil
+	}
+	if len(ips) > 1 {
+		logrus.Warnf("Found multiple IPv6 addresses (%s) in %s", ips, pDir)
+	}
+
+	return ips[0], true, nil
+}
+
+// containerIDPattern matches the 64 hex digit container IDs container runtimes name cgroups by, such as
+// "cri-containerd-<id>.scope" and "docker/<id>".
+var containerIDPattern = regexp.MustCompile(`[0-9a-f]{64}`)
+
+// ExtractProcessContainerID returns the ID of the container the process runs in, from the path of its cgroup. Processes
+// that do not run in a container, such as the node's own services, have no container ID.
+func ExtractProcessContainerID(pDir string) (string, bool, error) {
+	contentBytes, err := os.ReadFile(fmt.Sprintf("%s/cgroup", pDir))
+	if err != nil {
+		return "", false, errors.Wrap(err)
+	}
+
+	// Format: <hierarchy ID>:<controllers>:<cgroup path>, with a single "0::<cgroup path>" line on cgroup v2 nodes
+	for _, line := range strings.Split(string(contentBytes), "\n") {
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if containerID := containerIDPattern.FindString(parts[2]); containerID != "" {
+			return containerID, true, nil
+		}
+	}
+	return "", false, nil
+}